@@ -2,6 +2,7 @@ package ast
 
 import (
 	"io"
+	"strconv"
 	"strings"
 
 	"github.com/ttakezawa/pgconverger/token"
@@ -51,8 +52,9 @@ func (tableName *TableName) SetSchema(schema string) {
 	tableName.SchemaIdentifier = &Identifier{
 		Token: token.Token{
 			Type:    token.Identifier,
-			Literal: `"` + schema + `"`,
+			Literal: QuoteIdentifier(schema),
 			Line:    0,
+			Value:   schema,
 		},
 		Value: schema,
 	}
@@ -84,9 +86,12 @@ type Identifier struct {
 
 func (identifier *Identifier) expressionNode() {}
 func (identifier *Identifier) WriteStringTo(w io.StringWriter) {
-	_, _ = w.WriteString(`"`)
-	_, _ = w.WriteString(identifier.Value)
-	_, _ = w.WriteString(`"`)
+	_, _ = w.WriteString(formatOptions(w).QuoteIdentifier(identifier.Value))
+}
+
+// QuoteIdentifier double-quotes name, doubling any embedded quotes.
+func QuoteIdentifier(name string) string {
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
 }
 
 type DataDefinition struct {
@@ -122,35 +127,508 @@ func (createSchemaStatement *CreateSchemaStatement) WriteStringTo(w io.StringWri
 // ] )
 // [ INHERITS ( parent_table [, ... ] ) ]
 // [ PARTITION BY { RANGE | LIST } ( { column_name | ( expression ) } [ COLLATE collation ] [ opclass ] [, ... ] ) ]
+// [ USING method ]
 // [ WITH ( storage_parameter [= value] [, ... ] ) | WITH OIDS | WITHOUT OIDS ]
 // [ ON COMMIT { PRESERVE ROWS | DELETE ROWS | DROP } ]
 // [ TABLESPACE tablespace_name ]
+//
+// CREATE TABLE table_name PARTITION OF parent_table [ ( column_definition [, ... ] ) ]
+// { FOR VALUES partition_bound_spec | DEFAULT }
+// [ PARTITION BY ... ] [ USING method ] [ WITH ( ... ) ] [ ON COMMIT ... ] [ TABLESPACE tablespace_name ]
+//
+// CREATE FOREIGN TABLE [ IF NOT EXISTS ] table_name ( [ column_definition [, ... ] ] )
+// [ INHERITS ( parent_table [, ... ] ) ]
+// SERVER server_name [ OPTIONS ( option 'value' [, ... ] ) ]
+//
+// CREATE FOREIGN TABLE table_name PARTITION OF parent_table [ ( column_definition [, ... ] ) ]
+// { FOR VALUES partition_bound_spec | DEFAULT }
+// SERVER server_name [ OPTIONS ( option 'value' [, ... ] ) ]
+//
+// OnCommit holds the action in upper case, such as "DELETE ROWS". WITHOUT
+// OIDS is accepted and dropped. Server is set for a foreign table only.
 type CreateTableStatement struct {
+	Temporary            bool
+	Unlogged             bool
+	Foreign              bool
+	IfNotExists          bool
 	TableName            *TableName
 	ColumnDefinitionList []*ColumnDefinition
+	TableConstraints     []*TableConstraint
+	Inherits             []*TableName
+	PartitionOf          *TableName
+	PartitionBound       *PartitionBound
+	PartitionBy          *PartitionBy
+	AccessMethod         *Identifier
+	StorageParameters    StorageParameters
+	OnCommit             string
+	Tablespace           *Identifier
+	Server               *Identifier
+	Options              GenericOptions
 }
 
 func (*CreateTableStatement) statementNode() {}
 
 func (createTableStatement *CreateTableStatement) WriteStringTo(w io.StringWriter) {
-	_, _ = w.WriteString("CREATE TABLE ")
+	_, _ = w.WriteString("CREATE ")
+	if createTableStatement.Temporary {
+		_, _ = w.WriteString("TEMPORARY ")
+	}
+	if createTableStatement.Unlogged {
+		_, _ = w.WriteString("UNLOGGED ")
+	}
+	if createTableStatement.Foreign {
+		_, _ = w.WriteString("FOREIGN ")
+	}
+	_, _ = w.WriteString("TABLE ")
+	if createTableStatement.IfNotExists {
+		_, _ = w.WriteString("IF NOT EXISTS ")
+	}
 	createTableStatement.TableName.WriteStringTo(w)
-	_, _ = w.WriteString(" (\n")
+	if createTableStatement.PartitionOf != nil {
+		_, _ = w.WriteString(" PARTITION OF ")
+		createTableStatement.PartitionOf.WriteStringTo(w)
+	}
 
-	for i, columnDefinition := range createTableStatement.ColumnDefinitionList {
-		_, _ = w.WriteString("    ")
-		columnDefinition.WriteStringTo(w)
-		if i < len(createTableStatement.ColumnDefinitionList)-1 {
-			_, _ = w.WriteString(",")
+	elements := len(createTableStatement.ColumnDefinitionList) + len(createTableStatement.TableConstraints)
+	if createTableStatement.PartitionOf == nil || elements > 0 {
+		_, _ = w.WriteString(" (\n")
+		for i, columnDefinition := range createTableStatement.ColumnDefinitionList {
+			_, _ = w.WriteString("    ")
+			columnDefinition.WriteStringTo(w)
+			if i < elements-1 {
+				_, _ = w.WriteString(",")
+			}
+			_, _ = w.WriteString("\n")
+		}
+		for i, tableConstraint := range createTableStatement.TableConstraints {
+			_, _ = w.WriteString("    ")
+			tableConstraint.writeDefinition(w)
+			if len(createTableStatement.ColumnDefinitionList)+i < elements-1 {
+				_, _ = w.WriteString(",")
+			}
+			_, _ = w.WriteString("\n")
+		}
+		_, _ = w.WriteString(")")
+	}
+
+	if len(createTableStatement.Inherits) > 0 {
+		_, _ = w.WriteString(" INHERITS (")
+		for i, parent := range createTableStatement.Inherits {
+			if i != 0 {
+				_, _ = w.WriteString(", ")
+			}
+			parent.WriteStringTo(w)
+		}
+		_, _ = w.WriteString(")")
+	}
+	if createTableStatement.PartitionBound != nil {
+		_, _ = w.WriteString(" ")
+		createTableStatement.PartitionBound.WriteStringTo(w)
+	}
+	if createTableStatement.PartitionBy != nil {
+		_, _ = w.WriteString(" ")
+		createTableStatement.PartitionBy.WriteStringTo(w)
+	}
+	if createTableStatement.Server != nil {
+		_, _ = w.WriteString(" SERVER ")
+		createTableStatement.Server.WriteStringTo(w)
+	}
+	if len(createTableStatement.Options) > 0 {
+		_, _ = w.WriteString(" ")
+		createTableStatement.Options.WriteStringTo(w)
+	}
+	if createTableStatement.AccessMethod != nil {
+		_, _ = w.WriteString(" USING ")
+		createTableStatement.AccessMethod.WriteStringTo(w)
+	}
+	if len(createTableStatement.StorageParameters) > 0 {
+		_, _ = w.WriteString(" WITH ")
+		createTableStatement.StorageParameters.WriteStringTo(w)
+	}
+	if createTableStatement.OnCommit != "" {
+		_, _ = w.WriteString(" ON COMMIT " + createTableStatement.OnCommit)
+	}
+	if createTableStatement.Tablespace != nil {
+		_, _ = w.WriteString(" TABLESPACE ")
+		createTableStatement.Tablespace.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";\n")
+}
+
+// CREATE COLLATION [ IF NOT EXISTS ] name ( option = value [, ... ] )
+// CREATE COLLATION [ IF NOT EXISTS ] name FROM existing_collation
+//
+// The options are held as storage parameters, which have the same syntax.
+type CreateCollationStatement struct {
+	IfNotExists bool
+	Name        *TableName
+	Options     StorageParameters
+	From        Expression // Identifier or QualifiedName
+}
+
+func (*CreateCollationStatement) statementNode() {}
+
+func (createCollationStatement *CreateCollationStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("CREATE COLLATION ")
+	if createCollationStatement.IfNotExists {
+		_, _ = w.WriteString("IF NOT EXISTS ")
+	}
+	createCollationStatement.Name.WriteStringTo(w)
+	if createCollationStatement.From != nil {
+		_, _ = w.WriteString(" FROM ")
+		createCollationStatement.From.WriteStringTo(w)
+	} else {
+		_, _ = w.WriteString(" ")
+		createCollationStatement.Options.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";\n")
+}
+
+// CREATE POLICY name ON table_name
+//     [ AS { PERMISSIVE | RESTRICTIVE } ]
+//     [ FOR { ALL | SELECT | INSERT | UPDATE | DELETE } ]
+//     [ TO role_specification [, ...] ]
+//     [ USING ( using_expression ) ]
+//     [ WITH CHECK ( check_expression ) ]
+//
+// Command is empty for ALL, and Roles is empty when TO is omitted.
+type CreatePolicyStatement struct {
+	Name        *Identifier
+	Table       *TableName
+	Restrictive bool
+	Command     string
+	Roles       []*RoleSpecification
+	Using       Expression
+	WithCheck   Expression
+}
+
+func (*CreatePolicyStatement) statementNode() {}
+
+func (createPolicyStatement *CreatePolicyStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("CREATE POLICY ")
+	createPolicyStatement.Name.WriteStringTo(w)
+	_, _ = w.WriteString(" ON ")
+	createPolicyStatement.Table.WriteStringTo(w)
+	if createPolicyStatement.Restrictive {
+		_, _ = w.WriteString(" AS RESTRICTIVE")
+	}
+	if createPolicyStatement.Command != "" {
+		_, _ = w.WriteString(" FOR " + createPolicyStatement.Command)
+	}
+	writePolicyClauses(w, createPolicyStatement.Roles, createPolicyStatement.Using, createPolicyStatement.WithCheck)
+	_, _ = w.WriteString(";\n")
+}
+
+// ALTER POLICY name ON table_name RENAME TO new_name
+// ALTER POLICY name ON table_name
+//     [ TO role_specification [, ...] ]
+//     [ USING ( using_expression ) ]
+//     [ WITH CHECK ( check_expression ) ]
+type AlterPolicyStatement struct {
+	Name      *Identifier
+	Table     *TableName
+	NewName   *Identifier
+	Roles     []*RoleSpecification
+	Using     Expression
+	WithCheck Expression
+}
+
+func (*AlterPolicyStatement) statementNode() {}
+
+func (alterPolicyStatement *AlterPolicyStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER POLICY ")
+	alterPolicyStatement.Name.WriteStringTo(w)
+	_, _ = w.WriteString(" ON ")
+	alterPolicyStatement.Table.WriteStringTo(w)
+	if alterPolicyStatement.NewName != nil {
+		_, _ = w.WriteString(" RENAME TO ")
+		alterPolicyStatement.NewName.WriteStringTo(w)
+	}
+	writePolicyClauses(w, alterPolicyStatement.Roles, alterPolicyStatement.Using, alterPolicyStatement.WithCheck)
+	_, _ = w.WriteString(";\n")
+}
+
+func writePolicyClauses(w io.StringWriter, roles []*RoleSpecification, using, withCheck Expression) {
+	if len(roles) > 0 {
+		_, _ = w.WriteString(" TO ")
+		for i, role := range roles {
+			if i != 0 {
+				_, _ = w.WriteString(", ")
+			}
+			role.WriteStringTo(w)
+		}
+	}
+	if using != nil {
+		_, _ = w.WriteString(" USING (")
+		using.WriteStringTo(w)
+		_, _ = w.WriteString(")")
+	}
+	if withCheck != nil {
+		_, _ = w.WriteString(" WITH CHECK (")
+		withCheck.WriteStringTo(w)
+		_, _ = w.WriteString(")")
+	}
+}
+
+// role_name | PUBLIC | CURRENT_ROLE | CURRENT_USER | SESSION_USER
+//
+// Keyword holds PUBLIC, CURRENT_ROLE, CURRENT_USER or SESSION_USER when Name
+// is nil.
+type RoleSpecification struct {
+	Name    *Identifier
+	Keyword string
+}
+
+func (roleSpecification *RoleSpecification) WriteStringTo(w io.StringWriter) {
+	if roleSpecification.Name != nil {
+		roleSpecification.Name.WriteStringTo(w)
+	} else {
+		_, _ = w.WriteString(roleSpecification.Keyword)
+	}
+}
+
+// CREATE SERVER [ IF NOT EXISTS ] server_name [ TYPE 'server_type' ] [ VERSION 'server_version' ]
+//     FOREIGN DATA WRAPPER fdw_name
+//     [ OPTIONS ( option 'value' [, ... ] ) ]
+//
+// Type and Version are empty when omitted.
+type CreateServerStatement struct {
+	IfNotExists bool
+	Name        *Identifier
+	Type        string
+	Version     string
+	Wrapper     *Identifier
+	Options     GenericOptions
+}
+
+func (*CreateServerStatement) statementNode() {}
+
+func (createServerStatement *CreateServerStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("CREATE SERVER ")
+	if createServerStatement.IfNotExists {
+		_, _ = w.WriteString("IF NOT EXISTS ")
+	}
+	createServerStatement.Name.WriteStringTo(w)
+	if createServerStatement.Type != "" {
+		_, _ = w.WriteString(" TYPE '" + strings.Replace(createServerStatement.Type, "'", "''", -1) + "'")
+	}
+	if createServerStatement.Version != "" {
+		_, _ = w.WriteString(" VERSION '" + strings.Replace(createServerStatement.Version, "'", "''", -1) + "'")
+	}
+	_, _ = w.WriteString(" FOREIGN DATA WRAPPER ")
+	createServerStatement.Wrapper.WriteStringTo(w)
+	if len(createServerStatement.Options) > 0 {
+		_, _ = w.WriteString(" ")
+		createServerStatement.Options.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";\n")
+}
+
+// ALTER SERVER name [ VERSION 'new_version' ] [ OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] ) ]
+type AlterServerStatement struct {
+	Name    *Identifier
+	Version string
+	Options GenericOptions
+}
+
+func (*AlterServerStatement) statementNode() {}
+
+func (alterServerStatement *AlterServerStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER SERVER ")
+	alterServerStatement.Name.WriteStringTo(w)
+	if alterServerStatement.Version != "" {
+		_, _ = w.WriteString(" VERSION '" + strings.Replace(alterServerStatement.Version, "'", "''", -1) + "'")
+	}
+	if len(alterServerStatement.Options) > 0 {
+		_, _ = w.WriteString(" ")
+		alterServerStatement.Options.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";\n")
+}
+
+// CREATE USER MAPPING [ IF NOT EXISTS ] FOR { user_name | USER | CURRENT_ROLE | CURRENT_USER | PUBLIC | SESSION_USER }
+//     SERVER server_name
+//     [ OPTIONS ( option 'value' [ , ... ] ) ]
+//
+// USER is held as CURRENT_USER, which it means.
+type CreateUserMappingStatement struct {
+	IfNotExists bool
+	User        *RoleSpecification
+	Server      *Identifier
+	Options     GenericOptions
+}
+
+func (*CreateUserMappingStatement) statementNode() {}
+
+func (createUserMappingStatement *CreateUserMappingStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("CREATE USER MAPPING ")
+	if createUserMappingStatement.IfNotExists {
+		_, _ = w.WriteString("IF NOT EXISTS ")
+	}
+	writeUserMapping(w, createUserMappingStatement.User, createUserMappingStatement.Server)
+	if len(createUserMappingStatement.Options) > 0 {
+		_, _ = w.WriteString(" ")
+		createUserMappingStatement.Options.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";\n")
+}
+
+// ALTER USER MAPPING FOR { user_name | USER | CURRENT_ROLE | CURRENT_USER | PUBLIC | SESSION_USER }
+//     SERVER server_name
+//     OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] )
+type AlterUserMappingStatement struct {
+	User    *RoleSpecification
+	Server  *Identifier
+	Options GenericOptions
+}
+
+func (*AlterUserMappingStatement) statementNode() {}
+
+func (alterUserMappingStatement *AlterUserMappingStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER USER MAPPING ")
+	writeUserMapping(w, alterUserMappingStatement.User, alterUserMappingStatement.Server)
+	_, _ = w.WriteString(" ")
+	alterUserMappingStatement.Options.WriteStringTo(w)
+	_, _ = w.WriteString(";\n")
+}
+
+// DROP USER MAPPING [ IF EXISTS ] FOR { user_name | USER | CURRENT_ROLE | CURRENT_USER | PUBLIC | SESSION_USER }
+//     SERVER server_name
+type DropUserMappingStatement struct {
+	IfExists bool
+	User     *RoleSpecification
+	Server   *Identifier
+}
+
+func (*DropUserMappingStatement) statementNode() {}
+
+func (dropUserMappingStatement *DropUserMappingStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("DROP USER MAPPING ")
+	if dropUserMappingStatement.IfExists {
+		_, _ = w.WriteString("IF EXISTS ")
+	}
+	writeUserMapping(w, dropUserMappingStatement.User, dropUserMappingStatement.Server)
+	_, _ = w.WriteString(";\n")
+}
+
+func writeUserMapping(w io.StringWriter, user *RoleSpecification, server *Identifier) {
+	_, _ = w.WriteString("FOR ")
+	user.WriteStringTo(w)
+	_, _ = w.WriteString(" SERVER ")
+	server.WriteStringTo(w)
+}
+
+// IMPORT FOREIGN SCHEMA remote_schema
+//     [ { LIMIT TO | EXCEPT } ( table_name [, ...] ) ]
+//     FROM SERVER server_name
+//     INTO local_schema
+//     [ OPTIONS ( option 'value' [, ... ] ) ]
+//
+// Restriction holds LIMIT TO or EXCEPT, and is empty when omitted.
+type ImportForeignSchemaStatement struct {
+	RemoteSchema *Identifier
+	Restriction  string
+	Tables       []*Identifier
+	Server       *Identifier
+	LocalSchema  *Identifier
+	Options      GenericOptions
+}
+
+func (*ImportForeignSchemaStatement) statementNode() {}
+
+func (importForeignSchemaStatement *ImportForeignSchemaStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("IMPORT FOREIGN SCHEMA ")
+	importForeignSchemaStatement.RemoteSchema.WriteStringTo(w)
+	if importForeignSchemaStatement.Restriction != "" {
+		_, _ = w.WriteString(" " + importForeignSchemaStatement.Restriction + " (")
+		for i, table := range importForeignSchemaStatement.Tables {
+			if i != 0 {
+				_, _ = w.WriteString(", ")
+			}
+			table.WriteStringTo(w)
 		}
-		_, _ = w.WriteString("\n")
+		_, _ = w.WriteString(")")
+	}
+	_, _ = w.WriteString(" FROM SERVER ")
+	importForeignSchemaStatement.Server.WriteStringTo(w)
+	_, _ = w.WriteString(" INTO ")
+	importForeignSchemaStatement.LocalSchema.WriteStringTo(w)
+	if len(importForeignSchemaStatement.Options) > 0 {
+		_, _ = w.WriteString(" ")
+		importForeignSchemaStatement.Options.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";\n")
+}
+
+// PARTITION BY { RANGE | LIST | HASH } ( { column_name | ( expression ) } [ COLLATE collation ] [ opclass ] [, ... ] )
+type PartitionBy struct {
+	Strategy string
+	Keys     []*IndexTarget
+}
+
+func (partitionBy *PartitionBy) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("PARTITION BY " + partitionBy.Strategy + " (")
+	for i, key := range partitionBy.Keys {
+		if i != 0 {
+			_, _ = w.WriteString(", ")
+		}
+		key.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(")")
+}
+
+// FOR VALUES IN ( partition_bound_expr [, ...] ) |
+// FOR VALUES FROM ( { partition_bound_expr | MINVALUE | MAXVALUE } [, ...] )
+//   TO ( { partition_bound_expr | MINVALUE | MAXVALUE } [, ...] ) |
+// FOR VALUES WITH ( MODULUS numeric_literal, REMAINDER numeric_literal ) |
+// DEFAULT
+//
+// MINVALUE and MAXVALUE are held as KeywordFunction.
+type PartitionBound struct {
+	Default   bool
+	In        []Expression
+	From      []Expression
+	To        []Expression
+	Modulus   Expression
+	Remainder Expression
+}
+
+func (partitionBound *PartitionBound) WriteStringTo(w io.StringWriter) {
+	switch {
+	case partitionBound.Default:
+		_, _ = w.WriteString("DEFAULT")
+	case partitionBound.In != nil:
+		_, _ = w.WriteString("FOR VALUES IN (")
+		writeExpressionList(w, partitionBound.In)
+		_, _ = w.WriteString(")")
+	case partitionBound.From != nil:
+		_, _ = w.WriteString("FOR VALUES FROM (")
+		writeExpressionList(w, partitionBound.From)
+		_, _ = w.WriteString(") TO (")
+		writeExpressionList(w, partitionBound.To)
+		_, _ = w.WriteString(")")
+	default:
+		_, _ = w.WriteString("FOR VALUES WITH (MODULUS ")
+		partitionBound.Modulus.WriteStringTo(w)
+		_, _ = w.WriteString(", REMAINDER ")
+		partitionBound.Remainder.WriteStringTo(w)
+		_, _ = w.WriteString(")")
 	}
-	_, _ = w.WriteString(");\n")
 }
 
+// column_name data_type [ STORAGE { PLAIN | EXTERNAL | EXTENDED | MAIN | DEFAULT } ]
+// [ COMPRESSION compression_method ] [ OPTIONS ( option 'value' [, ... ] ) ]
+// [ COLLATE collation ] [ column_constraint [ ... ] ]
+//
+// Storage holds the storage mode in upper case and is empty when omitted.
+// Options are given to the columns of a foreign table.
 type ColumnDefinition struct {
 	Name           *Identifier
 	Type           DataType
+	Storage        string
+	Compression    *Identifier
+	Options        GenericOptions
+	Collation      Expression // Identifier or QualifiedName
 	ConstraintList []ColumnConstraint
 }
 
@@ -158,6 +636,21 @@ func (columnDefinition *ColumnDefinition) WriteStringTo(w io.StringWriter) {
 	columnDefinition.Name.WriteStringTo(w)
 	_, _ = w.WriteString(" ")
 	columnDefinition.Type.WriteStringTo(w)
+	if columnDefinition.Storage != "" {
+		_, _ = w.WriteString(" STORAGE " + columnDefinition.Storage)
+	}
+	if columnDefinition.Compression != nil {
+		_, _ = w.WriteString(" COMPRESSION ")
+		columnDefinition.Compression.WriteStringTo(w)
+	}
+	if len(columnDefinition.Options) > 0 {
+		_, _ = w.WriteString(" ")
+		columnDefinition.Options.WriteStringTo(w)
+	}
+	if columnDefinition.Collation != nil {
+		_, _ = w.WriteString(" COLLATE ")
+		columnDefinition.Collation.WriteStringTo(w)
+	}
 	for _, constraint := range columnDefinition.ConstraintList {
 		if _, ok := constraint.(*ColumnConstraintNull); ok {
 			// When Constraint Null, skip it.
@@ -204,20 +697,112 @@ func (columnConstraintDefault *ColumnConstraintDefault) WriteStringTo(w io.Strin
 	columnConstraintDefault.Expr.WriteStringTo(w)
 }
 
+// { column_name | ( expression ) } [ COLLATE collation ] [ opclass ] [ ASC | DESC ] [ NULLS { FIRST | LAST } ]
 type IndexTarget struct {
 	Node          Node
-	OperatorClass *token.Token
-	IsDesc        bool
+	Collation     Expression // Identifier or QualifiedName
+	OperatorClass Expression // Identifier or QualifiedName
+	// OperatorClassParameters are the parameters of OperatorClass, such as
+	// siglen.
+	OperatorClassParameters StorageParameters
+	IsDesc                  bool
+	NullsFirst              bool
+	NullsLast               bool
 }
 
 func (indexTarget *IndexTarget) WriteStringTo(w io.StringWriter) {
 	indexTarget.Node.WriteStringTo(w)
+	if indexTarget.Collation != nil {
+		_, _ = w.WriteString(" COLLATE ")
+		indexTarget.Collation.WriteStringTo(w)
+	}
 	if indexTarget.OperatorClass != nil {
-		_, _ = w.WriteString(" " + indexTarget.OperatorClass.Literal)
+		_, _ = w.WriteString(" ")
+		indexTarget.OperatorClass.WriteStringTo(w)
+		if indexTarget.OperatorClassParameters != nil {
+			_, _ = w.WriteString(" ")
+			indexTarget.OperatorClassParameters.WriteStringTo(w)
+		}
 	}
 	if indexTarget.IsDesc {
 		_, _ = w.WriteString(" DESC")
 	}
+	switch {
+	case indexTarget.NullsFirst:
+		_, _ = w.WriteString(" NULLS FIRST")
+	case indexTarget.NullsLast:
+		_, _ = w.WriteString(" NULLS LAST")
+	}
+}
+
+// ( storage_parameter [= value] [, ... ] )
+type StorageParameters []*StorageParameter
+
+func (storageParameters StorageParameters) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("(")
+	for i, storageParameter := range storageParameters {
+		if i != 0 {
+			_, _ = w.WriteString(", ")
+		}
+		storageParameter.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(")")
+}
+
+// [ namespace . ] name [ = value ]
+//
+// Value holds the value as PostgreSQL stores it in reloptions, so 70, '70'
+// and "70" are alike. HasValue is false for a bare name, which means true.
+type StorageParameter struct {
+	Namespace *Identifier
+	Name      *Identifier
+	Value     string
+	HasValue  bool
+}
+
+func (storageParameter *StorageParameter) WriteStringTo(w io.StringWriter) {
+	if storageParameter.Namespace != nil {
+		storageParameter.Namespace.WriteStringTo(w)
+		_, _ = w.WriteString(".")
+	}
+	storageParameter.Name.WriteStringTo(w)
+	if storageParameter.HasValue {
+		_, _ = w.WriteString("='" + strings.Replace(storageParameter.Value, "'", "''", -1) + "'")
+	}
+}
+
+// OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] )
+type GenericOptions []*GenericOption
+
+func (genericOptions GenericOptions) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("OPTIONS (")
+	for i, genericOption := range genericOptions {
+		if i != 0 {
+			_, _ = w.WriteString(", ")
+		}
+		genericOption.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(")")
+}
+
+// [ ADD | SET | DROP ] option [ 'value' ]
+//
+// Action is empty where options can only be added, as in CREATE statements.
+// A dropped option has no value.
+type GenericOption struct {
+	Action string
+	Name   *Identifier
+	Value  string
+}
+
+func (genericOption *GenericOption) WriteStringTo(w io.StringWriter) {
+	if genericOption.Action != "" {
+		_, _ = w.WriteString(genericOption.Action + " ")
+	}
+	genericOption.Name.WriteStringTo(w)
+	if genericOption.Action != "DROP" {
+		_, _ = w.WriteString(" '" + strings.Replace(genericOption.Value, "'", "''", -1) + "'")
+	}
 }
 
 type ColumnList struct {
@@ -236,22 +821,399 @@ func (columnList *ColumnList) WriteStringTo(w io.StringWriter) {
 }
 
 type InfixExpression struct {
-	Left     Expression
-	Operator token.Token
-	Right    Expression
+	Left           Expression
+	Operator       token.Token
+	OperatorSchema *Identifier // OPERATOR(schema.op)
+	Right          Expression
 }
 
 func (infixExpr *InfixExpression) expressionNode() {}
 func (infixExpr *InfixExpression) WriteStringTo(w io.StringWriter) {
 	infixExpr.Left.WriteStringTo(w)
-	if infixExpr.Operator.Type == token.Is {
-		_, _ = w.WriteString(" IS ")
+	if infixExpr.OperatorSchema != nil {
+		_, _ = w.WriteString(" ")
+		writeQualifiedOperator(w, infixExpr.OperatorSchema, infixExpr.Operator)
+		_, _ = w.WriteString(" ")
 	} else {
-		_, _ = w.WriteString(infixExpr.Operator.Literal)
+		writeOperator(w, infixExpr.Operator)
+		if !infixExpr.Operator.IsKeyword() && startsWithOperator(infixExpr.Right) {
+			_, _ = w.WriteString(" ")
+		}
 	}
 	infixExpr.Right.WriteStringTo(w)
 }
 
+// writeOperator writes a symbolic operator as is and a keyword operator such
+// as AND or OR in upper case surrounded by spaces.
+func writeOperator(w io.StringWriter, operator token.Token) {
+	switch {
+	case operator.Type == token.NotEquals:
+		_, _ = w.WriteString("<>")
+	case operator.IsKeyword():
+		_, _ = w.WriteString(" " + strings.ToUpper(operator.Literal) + " ")
+	default:
+		_, _ = w.WriteString(operator.Literal)
+	}
+}
+
+// OPERATOR(schema.op)
+func writeQualifiedOperator(w io.StringWriter, schema *Identifier, operator token.Token) {
+	_, _ = w.WriteString("OPERATOR(")
+	schema.WriteStringTo(w)
+	_, _ = w.WriteString(".")
+	writeOperator(w, operator)
+	_, _ = w.WriteString(")")
+}
+
+// startsWithOperator reports whether expr is written with a leading symbolic
+// operator, which must be separated from a preceding one so that the lexer
+// does not join them, e.g. "a - -1" written as "a--1" is a comment.
+func startsWithOperator(expr Expression) bool {
+	prefixExpr, ok := expr.(*PrefixExpression)
+	return ok && prefixExpr.OperatorSchema == nil && !prefixExpr.Operator.IsKeyword()
+}
+
+// -x, +x, NOT x, ~x, OPERATOR(schema.op) x
+type PrefixExpression struct {
+	Operator       token.Token
+	OperatorSchema *Identifier // OPERATOR(schema.op)
+	Right          Expression
+}
+
+func (prefixExpr *PrefixExpression) expressionNode() {}
+func (prefixExpr *PrefixExpression) WriteStringTo(w io.StringWriter) {
+	switch {
+	case prefixExpr.OperatorSchema != nil:
+		writeQualifiedOperator(w, prefixExpr.OperatorSchema, prefixExpr.Operator)
+		_, _ = w.WriteString(" ")
+	case prefixExpr.Operator.IsKeyword():
+		_, _ = w.WriteString(strings.ToUpper(prefixExpr.Operator.Literal) + " ")
+	default:
+		_, _ = w.WriteString(prefixExpr.Operator.Literal)
+		if startsWithOperator(prefixExpr.Right) {
+			_, _ = w.WriteString(" ")
+		}
+	}
+	prefixExpr.Right.WriteStringTo(w)
+}
+
+// expr::type
+type TypeCastExpression struct {
+	Expression Expression
+	Type       DataType
+}
+
+func (typeCastExpr *TypeCastExpression) expressionNode() {}
+func (typeCastExpr *TypeCastExpression) WriteStringTo(w io.StringWriter) {
+	typeCastExpr.Expression.WriteStringTo(w)
+	_, _ = w.WriteString("::")
+	typeCastExpr.Type.WriteStringTo(w)
+}
+
+// CAST ( expr AS type )
+type CastExpression struct {
+	Expression Expression
+	Type       DataType
+}
+
+func (castExpr *CastExpression) expressionNode() {}
+func (castExpr *CastExpression) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("CAST(")
+	castExpr.Expression.WriteStringTo(w)
+	_, _ = w.WriteString(" AS ")
+	castExpr.Type.WriteStringTo(w)
+	_, _ = w.WriteString(")")
+}
+
+// expr IS [ NOT ] { NULL | TRUE | FALSE | UNKNOWN }
+// expr IS [ NOT ] DISTINCT FROM expr
+// expr ISNULL, expr NOTNULL
+type IsExpression struct {
+	Expression   Expression
+	Not          bool
+	Test         token.Token // NULL, TRUE, FALSE, UNKNOWN or DISTINCT
+	DistinctFrom Expression
+}
+
+func (isExpr *IsExpression) expressionNode() {}
+func (isExpr *IsExpression) WriteStringTo(w io.StringWriter) {
+	isExpr.Expression.WriteStringTo(w)
+	_, _ = w.WriteString(" IS ")
+	if isExpr.Not {
+		_, _ = w.WriteString("NOT ")
+	}
+	_, _ = w.WriteString(strings.ToUpper(isExpr.Test.Literal))
+	if isExpr.DistinctFrom != nil {
+		_, _ = w.WriteString(" FROM ")
+		isExpr.DistinctFrom.WriteStringTo(w)
+	}
+}
+
+// expr [ NOT ] { LIKE | ILIKE | SIMILAR TO } pattern [ ESCAPE escape ]
+type LikeExpression struct {
+	Expression Expression
+	Not        bool
+	Operator   token.Token // LIKE, ILIKE or SIMILAR
+	Pattern    Expression
+	Escape     Expression
+}
+
+func (likeExpr *LikeExpression) expressionNode() {}
+func (likeExpr *LikeExpression) WriteStringTo(w io.StringWriter) {
+	likeExpr.Expression.WriteStringTo(w)
+	if likeExpr.Not {
+		_, _ = w.WriteString(" NOT")
+	}
+	_, _ = w.WriteString(" " + strings.ToUpper(likeExpr.Operator.Literal) + " ")
+	if likeExpr.Operator.Type == token.Similar {
+		_, _ = w.WriteString("TO ")
+	}
+	likeExpr.Pattern.WriteStringTo(w)
+	if likeExpr.Escape != nil {
+		_, _ = w.WriteString(" ESCAPE ")
+		likeExpr.Escape.WriteStringTo(w)
+	}
+}
+
+// expr [ NOT ] IN ( value [, ...] )
+type InExpression struct {
+	Expression Expression
+	Not        bool
+	List       []Expression
+}
+
+func (inExpr *InExpression) expressionNode() {}
+func (inExpr *InExpression) WriteStringTo(w io.StringWriter) {
+	inExpr.Expression.WriteStringTo(w)
+	if inExpr.Not {
+		_, _ = w.WriteString(" NOT")
+	}
+	_, _ = w.WriteString(" IN (")
+	writeExpressionList(w, inExpr.List)
+	_, _ = w.WriteString(")")
+}
+
+// expr [ NOT ] BETWEEN [ SYMMETRIC ] low AND high
+type BetweenExpression struct {
+	Expression Expression
+	Not        bool
+	Symmetric  bool
+	Low        Expression
+	High       Expression
+}
+
+func (betweenExpr *BetweenExpression) expressionNode() {}
+func (betweenExpr *BetweenExpression) WriteStringTo(w io.StringWriter) {
+	betweenExpr.Expression.WriteStringTo(w)
+	if betweenExpr.Not {
+		_, _ = w.WriteString(" NOT")
+	}
+	_, _ = w.WriteString(" BETWEEN ")
+	if betweenExpr.Symmetric {
+		_, _ = w.WriteString("SYMMETRIC ")
+	}
+	betweenExpr.Low.WriteStringTo(w)
+	_, _ = w.WriteString(" AND ")
+	betweenExpr.High.WriteStringTo(w)
+}
+
+// { ANY | SOME | ALL } ( array )
+type SubLinkExpression struct {
+	Quantifier token.Token
+	Expression Expression
+}
+
+func (subLinkExpr *SubLinkExpression) expressionNode() {}
+func (subLinkExpr *SubLinkExpression) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString(strings.ToUpper(subLinkExpr.Quantifier.Literal) + " (")
+	subLinkExpr.Expression.WriteStringTo(w)
+	_, _ = w.WriteString(")")
+}
+
+// CASE [ arg ] WHEN condition THEN result [ ... ] [ ELSE result ] END
+type CaseExpression struct {
+	Argument Expression
+	Whens    []*CaseWhen
+	Else     Expression
+}
+
+type CaseWhen struct {
+	Condition Expression
+	Result    Expression
+}
+
+func (caseExpr *CaseExpression) expressionNode() {}
+func (caseExpr *CaseExpression) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("CASE")
+	if caseExpr.Argument != nil {
+		_, _ = w.WriteString(" ")
+		caseExpr.Argument.WriteStringTo(w)
+	}
+	for _, when := range caseExpr.Whens {
+		_, _ = w.WriteString(" WHEN ")
+		when.Condition.WriteStringTo(w)
+		_, _ = w.WriteString(" THEN ")
+		when.Result.WriteStringTo(w)
+	}
+	if caseExpr.Else != nil {
+		_, _ = w.WriteString(" ELSE ")
+		caseExpr.Else.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(" END")
+}
+
+// ARRAY[ elem [, ...] ]
+type ArrayExpression struct {
+	Keyword  bool // false for the nested [ ... ] of a multidimensional array
+	Elements []Expression
+}
+
+func (arrayExpr *ArrayExpression) expressionNode() {}
+func (arrayExpr *ArrayExpression) WriteStringTo(w io.StringWriter) {
+	if arrayExpr.Keyword {
+		_, _ = w.WriteString("ARRAY")
+	}
+	_, _ = w.WriteString("[")
+	writeExpressionList(w, arrayExpr.Elements)
+	_, _ = w.WriteString("]")
+}
+
+// expr[ index ] or expr[ lower : upper ]
+type SubscriptExpression struct {
+	Expression Expression
+	Slice      bool
+	Lower      Expression
+	Upper      Expression
+}
+
+func (subscriptExpr *SubscriptExpression) expressionNode() {}
+func (subscriptExpr *SubscriptExpression) WriteStringTo(w io.StringWriter) {
+	subscriptExpr.Expression.WriteStringTo(w)
+	_, _ = w.WriteString("[")
+	if subscriptExpr.Lower != nil {
+		subscriptExpr.Lower.WriteStringTo(w)
+	}
+	if subscriptExpr.Slice {
+		_, _ = w.WriteString(":")
+	}
+	if subscriptExpr.Upper != nil {
+		subscriptExpr.Upper.WriteStringTo(w)
+	}
+	_, _ = w.WriteString("]")
+}
+
+// ROW ( expr [, ...] ) or ( expr, expr [, ...] )
+type RowExpression struct {
+	Keyword bool
+	Fields  []Expression
+}
+
+func (rowExpr *RowExpression) expressionNode() {}
+func (rowExpr *RowExpression) WriteStringTo(w io.StringWriter) {
+	if rowExpr.Keyword {
+		_, _ = w.WriteString("ROW")
+	}
+	_, _ = w.WriteString("(")
+	writeExpressionList(w, rowExpr.Fields)
+	_, _ = w.WriteString(")")
+}
+
+// expr COLLATE collation
+type CollateExpression struct {
+	Expression Expression
+	Collation  Expression // Identifier or QualifiedName
+}
+
+func (collateExpr *CollateExpression) expressionNode() {}
+func (collateExpr *CollateExpression) WriteStringTo(w io.StringWriter) {
+	collateExpr.Expression.WriteStringTo(w)
+	_, _ = w.WriteString(" COLLATE ")
+	collateExpr.Collation.WriteStringTo(w)
+}
+
+// expr AT TIME ZONE zone
+type AtTimeZoneExpression struct {
+	Expression Expression
+	Zone       Expression
+}
+
+func (atTimeZoneExpr *AtTimeZoneExpression) expressionNode() {}
+func (atTimeZoneExpr *AtTimeZoneExpression) WriteStringTo(w io.StringWriter) {
+	atTimeZoneExpr.Expression.WriteStringTo(w)
+	_, _ = w.WriteString(" AT TIME ZONE ")
+	atTimeZoneExpr.Zone.WriteStringTo(w)
+}
+
+// CURRENT_TIMESTAMP [ ( precision ) ], CURRENT_USER, ...
+type KeywordFunction struct {
+	Token     token.Token
+	Precision Expression
+}
+
+func (keywordFunction *KeywordFunction) expressionNode() {}
+func (keywordFunction *KeywordFunction) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString(strings.ToUpper(keywordFunction.Token.Literal))
+	if keywordFunction.Precision != nil {
+		_, _ = w.WriteString("(")
+		keywordFunction.Precision.WriteStringTo(w)
+		_, _ = w.WriteString(")")
+	}
+}
+
+// COALESCE(a, b), EXTRACT(field FROM a), SUBSTRING(a FROM n FOR m), ...,
+// the functions the grammar gives a syntax of their own. They are not
+// functions PostgreSQL can look up, so the name is written bare.
+type SpecialFunction struct {
+	Token     token.Token // The name
+	Modifier  string      // The field of EXTRACT, or BOTH, LEADING or TRAILING of TRIM
+	Arguments []Expression
+	Keywords  []string // The keyword before each argument, such as FROM, or "" after a comma
+}
+
+func (specialFunction *SpecialFunction) expressionNode() {}
+func (specialFunction *SpecialFunction) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString(strings.ToUpper(specialFunction.Token.Value) + "(" + specialFunction.Modifier)
+	for i, argument := range specialFunction.Arguments {
+		switch {
+		case specialFunction.Keywords[i] != "":
+			if i != 0 || specialFunction.Modifier != "" {
+				_, _ = w.WriteString(" ")
+			}
+			_, _ = w.WriteString(specialFunction.Keywords[i] + " ")
+		case i != 0:
+			_, _ = w.WriteString(", ")
+		case specialFunction.Modifier != "":
+			_, _ = w.WriteString(" ")
+		}
+		argument.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(")")
+}
+
+// schema.name or table.column
+type QualifiedName struct {
+	Identifiers []*Identifier
+}
+
+func (qualifiedName *QualifiedName) expressionNode() {}
+func (qualifiedName *QualifiedName) WriteStringTo(w io.StringWriter) {
+	for i, identifier := range qualifiedName.Identifiers {
+		if i != 0 {
+			_, _ = w.WriteString(".")
+		}
+		identifier.WriteStringTo(w)
+	}
+}
+
+func writeExpressionList(w io.StringWriter, list []Expression) {
+	for i, expr := range list {
+		if i != 0 {
+			_, _ = w.WriteString(", ")
+		}
+		expr.WriteStringTo(w)
+	}
+}
+
 type CallExpression struct {
 	Token     token.Token // The '(' token
 	Function  Expression  // Identifier or FunctionLiteral
@@ -276,17 +1238,61 @@ type StringLiteral struct {
 }
 
 func (il *StringLiteral) expressionNode() {}
+
+// WriteStringTo writes the literal in the canonical form PostgreSQL itself
+// uses when deparsing, so that 'a''b', E'a\'b' and 'a' 'b' across lines are
+// all written the same way.
 func (il *StringLiteral) WriteStringTo(w io.StringWriter) {
-	_, _ = w.WriteString(il.Token.Literal)
+	if il.IsBitString() {
+		_, _ = w.WriteString("B'" + il.Value() + "'")
+		return
+	}
+	_, _ = w.WriteString("'" + strings.Replace(il.Value(), "'", "''", -1) + "'")
+}
+
+// Value returns the decoded content of the literal.
+func (il *StringLiteral) Value() string {
+	return il.Token.Value
+}
+
+// IsBitString reports whether the literal is B'...' or X'...'.
+func (il *StringLiteral) IsBitString() bool {
+	return il.Token.Literal != "" && strings.ContainsRune("BbXx", rune(il.Token.Literal[0]))
 }
 
 type NumberLiteral struct {
 	Token token.Token
 }
 
-func (il *NumberLiteral) expressionNode() {}
-func (il *NumberLiteral) WriteStringTo(w io.StringWriter) {
-	_, _ = w.WriteString(il.Token.Literal)
+func (il *NumberLiteral) expressionNode() {}
+func (il *NumberLiteral) WriteStringTo(w io.StringWriter) {
+	switch formatOptions(w).Numeric {
+	case NumericNormalized:
+		_, _ = w.WriteString(il.Normalized())
+	case NumericValue:
+		_, _ = w.WriteString(il.Value())
+	default:
+		_, _ = w.WriteString(il.Token.Literal)
+	}
+}
+
+// Normalized returns the literal in decimal notation keeping its scale,
+// e.g. 500 for 5e2, 31 for 0x1F and 1.50 for 1.50.
+func (il *NumberLiteral) Normalized() string {
+	if il.Token.Value == "" {
+		return il.Token.Literal
+	}
+	return il.Token.Value
+}
+
+// Value returns Normalized without trailing zeros in the fraction, so that
+// 1.0 and 1.00 have the same value.
+func (il *NumberLiteral) Value() string {
+	normalized := il.Normalized()
+	if !strings.Contains(normalized, ".") {
+		return normalized
+	}
+	return strings.TrimSuffix(strings.TrimRight(normalized, "0"), ".")
 }
 
 type BooleanLiteral struct {
@@ -326,19 +1332,25 @@ func (groupedExpr *GroupedExpression) WriteStringTo(w io.StringWriter) {
 	_, _ = w.WriteString(")")
 }
 
-// CREATE [ UNIQUE ] INDEX [ CONCURRENTLY ] [ [ IF NOT EXISTS ] name ] ON table_name [ USING method ]
+// CREATE [ UNIQUE ] INDEX [ CONCURRENTLY ] [ [ IF NOT EXISTS ] name ] ON [ ONLY ] table_name [ USING method ]
 //     ( { column_name | ( expression ) } [ COLLATE collation ] [ opclass ] [ ASC | DESC ] [ NULLS { FIRST | LAST } ] [, ...] )
 //     [ WITH ( storage_parameter = value [, ... ] ) ]
 //     [ TABLESPACE tablespace_name ]
 //     [ WHERE predicate ]
 type CreateIndexStatement struct {
-	UniqueIndex  bool
-	Concurrently bool
-	IfNotExists  bool
-	Name         *Identifier
-	TableName    *TableName
-	UsingMethod  *Identifier
-	IndexTargets []*IndexTarget // Slice of (Identifier OR Expression)
+	UniqueIndex       bool
+	Concurrently      bool
+	IfNotExists       bool
+	Name              *Identifier
+	Only              bool
+	TableName         *TableName
+	UsingMethod       *Identifier
+	IndexTargets      []*IndexTarget // Slice of (Identifier OR Expression)
+	Include           []*Identifier
+	NullsNotDistinct  bool
+	StorageParameters StorageParameters
+	Tablespace        *Identifier
+	Where             Expression
 }
 
 func (*CreateIndexStatement) statementNode() {}
@@ -360,6 +1372,9 @@ func (createIndexStatement *CreateIndexStatement) WriteStringTo(w io.StringWrite
 		_, _ = w.WriteString(" ")
 	}
 	_, _ = w.WriteString("ON ")
+	if createIndexStatement.Only {
+		_, _ = w.WriteString("ONLY ")
+	}
 	createIndexStatement.TableName.WriteStringTo(w)
 	_, _ = w.WriteString(" ")
 	if createIndexStatement.UsingMethod != nil {
@@ -376,10 +1391,59 @@ func (createIndexStatement *CreateIndexStatement) WriteStringTo(w io.StringWrite
 			indexTarget.WriteStringTo(w)
 		}
 	}
-	_, _ = w.WriteString(");")
+	_, _ = w.WriteString(")")
+	if len(createIndexStatement.Include) > 0 {
+		_, _ = w.WriteString(" INCLUDE (")
+		for i, column := range createIndexStatement.Include {
+			if i != 0 {
+				_, _ = w.WriteString(", ")
+			}
+			column.WriteStringTo(w)
+		}
+		_, _ = w.WriteString(")")
+	}
+	if createIndexStatement.NullsNotDistinct {
+		_, _ = w.WriteString(" NULLS NOT DISTINCT")
+	}
+	if len(createIndexStatement.StorageParameters) > 0 {
+		_, _ = w.WriteString(" WITH ")
+		createIndexStatement.StorageParameters.WriteStringTo(w)
+	}
+	if createIndexStatement.Tablespace != nil {
+		_, _ = w.WriteString(" TABLESPACE ")
+		createIndexStatement.Tablespace.WriteStringTo(w)
+	}
+	if createIndexStatement.Where != nil {
+		_, _ = w.WriteString(" WHERE ")
+		createIndexStatement.Where.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";")
+}
+
+// ALTER INDEX name ATTACH PARTITION index_name
+//
+// Only ATTACH PARTITION, which pg_dump writes for the indexes of partitions,
+// is supported.
+type AlterIndexStatement struct {
+	Name            *TableName
+	AttachPartition *TableName
+}
+
+func (*AlterIndexStatement) statementNode() {}
+
+func (alterIndexStatement *AlterIndexStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER INDEX ")
+	alterIndexStatement.Name.WriteStringTo(w)
+	_, _ = w.WriteString(" ATTACH PARTITION ")
+	alterIndexStatement.AttachPartition.WriteStringTo(w)
+	_, _ = w.WriteString(";")
 }
 
+// SET [ SESSION | LOCAL ] configuration_parameter { TO | = } { value [, ...] | DEFAULT }
+//
+// Values is empty for DEFAULT.
 type SetStatement struct {
+	Local  bool
 	Name   *Identifier
 	Values []Expression
 }
@@ -388,8 +1452,14 @@ func (*SetStatement) statementNode() {}
 
 func (setStatement *SetStatement) WriteStringTo(w io.StringWriter) {
 	_, _ = w.WriteString("SET ")
+	if setStatement.Local {
+		_, _ = w.WriteString("LOCAL ")
+	}
 	_, _ = w.WriteString(setStatement.Name.Value)
 	_, _ = w.WriteString(" = ")
+	if len(setStatement.Values) == 0 {
+		_, _ = w.WriteString("DEFAULT")
+	}
 	for i, value := range setStatement.Values {
 		if i != 0 {
 			_, _ = w.WriteString(", ")
@@ -404,6 +1474,80 @@ func (setStatement *SetStatement) WriteStringTo(w io.StringWriter) {
 	_, _ = w.WriteString(";")
 }
 
+// A psql meta-command such as \connect or \i, which ends at the end of the
+// line.
+type MetaCommand struct {
+	Token     token.Token
+	Command   string
+	Arguments []string
+}
+
+func (*MetaCommand) statementNode() {}
+
+func (metaCommand *MetaCommand) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString(`\` + metaCommand.Command)
+	for _, argument := range metaCommand.Arguments {
+		_, _ = w.WriteString(" ")
+		if argument == "" || strings.ContainsAny(argument, " \t'") {
+			argument = "'" + strings.Replace(argument, "'", "''", -1) + "'"
+		}
+		_, _ = w.WriteString(argument)
+	}
+}
+
+// RESET { configuration_parameter | ALL }
+//
+// Name is nil for ALL.
+type ResetStatement struct {
+	Name *Identifier
+}
+
+func (*ResetStatement) statementNode() {}
+
+func (resetStatement *ResetStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("RESET ")
+	if resetStatement.Name != nil {
+		_, _ = w.WriteString(resetStatement.Name.Value)
+	} else {
+		_, _ = w.WriteString("ALL")
+	}
+	_, _ = w.WriteString(";")
+}
+
+// SELECT expression [, ...]
+//
+// Only a target list without FROM is supported, such as pg_dump's calls to
+// set_config and setval.
+type SelectStatement struct {
+	Targets []Expression
+}
+
+func (*SelectStatement) statementNode() {}
+
+func (selectStatement *SelectStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("SELECT ")
+	for i, target := range selectStatement.Targets {
+		if i != 0 {
+			_, _ = w.WriteString(", ")
+		}
+		target.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(";")
+}
+
+// { BEGIN | START TRANSACTION | COMMIT | END | ROLLBACK }
+//
+// Command is "BEGIN", "COMMIT" or "ROLLBACK".
+type TransactionStatement struct {
+	Command string
+}
+
+func (*TransactionStatement) statementNode() {}
+
+func (transactionStatement *TransactionStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString(transactionStatement.Command + ";")
+}
+
 // CREATE SEQUENCE "users_id_seq"
 //     START WITH 1
 //     INCREMENT BY 1
@@ -463,45 +1607,210 @@ func (alterSequenceStatement *AlterSequenceStatement) WriteStringTo(w io.StringW
 	_, _ = w.WriteString(";")
 }
 
+// DROP { TABLE | VIEW | INDEX [ CONCURRENTLY ] | SEQUENCE | FUNCTION | TYPE | SCHEMA | COLLATION |
+//     FOREIGN TABLE | SERVER | FOREIGN DATA WRAPPER }
+//     [ IF EXISTS ] name [, ...] [ CASCADE | RESTRICT ]
+//
+// ObjectType holds the kind of object in upper case, such as "TABLE". The
+// name of a schema, server or wrapper is held in TableIdentifier, and the
+// argument lists of functions are not kept.
+type DropStatement struct {
+	ObjectType   string
+	Concurrently bool
+	IfExists     bool
+	Names        []*TableName
+	// Table is the table a dropped policy is on.
+	Table   *TableName
+	Cascade bool
+}
+
+func (*DropStatement) statementNode() {}
+
+func (dropStatement *DropStatement) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("DROP " + dropStatement.ObjectType + " ")
+	if dropStatement.Concurrently {
+		_, _ = w.WriteString("CONCURRENTLY ")
+	}
+	if dropStatement.IfExists {
+		_, _ = w.WriteString("IF EXISTS ")
+	}
+	for i, name := range dropStatement.Names {
+		if i != 0 {
+			_, _ = w.WriteString(", ")
+		}
+		name.WriteStringTo(w)
+	}
+	if dropStatement.Table != nil {
+		_, _ = w.WriteString(" ON ")
+		dropStatement.Table.WriteStringTo(w)
+	}
+	if dropStatement.Cascade {
+		_, _ = w.WriteString(" CASCADE")
+	}
+	_, _ = w.WriteString(";")
+}
+
+// ALTER [ FOREIGN ] TABLE [ IF EXISTS ] [ ONLY ] name [ * ] action [, ... ]
 type AlterTableStatement struct {
-	Name    *TableName
-	Only    bool
-	Actions []Node
+	Foreign  bool
+	Name     *TableName
+	IfExists bool
+	Only     bool
+	Actions  []Node
 }
 
 func (*AlterTableStatement) statementNode() {}
 
 func (alterTableStatement *AlterTableStatement) WriteStringTo(w io.StringWriter) {
-	_, _ = w.WriteString("ALTER TABLE ")
+	_, _ = w.WriteString("ALTER ")
+	if alterTableStatement.Foreign {
+		_, _ = w.WriteString("FOREIGN ")
+	}
+	_, _ = w.WriteString("TABLE ")
+	if alterTableStatement.IfExists {
+		_, _ = w.WriteString("IF EXISTS ")
+	}
 	if alterTableStatement.Only {
 		_, _ = w.WriteString("ONLY ")
 	}
 	alterTableStatement.Name.WriteStringTo(w)
 	_, _ = w.WriteString("\n")
-	for _, action := range alterTableStatement.Actions {
+	for i, action := range alterTableStatement.Actions {
+		if i != 0 {
+			_, _ = w.WriteString(",\n")
+		}
 		_, _ = w.WriteString("    ")
 		action.WriteStringTo(w)
 	}
 	_, _ = w.WriteString(";")
 }
 
+// [ CONSTRAINT constraint_name ]
+// { CHECK ( expression ) [ NO INHERIT ] |
+//   UNIQUE [ NULLS [ NOT ] DISTINCT ] ( column_name [, ... ] ) index_parameters |
+//   PRIMARY KEY ( column_name [, ... ] ) index_parameters |
+//   FOREIGN KEY ( column_name [, ... ] ) REFERENCES reftable [ ( refcolumn [, ... ] ) ]
+//     [ MATCH FULL | MATCH PARTIAL | MATCH SIMPLE ] [ ON DELETE action ] [ ON UPDATE action ] }
+// [ DEFERRABLE | NOT DEFERRABLE ] [ INITIALLY DEFERRED | INITIALLY IMMEDIATE ] [ NOT VALID ]
 type TableConstraint struct {
-	Name       *Identifier
-	Unique     bool
-	PrimaryKey bool
-	ColumnList *ColumnList
+	Name             *Identifier
+	Unique           bool
+	PrimaryKey       bool
+	Check            Expression
+	NoInherit        bool
+	ForeignKey       bool
+	ColumnList       *ColumnList
+	NullsNotDistinct bool
+	IndexParameters  *IndexParameters
+	References       *References
+	Deferrable       bool
+	Deferred         bool
+	NotValid         bool
 }
 
 func (tableConstraint *TableConstraint) WriteStringTo(w io.StringWriter) {
-	_, _ = w.WriteString("ADD CONSTRAINT ")
-	tableConstraint.Name.WriteStringTo(w)
+	_, _ = w.WriteString("ADD ")
+	tableConstraint.writeDefinition(w)
+}
+
+// writeDefinition writes the constraint as an element of CREATE TABLE.
+func (tableConstraint *TableConstraint) writeDefinition(w io.StringWriter) {
+	if tableConstraint.Name != nil {
+		_, _ = w.WriteString("CONSTRAINT ")
+		tableConstraint.Name.WriteStringTo(w)
+		_, _ = w.WriteString(" ")
+	}
 	if tableConstraint.PrimaryKey {
-		_, _ = w.WriteString(" PRIMARY KEY ")
+		_, _ = w.WriteString("PRIMARY KEY ")
 		tableConstraint.ColumnList.WriteStringTo(w)
 	}
 	if tableConstraint.Unique {
-		_, _ = w.WriteString(" UNIQUE ")
+		_, _ = w.WriteString("UNIQUE ")
+		if tableConstraint.NullsNotDistinct {
+			_, _ = w.WriteString("NULLS NOT DISTINCT ")
+		}
+		tableConstraint.ColumnList.WriteStringTo(w)
+	}
+	if tableConstraint.Check != nil {
+		_, _ = w.WriteString("CHECK (")
+		tableConstraint.Check.WriteStringTo(w)
+		_, _ = w.WriteString(")")
+		if tableConstraint.NoInherit {
+			_, _ = w.WriteString(" NO INHERIT")
+		}
+	}
+	if tableConstraint.ForeignKey {
+		_, _ = w.WriteString("FOREIGN KEY ")
 		tableConstraint.ColumnList.WriteStringTo(w)
+		tableConstraint.References.WriteStringTo(w)
+	}
+	if tableConstraint.IndexParameters != nil {
+		tableConstraint.IndexParameters.WriteStringTo(w)
+	}
+	if tableConstraint.Deferrable {
+		_, _ = w.WriteString(" DEFERRABLE")
+		if tableConstraint.Deferred {
+			_, _ = w.WriteString(" INITIALLY DEFERRED")
+		}
+	}
+	if tableConstraint.NotValid {
+		_, _ = w.WriteString(" NOT VALID")
+	}
+}
+
+// index_parameters in UNIQUE and PRIMARY KEY constraints:
+// [ INCLUDE ( column_name [, ... ] ) ]
+// [ WITH ( storage_parameter [= value] [, ... ] ) ]
+// [ USING INDEX TABLESPACE tablespace_name ]
+type IndexParameters struct {
+	Include           []*Identifier
+	StorageParameters StorageParameters
+	Tablespace        *Identifier
+}
+
+func (indexParameters *IndexParameters) WriteStringTo(w io.StringWriter) {
+	if len(indexParameters.Include) > 0 {
+		_, _ = w.WriteString(" INCLUDE ")
+		(&ColumnList{ColumnNames: indexParameters.Include}).WriteStringTo(w)
+	}
+	if len(indexParameters.StorageParameters) > 0 {
+		_, _ = w.WriteString(" WITH ")
+		indexParameters.StorageParameters.WriteStringTo(w)
+	}
+	if indexParameters.Tablespace != nil {
+		_, _ = w.WriteString(" USING INDEX TABLESPACE ")
+		indexParameters.Tablespace.WriteStringTo(w)
+	}
+}
+
+// REFERENCES reftable [ ( refcolumn [, ... ] ) ]
+// [ MATCH FULL | MATCH PARTIAL | MATCH SIMPLE ] [ ON DELETE action ] [ ON UPDATE action ]
+//
+// Match, OnDelete and OnUpdate hold the keywords in upper case, such as
+// "FULL" or "SET NULL", and are empty when omitted.
+type References struct {
+	Table      *TableName
+	ColumnList *ColumnList
+	Match      string
+	OnDelete   string
+	OnUpdate   string
+}
+
+func (references *References) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString(" REFERENCES ")
+	references.Table.WriteStringTo(w)
+	if references.ColumnList != nil {
+		_, _ = w.WriteString(" ")
+		references.ColumnList.WriteStringTo(w)
+	}
+	if references.Match != "" {
+		_, _ = w.WriteString(" MATCH " + references.Match)
+	}
+	if references.OnUpdate != "" {
+		_, _ = w.WriteString(" ON UPDATE " + references.OnUpdate)
+	}
+	if references.OnDelete != "" {
+		_, _ = w.WriteString(" ON DELETE " + references.OnDelete)
 	}
 }
 
@@ -516,3 +1825,400 @@ func (alterColumnSetDefault *AlterColumnSetDefault) WriteStringTo(w io.StringWri
 	_, _ = w.WriteString(" SET DEFAULT ")
 	alterColumnSetDefault.Expr.WriteStringTo(w)
 }
+
+// ADD [ COLUMN ] [ IF NOT EXISTS ] column_name data_type [ column_constraint [ ... ] ]
+type AddColumn struct {
+	IfNotExists      bool
+	ColumnDefinition *ColumnDefinition
+}
+
+func (addColumn *AddColumn) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ADD COLUMN ")
+	if addColumn.IfNotExists {
+		_, _ = w.WriteString("IF NOT EXISTS ")
+	}
+	addColumn.ColumnDefinition.WriteStringTo(w)
+}
+
+// DROP [ COLUMN ] [ IF EXISTS ] column_name [ RESTRICT | CASCADE ]
+type DropColumn struct {
+	IfExists bool
+	Column   *Identifier
+	Cascade  bool
+}
+
+func (dropColumn *DropColumn) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("DROP COLUMN ")
+	if dropColumn.IfExists {
+		_, _ = w.WriteString("IF EXISTS ")
+	}
+	dropColumn.Column.WriteStringTo(w)
+	if dropColumn.Cascade {
+		_, _ = w.WriteString(" CASCADE")
+	}
+}
+
+// ALTER [ COLUMN ] column_name [ SET DATA ] TYPE data_type [ COLLATE collation ] [ USING expression ]
+type AlterColumnType struct {
+	Column    *Identifier
+	Type      DataType
+	Collation Expression // Identifier or QualifiedName
+	Using     Expression
+}
+
+func (alterColumnType *AlterColumnType) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER COLUMN ")
+	alterColumnType.Column.WriteStringTo(w)
+	_, _ = w.WriteString(" TYPE ")
+	alterColumnType.Type.WriteStringTo(w)
+	if alterColumnType.Collation != nil {
+		_, _ = w.WriteString(" COLLATE ")
+		alterColumnType.Collation.WriteStringTo(w)
+	}
+	if alterColumnType.Using != nil {
+		_, _ = w.WriteString(" USING ")
+		alterColumnType.Using.WriteStringTo(w)
+	}
+}
+
+// ALTER [ COLUMN ] column_name SET STATISTICS integer
+type AlterColumnSetStatistics struct {
+	Column     *Identifier
+	Statistics int
+}
+
+func (alterColumnSetStatistics *AlterColumnSetStatistics) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER COLUMN ")
+	alterColumnSetStatistics.Column.WriteStringTo(w)
+	_, _ = w.WriteString(" SET STATISTICS " + strconv.Itoa(alterColumnSetStatistics.Statistics))
+}
+
+// ALTER [ COLUMN ] column_name SET STORAGE { PLAIN | EXTERNAL | EXTENDED | MAIN | DEFAULT }
+type AlterColumnSetStorage struct {
+	Column  *Identifier
+	Storage string
+}
+
+func (alterColumnSetStorage *AlterColumnSetStorage) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER COLUMN ")
+	alterColumnSetStorage.Column.WriteStringTo(w)
+	_, _ = w.WriteString(" SET STORAGE " + alterColumnSetStorage.Storage)
+}
+
+// ALTER [ COLUMN ] column_name SET COMPRESSION compression_method
+//
+// Compression is nil for DEFAULT.
+type AlterColumnSetCompression struct {
+	Column      *Identifier
+	Compression *Identifier
+}
+
+func (alterColumnSetCompression *AlterColumnSetCompression) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER COLUMN ")
+	alterColumnSetCompression.Column.WriteStringTo(w)
+	_, _ = w.WriteString(" SET COMPRESSION ")
+	if alterColumnSetCompression.Compression != nil {
+		alterColumnSetCompression.Compression.WriteStringTo(w)
+	} else {
+		_, _ = w.WriteString("DEFAULT")
+	}
+}
+
+// ALTER [ COLUMN ] column_name DROP DEFAULT
+type AlterColumnDropDefault struct {
+	Column *Identifier
+}
+
+func (alterColumnDropDefault *AlterColumnDropDefault) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER COLUMN ")
+	alterColumnDropDefault.Column.WriteStringTo(w)
+	_, _ = w.WriteString(" DROP DEFAULT")
+}
+
+// ALTER [ COLUMN ] column_name { SET | DROP } NOT NULL
+type AlterColumnNotNull struct {
+	Column  *Identifier
+	NotNull bool
+}
+
+func (alterColumnNotNull *AlterColumnNotNull) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER COLUMN ")
+	alterColumnNotNull.Column.WriteStringTo(w)
+	if alterColumnNotNull.NotNull {
+		_, _ = w.WriteString(" SET NOT NULL")
+	} else {
+		_, _ = w.WriteString(" DROP NOT NULL")
+	}
+}
+
+// DROP CONSTRAINT [ IF EXISTS ] constraint_name [ RESTRICT | CASCADE ]
+type DropConstraint struct {
+	IfExists bool
+	Name     *Identifier
+	Cascade  bool
+}
+
+func (dropConstraint *DropConstraint) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("DROP CONSTRAINT ")
+	if dropConstraint.IfExists {
+		_, _ = w.WriteString("IF EXISTS ")
+	}
+	dropConstraint.Name.WriteStringTo(w)
+	if dropConstraint.Cascade {
+		_, _ = w.WriteString(" CASCADE")
+	}
+}
+
+// RENAME [ COLUMN ] column_name TO new_column_name
+type RenameColumn struct {
+	Column  *Identifier
+	NewName *Identifier
+}
+
+func (renameColumn *RenameColumn) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("RENAME COLUMN ")
+	renameColumn.Column.WriteStringTo(w)
+	_, _ = w.WriteString(" TO ")
+	renameColumn.NewName.WriteStringTo(w)
+}
+
+// RENAME CONSTRAINT constraint_name TO new_constraint_name
+type RenameConstraint struct {
+	Name    *Identifier
+	NewName *Identifier
+}
+
+func (renameConstraint *RenameConstraint) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("RENAME CONSTRAINT ")
+	renameConstraint.Name.WriteStringTo(w)
+	_, _ = w.WriteString(" TO ")
+	renameConstraint.NewName.WriteStringTo(w)
+}
+
+// RENAME TO new_name
+type RenameTable struct {
+	NewName *Identifier
+}
+
+func (renameTable *RenameTable) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("RENAME TO ")
+	renameTable.NewName.WriteStringTo(w)
+}
+
+// ATTACH PARTITION partition_name { FOR VALUES partition_bound_spec | DEFAULT }
+type AttachPartition struct {
+	Name  *TableName
+	Bound *PartitionBound
+}
+
+func (attachPartition *AttachPartition) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ATTACH PARTITION ")
+	attachPartition.Name.WriteStringTo(w)
+	_, _ = w.WriteString(" ")
+	attachPartition.Bound.WriteStringTo(w)
+}
+
+// DETACH PARTITION partition_name [ CONCURRENTLY | FINALIZE ]
+type DetachPartition struct {
+	Name         *TableName
+	Concurrently bool
+	Finalize     bool
+}
+
+func (detachPartition *DetachPartition) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("DETACH PARTITION ")
+	detachPartition.Name.WriteStringTo(w)
+	if detachPartition.Concurrently {
+		_, _ = w.WriteString(" CONCURRENTLY")
+	}
+	if detachPartition.Finalize {
+		_, _ = w.WriteString(" FINALIZE")
+	}
+}
+
+// [ NO ] INHERIT parent_table
+type Inherit struct {
+	No     bool
+	Parent *TableName
+}
+
+func (inherit *Inherit) WriteStringTo(w io.StringWriter) {
+	if inherit.No {
+		_, _ = w.WriteString("NO ")
+	}
+	_, _ = w.WriteString("INHERIT ")
+	inherit.Parent.WriteStringTo(w)
+}
+
+// SET { LOGGED | UNLOGGED }
+type SetLogged struct {
+	Logged bool
+}
+
+func (setLogged *SetLogged) WriteStringTo(w io.StringWriter) {
+	if setLogged.Logged {
+		_, _ = w.WriteString("SET LOGGED")
+	} else {
+		_, _ = w.WriteString("SET UNLOGGED")
+	}
+}
+
+// SET ( storage_parameter [= value] [, ... ] ) | RESET ( storage_parameter [, ... ] )
+type SetStorageParameters struct {
+	Reset      bool
+	Parameters StorageParameters
+}
+
+func (setStorageParameters *SetStorageParameters) WriteStringTo(w io.StringWriter) {
+	if setStorageParameters.Reset {
+		_, _ = w.WriteString("RESET ")
+	} else {
+		_, _ = w.WriteString("SET ")
+	}
+	setStorageParameters.Parameters.WriteStringTo(w)
+}
+
+// SET TABLESPACE new_tablespace
+type SetTablespace struct {
+	Tablespace *Identifier
+}
+
+func (setTablespace *SetTablespace) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("SET TABLESPACE ")
+	setTablespace.Tablespace.WriteStringTo(w)
+}
+
+// SET ACCESS METHOD new_access_method
+type SetAccessMethod struct {
+	AccessMethod *Identifier
+}
+
+func (setAccessMethod *SetAccessMethod) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("SET ACCESS METHOD ")
+	setAccessMethod.AccessMethod.WriteStringTo(w)
+}
+
+// SET SCHEMA new_schema
+type SetSchema struct {
+	Schema *Identifier
+}
+
+func (setSchema *SetSchema) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("SET SCHEMA ")
+	setSchema.Schema.WriteStringTo(w)
+}
+
+// { ENABLE [ REPLICA | ALWAYS ] | DISABLE } TRIGGER [ trigger_name | ALL | USER ]
+//
+// Mode is "ENABLE", "ENABLE REPLICA", "ENABLE ALWAYS" or "DISABLE". Name is
+// nil when Scope is "ALL" or "USER".
+type AlterTrigger struct {
+	Mode  string
+	Name  *Identifier
+	Scope string
+}
+
+func (alterTrigger *AlterTrigger) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString(alterTrigger.Mode + " TRIGGER ")
+	if alterTrigger.Name != nil {
+		alterTrigger.Name.WriteStringTo(w)
+	} else {
+		_, _ = w.WriteString(alterTrigger.Scope)
+	}
+}
+
+// OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] ), on a foreign table.
+type SetOptions struct {
+	Options GenericOptions
+}
+
+func (setOptions *SetOptions) WriteStringTo(w io.StringWriter) {
+	setOptions.Options.WriteStringTo(w)
+}
+
+// ALTER [ COLUMN ] column_name OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] )
+type AlterColumnOptions struct {
+	Column  *Identifier
+	Options GenericOptions
+}
+
+func (alterColumnOptions *AlterColumnOptions) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("ALTER COLUMN ")
+	alterColumnOptions.Column.WriteStringTo(w)
+	_, _ = w.WriteString(" ")
+	alterColumnOptions.Options.WriteStringTo(w)
+}
+
+// REPLICA IDENTITY { DEFAULT | USING INDEX index_name | FULL | NOTHING }
+//
+// Identity holds DEFAULT, FULL or NOTHING when Index is nil.
+type ReplicaIdentity struct {
+	Identity string
+	Index    *Identifier
+}
+
+func (replicaIdentity *ReplicaIdentity) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("REPLICA IDENTITY ")
+	if replicaIdentity.Index != nil {
+		_, _ = w.WriteString("USING INDEX ")
+		replicaIdentity.Index.WriteStringTo(w)
+	} else {
+		_, _ = w.WriteString(replicaIdentity.Identity)
+	}
+}
+
+// CLUSTER ON index_name
+type ClusterOn struct {
+	Index *Identifier
+}
+
+func (clusterOn *ClusterOn) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("CLUSTER ON ")
+	clusterOn.Index.WriteStringTo(w)
+}
+
+// SET WITHOUT CLUSTER
+type SetWithoutCluster struct{}
+
+func (*SetWithoutCluster) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("SET WITHOUT CLUSTER")
+}
+
+// { ENABLE | DISABLE | FORCE | NO FORCE } ROW LEVEL SECURITY
+//
+// Force is set for FORCE and NO FORCE, and Enable for ENABLE and FORCE.
+type RowLevelSecurity struct {
+	Force  bool
+	Enable bool
+}
+
+func (rowLevelSecurity *RowLevelSecurity) WriteStringTo(w io.StringWriter) {
+	switch {
+	case rowLevelSecurity.Force && rowLevelSecurity.Enable:
+		_, _ = w.WriteString("FORCE")
+	case rowLevelSecurity.Force:
+		_, _ = w.WriteString("NO FORCE")
+	case rowLevelSecurity.Enable:
+		_, _ = w.WriteString("ENABLE")
+	default:
+		_, _ = w.WriteString("DISABLE")
+	}
+	_, _ = w.WriteString(" ROW LEVEL SECURITY")
+}
+
+// OWNER TO { new_owner | CURRENT_ROLE | CURRENT_USER | SESSION_USER }
+//
+// Keyword holds CURRENT_ROLE, CURRENT_USER or SESSION_USER when Owner is nil.
+type OwnerTo struct {
+	Owner   *Identifier
+	Keyword string
+}
+
+func (ownerTo *OwnerTo) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("OWNER TO ")
+	if ownerTo.Owner != nil {
+		ownerTo.Owner.WriteStringTo(w)
+	} else {
+		_, _ = w.WriteString(ownerTo.Keyword)
+	}
+}
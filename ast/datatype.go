@@ -32,6 +32,16 @@ type DataTypeSmallint struct {
 func (*DataTypeSmallint) Name() DataTypeName              { return Smallint }
 func (*DataTypeSmallint) WriteStringTo(w io.StringWriter) { _, _ = w.WriteString("smallint") }
 
+type DataTypeSmallserial struct{}
+
+func (*DataTypeSmallserial) Name() DataTypeName              { return Smallserial }
+func (*DataTypeSmallserial) WriteStringTo(w io.StringWriter) { _, _ = w.WriteString("smallserial") }
+
+type DataTypeSerial struct{}
+
+func (*DataTypeSerial) Name() DataTypeName              { return Serial }
+func (*DataTypeSerial) WriteStringTo(w io.StringWriter) { _, _ = w.WriteString("serial") }
+
 type DataTypeBigserial struct{}
 
 func (*DataTypeBigserial) Name() DataTypeName              { return Bigserial }
@@ -42,10 +52,31 @@ type DataTypeBoolean struct{}
 func (*DataTypeBoolean) Name() DataTypeName              { return Boolean }
 func (*DataTypeBoolean) WriteStringTo(w io.StringWriter) { _, _ = w.WriteString("boolean") }
 
-type DataTypeNumeric struct{}
+type DataTypeNumeric struct {
+	Modifiers []Expression // precision [, scale]
+}
 
-func (*DataTypeNumeric) Name() DataTypeName              { return Numeric }
-func (*DataTypeNumeric) WriteStringTo(w io.StringWriter) { _, _ = w.WriteString("numeric") }
+func (*DataTypeNumeric) Name() DataTypeName { return Numeric }
+func (dataTypeNumeric *DataTypeNumeric) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("numeric")
+	writeTypeModifiers(w, dataTypeNumeric.Modifiers)
+}
+
+// writeTypeModifiers writes ( modifier [, ...] ) the way pg_dump does, with
+// no space after the commas. It writes nothing if there are no modifiers.
+func writeTypeModifiers(w io.StringWriter, modifiers []Expression) {
+	if len(modifiers) == 0 {
+		return
+	}
+	_, _ = w.WriteString("(")
+	for i, modifier := range modifiers {
+		if i != 0 {
+			_, _ = w.WriteString(",")
+		}
+		modifier.WriteStringTo(w)
+	}
+	_, _ = w.WriteString(")")
+}
 
 type DataTypeOptionLength struct {
 	token.Token
@@ -117,6 +148,46 @@ func (dataTypeTimestamp *DataTypeTimestamp) WriteStringTo(w io.StringWriter) {
 	}
 }
 
+type DataTypeTime struct {
+	WithTimeZone bool
+}
+
+func (*DataTypeTime) Name() DataTypeName { return Time }
+func (dataTypeTime *DataTypeTime) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("time")
+	if dataTypeTime.WithTimeZone {
+		_, _ = w.WriteString(" with time zone")
+	} else {
+		_, _ = w.WriteString(" without time zone")
+	}
+}
+
+type DataTypeReal struct{}
+
+func (*DataTypeReal) Name() DataTypeName              { return Real }
+func (*DataTypeReal) WriteStringTo(w io.StringWriter) { _, _ = w.WriteString("real") }
+
+type DataTypeDoublePrecision struct{}
+
+func (*DataTypeDoublePrecision) Name() DataTypeName              { return DoublePrecision }
+func (*DataTypeDoublePrecision) WriteStringTo(w io.StringWriter) { _, _ = w.WriteString("double precision") }
+
+type DataTypeBit struct {
+	Varying      bool
+	OptionLength *DataTypeOptionLength
+}
+
+func (*DataTypeBit) Name() DataTypeName { return Bit }
+func (dataTypeBit *DataTypeBit) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("bit")
+	if dataTypeBit.Varying {
+		_, _ = w.WriteString(" varying")
+	}
+	if dataTypeBit.OptionLength != nil {
+		dataTypeBit.OptionLength.WriteStringTo(w)
+	}
+}
+
 type DataTypeArray struct {
 	ElementType DataType
 }
@@ -127,14 +198,40 @@ func (dataTypeArray *DataTypeArray) WriteStringTo(w io.StringWriter) {
 	_, _ = w.WriteString("[]")
 }
 
+// DataTypeInterval is interval, with Fields such as "day to second" when
+// they restrict it.
+type DataTypeInterval struct {
+	Fields string
+}
+
+func (*DataTypeInterval) Name() DataTypeName { return Interval }
+func (dataTypeInterval *DataTypeInterval) WriteStringTo(w io.StringWriter) {
+	_, _ = w.WriteString("interval")
+	if dataTypeInterval.Fields != "" {
+		_, _ = w.WriteString(" " + dataTypeInterval.Fields)
+	}
+}
+
+// DataTypeUserDefined is a type referenced by name, such as a domain, an enum
+// or a builtin type which has no dedicated node.
+type DataTypeUserDefined struct {
+	TypeName  Expression // Identifier or QualifiedName
+	Modifiers []Expression
+}
+
+func (*DataTypeUserDefined) Name() DataTypeName { return UserDefined }
+func (dataTypeUserDefined *DataTypeUserDefined) WriteStringTo(w io.StringWriter) {
+	dataTypeUserDefined.TypeName.WriteStringTo(w)
+	writeTypeModifiers(w, dataTypeUserDefined.Modifiers)
+}
+
 // //go:generate stringer -type=DataTypeName
 type DataTypeName int
 
 const (
 	Bigint DataTypeName = iota
 	Bigserial
-	// Not implemented: Bit
-	// Not implemented: BitVarying
+	Bit
 	Boolean
 	// Not implemented: Box
 	Bytea
@@ -142,10 +239,10 @@ const (
 	// Not implemented: Cidr
 	// Not implemented: Circle
 	Date
-	// Not implemented: DoublePrecision
+	DoublePrecision
 	// Not implemented: Inet
 	Integer
-	// Not implemented: Interval
+	Interval
 	// Not implemented: Json
 	Jsonb
 	// Not implemented: Line
@@ -159,12 +256,11 @@ const (
 	// Not implemented: PgLsn
 	// Not implemented: Point
 	// Not implemented: Polygon
-	// Not implemented: Real
-	// Not implemented: Smallserial
+	Real
+	Smallserial
 	Serial
 	Text
-	// Not implemented: Time
-	// Not implemented: TimeWithTimeZone
+	Time
 	Timestamp
 	// Not implemented: Tsquery
 	Tsvector
@@ -172,4 +268,5 @@ const (
 	Uuid
 	// Not implemented: Xml
 	Array
+	UserDefined
 )
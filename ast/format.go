@@ -0,0 +1,66 @@
+package ast
+
+import (
+	"io"
+	"strings"
+
+	"github.com/ttakezawa/pgconverger/token"
+)
+
+// NumericFormat selects how number literals are written.
+type NumericFormat int
+
+const (
+	// NumericAsWritten writes number literals as they appear in the source.
+	NumericAsWritten NumericFormat = iota
+	// NumericNormalized writes number literals in decimal notation keeping
+	// their scale, so that 0x1F and 31 or 5e2 and 500 are written alike.
+	NumericNormalized
+	// NumericValue writes number literals by their value alone, so that
+	// 1.0 and 1.00 are written alike as well.
+	NumericValue
+)
+
+// FormatOptions controls how nodes write themselves.
+type FormatOptions struct {
+	Numeric NumericFormat
+
+	// MinimalQuoting writes identifiers without double quotes unless they
+	// need them, as pg_dump does: public.users rather than "public"."users".
+	MinimalQuoting bool
+}
+
+// QuoteIdentifier double-quotes name unless MinimalQuoting is set and name
+// reads back unchanged without quotes.
+func (options FormatOptions) QuoteIdentifier(name string) string {
+	if options.MinimalQuoting && !token.NeedsQuoting(name) {
+		return name
+	}
+	return QuoteIdentifier(name)
+}
+
+// formatWriter carries FormatOptions down to the nodes being written.
+type formatWriter struct {
+	io.StringWriter
+	options FormatOptions
+}
+
+// NewWriter returns a writer to w which makes nodes write themselves with
+// options.
+func NewWriter(w io.StringWriter, options FormatOptions) io.StringWriter {
+	return &formatWriter{StringWriter: w, options: options}
+}
+
+// FormatNodeWith is like FormatNode but writes node with options.
+func FormatNodeWith(node Node, options FormatOptions) string {
+	var builder strings.Builder
+	node.WriteStringTo(NewWriter(&builder, options))
+	return builder.String()
+}
+
+func formatOptions(w io.StringWriter) FormatOptions {
+	if fw, ok := w.(*formatWriter); ok {
+		return fw.options
+	}
+	return FormatOptions{}
+}
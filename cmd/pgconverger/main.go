@@ -11,11 +11,19 @@ import (
 
 func main() {
 	var (
-		source  = flag.String("source", "", "compare {SOURCE} to {DESIRED}")
-		desired = flag.String("desired", "", "compare {SOURCE} to {DESIRED}")
+		source       = flag.String("source", "", "compare {SOURCE} to {DESIRED}")
+		desired      = flag.String("desired", "", "compare {SOURCE} to {DESIRED}")
+		numeric      = flag.String("numeric-equivalence", "scale", "compare numbers in defaults by `scale`, value or literal")
+		minimal      = flag.Bool("minimal-quoting", false, "quote identifiers only when required, as pg_dump does")
+		zeroDowntime = flag.Bool("zero-downtime-indexes", false, "replace changed indexes concurrently under a temporary name")
 	)
 	flag.Parse()
 
+	numericEquivalence, err := diff.ParseNumericEquivalence(*numeric)
+	if err != nil {
+		log.Fatal(err.Error())
+	}
+
 	log.Printf("source:  %s", *source)
 	log.Printf("desired: %s", *desired)
 
@@ -29,7 +37,14 @@ func main() {
 		log.Fatal(err.Error())
 	}
 
-	ddl, err := diff.Process(sourceFile, desiredFile)
+	options := []diff.Option{diff.WithNumericEquivalence(numericEquivalence)}
+	if *minimal {
+		options = append(options, diff.WithMinimalQuoting())
+	}
+	if *zeroDowntime {
+		options = append(options, diff.WithZeroDowntimeIndexes())
+	}
+	ddl, err := diff.Process(sourceFile, desiredFile, options...)
 	if err != nil {
 		fmt.Printf("%+v", err)
 		return
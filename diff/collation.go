@@ -0,0 +1,163 @@
+package diff
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// Catalog holds the modelled objects which do not belong to a table.
+type Catalog struct {
+	Collations   Collations
+	Servers      Servers
+	UserMappings UserMappings
+	// Imports are the IMPORT FOREIGN SCHEMA statements, whose foreign
+	// tables are not known until they run.
+	Imports []*ast.ImportForeignSchemaStatement
+}
+
+// Collations maps the qualified name of a collation to its definition.
+type Collations map[string]*ast.CreateCollationStatement
+
+func newCatalog() *Catalog {
+	return &Catalog{
+		Collations:   make(Collations),
+		Servers:      make(Servers),
+		UserMappings: make(UserMappings),
+	}
+}
+
+func (collations Collations) SortedKeys() (keys []string) {
+	for k := range collations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// AddCollation records a CREATE COLLATION, placing an unqualified name in the
+// creation schema of searchPath.
+func (collations Collations) AddCollation(searchPath SearchPath, createCollationStatement *ast.CreateCollationStatement) {
+	if createCollationStatement.Name.SchemaIdentifier == nil {
+		createCollationStatement.Name.SetSchema(searchPath.creationSchema())
+	}
+	identifier := createCollationStatement.Name.String()
+	if _, ok := collations[identifier]; ok {
+		if !createCollationStatement.IfNotExists {
+			log.Printf("irregular create collation to existing collation=%s", identifier)
+		}
+		return
+	}
+	createCollationStatement.IfNotExists = false
+	collations[identifier] = createCollationStatement
+}
+
+// dropCollation removes the collation name, looking an unqualified name up
+// on searchPath.
+func (collations Collations) dropCollation(searchPath SearchPath, name *ast.TableName) bool {
+	schemas := searchPath
+	if name.SchemaIdentifier != nil {
+		schemas = SearchPath{name.SchemaIdentifier.Value}
+	}
+	for _, schema := range schemas {
+		identifier := ast.QuoteIdentifier(schema) + "." + ast.QuoteIdentifier(name.TableIdentifier.Value)
+		if _, ok := collations[identifier]; ok {
+			delete(collations, identifier)
+			return true
+		}
+	}
+	return false
+}
+
+// resolveCollation returns collation as modelled: nil for the default
+// collation, unqualified for a collation in pg_catalog, and qualified for a
+// collation created on searchPath. Other names are built-in collations
+// such as "C" and are kept as written.
+func (collations Collations) resolveCollation(searchPath SearchPath, collation ast.Expression) ast.Expression {
+	switch v := collation.(type) {
+	case *ast.Identifier:
+		if v.Value == "default" {
+			return nil
+		}
+		for _, schema := range searchPath {
+			if _, ok := collations[ast.QuoteIdentifier(schema)+"."+ast.QuoteIdentifier(v.Value)]; ok {
+				return &ast.QualifiedName{Identifiers: []*ast.Identifier{newIdentifier(schema), v}}
+			}
+		}
+	case *ast.QualifiedName:
+		if len(v.Identifiers) == 2 && v.Identifiers[0].Value == "pg_catalog" {
+			return collations.resolveCollation(nil, v.Identifiers[1])
+		}
+	}
+	return collation
+}
+
+// resolveCollations resolves the collations which statement gives columns
+// and index elements.
+func (collations Collations) resolveCollations(searchPath SearchPath, statement ast.Statement) {
+	switch stmt := statement.(type) {
+	case *ast.CreateTableStatement:
+		for _, columnDefinition := range stmt.ColumnDefinitionList {
+			columnDefinition.Collation = collations.resolveCollation(searchPath, columnDefinition.Collation)
+		}
+	case *ast.CreateIndexStatement:
+		for _, indexTarget := range stmt.IndexTargets {
+			if indexTarget.Collation != nil {
+				indexTarget.Collation = collations.resolveCollation(searchPath, indexTarget.Collation)
+			}
+		}
+	case *ast.AlterTableStatement:
+		for _, action := range stmt.Actions {
+			switch v := action.(type) {
+			case *ast.AddColumn:
+				v.ColumnDefinition.Collation = collations.resolveCollation(searchPath, v.ColumnDefinition.Collation)
+			case *ast.AlterColumnType:
+				v.Collation = collations.resolveCollation(searchPath, v.Collation)
+			}
+		}
+	}
+}
+
+func (df *Diff) writeCollationAnnotation(identifier string, collations Collations) {
+	df.WriteString("-- Collation: " + df.formatNode(collations[identifier].Name) + "\n")
+}
+
+// createCollations creates the new collations, before the tables which may
+// use them. A collation cannot be altered, so a changed definition is
+// recorded as an error.
+func (df *Diff) createCollations() {
+	sourceCollations := df.sourceCatalog.Collations
+	desiredCollations := df.desiredCatalog.Collations
+	for _, identifier := range desiredCollations.SortedKeys() {
+		desiredCollation := desiredCollations[identifier]
+		sourceCollation, ok := sourceCollations[identifier]
+		if !ok {
+			df.writeCollationAnnotation(identifier, desiredCollations)
+			df.writeNode(desiredCollation)
+			df.WriteString("\n")
+			continue
+		}
+		if df.formatForComparison(sourceCollation) != df.formatForComparison(desiredCollation) {
+			df.patchErrors = append(df.patchErrors, fmt.Errorf(
+				"cannot change the definition of collation %s in place; it must be recreated",
+				df.formatNode(sourceCollation.Name),
+			))
+		}
+	}
+}
+
+// dropCollations drops the removed collations, after the columns using them
+// have been changed or dropped.
+func (df *Diff) dropCollations() {
+	sourceCollations := df.sourceCatalog.Collations
+	for _, identifier := range sourceCollations.SortedKeys() {
+		if _, ok := df.desiredCatalog.Collations[identifier]; ok {
+			continue
+		}
+		df.writeCollationAnnotation(identifier, sourceCollations)
+		df.WriteString(fmt.Sprintf("DROP COLLATION %s;\n", df.formatNode(sourceCollations[identifier].Name)))
+		df.WriteString("\n")
+	}
+}
@@ -1,17 +1,16 @@
 package diff
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"log"
 	"sort"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/ttakezawa/pgconverger/ast"
-	"github.com/ttakezawa/pgconverger/lexer"
-	"github.com/ttakezawa/pgconverger/parser"
+	"github.com/ttakezawa/pgconverger/token"
 )
 
 type fileReader interface {
@@ -31,37 +30,149 @@ type Diff struct {
 	sourceTables  Tables
 	desiredTables Tables
 
+	sourceCatalog  *Catalog
+	desiredCatalog *Catalog
+
 	stringBuilder *strings.Builder
+
+	numericEquivalence  NumericEquivalence
+	formatOptions       ast.FormatOptions
+	zeroDowntimeIndexes bool
+
+	// dependentForeignKeys holds, for each primary key or unique constraint
+	// that is replaced, the foreign keys that reference it. They are dropped
+	// before the constraint and re-added after it, so the diff of the
+	// referencing table skips the ones recorded in handledConstraints.
+	dependentForeignKeys map[*TableConstraint][]*foreignKeyReference
+	handledConstraints   map[*TableConstraint]bool
+
+	// patchErrors holds the changes which cannot be made in place.
+	patchErrors []error
+}
+
+// foreignKeyReference is a foreign key of table which must be dropped and
+// re-added around a change of the constraint it references. desired is nil
+// when the foreign key no longer exists.
+type foreignKeyReference struct {
+	table   *Table
+	source  *TableConstraint
+	desired *TableConstraint
+}
+
+// NumericEquivalence decides when two number literals in default
+// expressions are considered equal.
+type NumericEquivalence int
+
+const (
+	// NumericEqualScale treats numbers as equal when they have the same
+	// value and scale: 0x1F equals 31 and 5e2 equals 500, but 1.0 differs
+	// from 1.00 as it does for a numeric column.
+	NumericEqualScale NumericEquivalence = iota
+	// NumericEqualValue treats numbers as equal when they have the same
+	// value, so 1.0 equals 1.00.
+	NumericEqualValue
+	// NumericEqualLiteral treats numbers as equal only when they are
+	// written identically.
+	NumericEqualLiteral
+)
+
+// ParseNumericEquivalence returns the NumericEquivalence named by s, one of
+// "scale", "value" or "literal".
+func ParseNumericEquivalence(s string) (NumericEquivalence, error) {
+	switch s {
+	case "scale":
+		return NumericEqualScale, nil
+	case "value":
+		return NumericEqualValue, nil
+	case "literal":
+		return NumericEqualLiteral, nil
+	}
+	return 0, fmt.Errorf("unknown numeric equivalence %q", s)
+}
+
+// Option configures Process.
+type Option func(*Diff)
+
+// WithNumericEquivalence sets the rule used to compare number literals in
+// default expressions. The default is NumericEqualScale.
+func WithNumericEquivalence(equivalence NumericEquivalence) Option {
+	return func(df *Diff) {
+		df.numericEquivalence = equivalence
+	}
+}
+
+// WithMinimalQuoting writes identifiers in the generated statements without
+// double quotes unless they need them, as pg_dump does.
+func WithMinimalQuoting() Option {
+	return func(df *Diff) {
+		df.formatOptions.MinimalQuoting = true
+	}
+}
+
+// WithZeroDowntimeIndexes replaces a changed index by building the new one
+// concurrently under a temporary name, dropping the old one concurrently and
+// renaming the new one, instead of DROP INDEX followed by CREATE INDEX.
+func WithZeroDowntimeIndexes() Option {
+	return func(df *Diff) {
+		df.zeroDowntimeIndexes = true
+	}
 }
 
-func Process(source fileReader, desired fileReader) (string, error) {
+func Process(source fileReader, desired fileReader, options ...Option) (string, error) {
 	df := &Diff{
 		source:        source,
 		desired:       desired,
 		stringBuilder: &strings.Builder{},
 	}
+	for _, option := range options {
+		option(df)
+	}
 	df.sourceErrors, df.sourceDDL = df.parseOneSide(df.source)
 	df.desiredErrors, df.desiredDDL = df.parseOneSide(df.desired)
 	if df.ErrorOrNil() != nil {
 		return "", df.ErrorOrNil()
 	}
 
-	return df.generatePatch(), nil
+	patch := df.generatePatch()
+	if df.ErrorOrNil() != nil {
+		return "", df.ErrorOrNil()
+	}
+	return patch, nil
 }
 
 func (df *Diff) WriteString(s string) {
 	_, _ = df.stringBuilder.WriteString(s)
 }
 
+// writeNode writes node with the configured format options.
+func (df *Diff) writeNode(node ast.Node) {
+	node.WriteStringTo(ast.NewWriter(df.stringBuilder, df.formatOptions))
+}
+
+// formatNode formats node with the configured format options.
+func (df *Diff) formatNode(node ast.Node) string {
+	return ast.FormatNodeWith(node, df.formatOptions)
+}
+
+// quote quotes name as an identifier with the configured format options.
+func (df *Diff) quote(name string) string {
+	return df.formatOptions.QuoteIdentifier(name)
+}
+
+// tableName formats the qualified name of table.
+func (df *Diff) tableName(table *Table) string {
+	return df.formatNode(table.CreateTableStatement.TableName)
+}
+
 func (*Diff) parseOneSide(reader fileReader) (errs []error, ddl *ast.DataDefinition) {
 	input, err := ioutil.ReadAll(reader)
 	if err != nil {
 		errs = append(errs, err)
 		return
 	}
-	p := parser.New(lexer.Lex(reader.Name(), string(input)))
-	ddl = p.ParseDataDefinition()
-	errs = append(errs, p.Errors()...)
+	in := &includer{}
+	ddl = &ast.DataDefinition{StatementList: in.parse(reader.Name(), string(input))}
+	errs = append(errs, in.errs...)
 	return
 }
 
@@ -72,31 +183,62 @@ func (df *Diff) ErrorOrNil() error {
 	if df.desiredErrors != nil {
 		return &Error{df}
 	}
+	if df.patchErrors != nil {
+		return &Error{df}
+	}
 	return nil
 }
 
 type (
-	Tables                 map[string]*Table
-	Indexes                map[string]*Index
-	TableConstraints       map[string]*TableConstraint
-	AlterColumnSetDefaults map[string]*AlterColumnSetDefault
+	Tables           map[string]*Table
+	Indexes          map[string]*Index
+	TableConstraints map[string]*TableConstraint
 
 	Table struct {
 		CreateTableStatement *ast.CreateTableStatement
 		string
-		Identifier             string
-		Columns                map[string]*Column
-		Indexes                Indexes
-		TableConstraints       TableConstraints
-		AlterColumnSetDefaults AlterColumnSetDefaults
+		Identifier       string
+		Columns          map[string]*Column
+		Indexes          Indexes
+		TableConstraints TableConstraints
+		Inherits         []*ast.TableName
+		// PartitionOf is the parent of a partition, with its PartitionBound.
+		PartitionOf    *ast.TableName
+		PartitionBound *ast.PartitionBound
+		PartitionBy    *ast.PartitionBy
+		// Tablespace and AccessMethod are "" for the defaults.
+		Temporary         bool
+		Unlogged          bool
+		StorageParameters ast.StorageParameters
+		Tablespace        string
+		AccessMethod      string
+		// Policies apply only while RowLevelSecurity is enabled, and to the
+		// table owner only while it is forced.
+		Policies              Policies
+		RowLevelSecurity      bool
+		ForceRowLevelSecurity bool
+		// ReplicaIdentity is nil for DEFAULT and ClusterOn is "" when no
+		// index is marked for CLUSTER.
+		ReplicaIdentity *ast.ReplicaIdentity
+		ClusterOn       string
+		// Server is the server of a foreign table, and "" for other tables.
+		Server  string
+		Options ast.GenericOptions
 	}
 
 	Column struct {
 		Name         string
-		DataType     string
+		DataType     ast.DataType
 		NotNull      bool
-		Default      string
+		Default      ast.Expression
 		SequenceName string
+		// Statistics is -1, Storage and Compression are "" for the defaults.
+		Statistics  int
+		Storage     string
+		Compression string
+		// Collation is nil for the default collation of the data type.
+		Collation ast.Expression
+		Options   ast.GenericOptions
 	}
 
 	Index struct {
@@ -105,14 +247,10 @@ type (
 	}
 
 	TableConstraint struct {
-		Name    string
-		Type    ConstraintType
-		Columns []string
-	}
-
-	AlterColumnSetDefault struct {
-		Column  string
-		Default string
+		Name       string
+		Type       ConstraintType
+		Columns    []string
+		Constraint *ast.TableConstraint
 	}
 )
 
@@ -122,6 +260,8 @@ const (
 	Unknown    ConstraintType = ""
 	Unique     ConstraintType = "UNIQUE"
 	PrimaryKey ConstraintType = "PRIMARY KEY"
+	Check      ConstraintType = "CHECK"
+	ForeignKey ConstraintType = "FOREIGN KEY"
 )
 
 func (tables Tables) SortedKeys() (keys []string) {
@@ -132,18 +272,30 @@ func (tables Tables) SortedKeys() (keys []string) {
 	return
 }
 
+func (tableConstraints TableConstraints) SortedKeys() (keys []string) {
+	for k := range tableConstraints {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
 func (df *Diff) writeTableAnnotation(table *Table) {
-	df.stringBuilder.WriteString("-- Table: " + table.Identifier + "\n")
+	df.stringBuilder.WriteString("-- Table: " + df.tableName(table) + "\n")
 }
 
 func (df *Diff) generatePatch() string {
-	df.sourceTables = processDDL(df.sourceDDL)
-	df.desiredTables = processDDL(df.desiredDDL)
+	df.sourceTables, df.sourceCatalog = processDDL(df.sourceDDL)
+	df.desiredTables, df.desiredCatalog = processDDL(df.desiredDDL)
+	df.collectDependentForeignKeys()
+	df.createCollations()
+	df.createServers()
+	df.detachPartitions()
 
-	for _, identifier := range df.sourceTables.SortedKeys() {
+	for _, identifier := range df.sourceTables.sortedKeysParentsFirst(true) {
 		sourceTable := df.sourceTables[identifier]
 		desiredTable := df.desiredTables.FindTable(identifier)
-		if desiredTable != nil {
+		if desiredTable != nil && !recreatedForeign(sourceTable, desiredTable) {
 			origBuilder := df.stringBuilder
 			tmpBuilder := &strings.Builder{}
 			df.stringBuilder = tmpBuilder
@@ -154,17 +306,17 @@ func (df *Diff) generatePatch() string {
 				df.stringBuilder.WriteString(tmpBuilder.String())
 				df.stringBuilder.WriteString("\n")
 			}
-		} else {
+		} else if !df.droppedWithParent(sourceTable) && !df.importedTable(sourceTable) {
 			df.writeTableAnnotation(sourceTable)
 			df.dropTable(sourceTable)
 			df.stringBuilder.WriteString("\n")
 		}
 	}
 
-	for _, identifier := range df.desiredTables.SortedKeys() {
+	for _, identifier := range df.desiredTables.sortedKeysParentsFirst(false) {
 		desiredTable := df.desiredTables[identifier]
 		sourceTable := df.sourceTables.FindTable(identifier)
-		if sourceTable != nil {
+		if sourceTable != nil && !recreatedForeign(sourceTable, desiredTable) {
 			// none
 		} else {
 			df.writeTableAnnotation(desiredTable)
@@ -172,36 +324,115 @@ func (df *Diff) generatePatch() string {
 			df.stringBuilder.WriteString("\n")
 		}
 	}
+	df.attachPartitions()
+	df.importForeignSchemas()
+	df.dropServers()
+	df.dropCollations()
 
 	return df.stringBuilder.String()
 }
 
 func (df *Diff) createTable(table *Table) {
-	table.CreateTableStatement.WriteStringTo(df.stringBuilder)
-	for _, column := range table.Columns {
-		if column.SequenceName != "" {
+	// Write the columns as modelled, since ALTER TABLE may have changed them.
+	// A default is kept inline only where it was declared inline and does not
+	// need a sequence created first.
+	var setDefaults []*Column
+	createTableStatement := *table.CreateTableStatement
+	createTableStatement.ColumnDefinitionList = nil
+	createTableStatement.Inherits = table.Inherits
+	createTableStatement.PartitionBy = table.PartitionBy
+	createTableStatement.Temporary = table.Temporary
+	createTableStatement.Unlogged = table.Unlogged
+	createTableStatement.StorageParameters = table.StorageParameters
+	if table.foreign() {
+		createTableStatement.Foreign = true
+		createTableStatement.Server = newIdentifier(table.Server)
+		createTableStatement.Options = table.Options
+	}
+	if tablespace := tablespaceOf(table); tablespace != "pg_default" {
+		createTableStatement.Tablespace = newIdentifier(tablespace)
+	}
+	if accessMethod := accessMethodOf(table); accessMethod != "heap" {
+		createTableStatement.AccessMethod = newIdentifier(accessMethod)
+	}
+	if table.PartitionOf != nil {
+		// A partition takes its columns from the parent.
+		createTableStatement.PartitionOf = table.PartitionOf
+		createTableStatement.PartitionBound = table.PartitionBound
+		df.writeNode(&createTableStatement)
+		df.createTableObjects(table)
+		return
+	}
+	for _, columnDefinition := range table.CreateTableStatement.ColumnDefinitionList {
+		column := table.Columns[columnDefinition.Name.Value]
+		inlineDefault := column.SequenceName == "" && df.equalExpression(column.Default, declaredDefault(columnDefinition))
+		if column.Default != nil && !inlineDefault {
+			setDefaults = append(setDefaults, column)
+		}
+		createTableStatement.ColumnDefinitionList = append(createTableStatement.ColumnDefinitionList, column.definition(inlineDefault))
+	}
+	df.writeNode(&createTableStatement)
+	for _, columnDefinition := range table.CreateTableStatement.ColumnDefinitionList {
+		// Inherited columns use the sequences of the parent.
+		if column := table.Columns[columnDefinition.Name.Value]; column.SequenceName != "" {
 			df.addSequence(table, column)
+			df.ownSequence(table, column)
 		}
 	}
+	df.createTableObjects(table)
+	for _, column := range setDefaults {
+		df.setDefault(table, column)
+	}
+	for _, columnDefinition := range table.CreateTableStatement.ColumnDefinitionList {
+		df.setColumnStorage(table, table.Columns[columnDefinition.Name.Value])
+	}
+}
+
+// createTableObjects creates the indexes, constraints and policies of a new
+// table, except those a partition gets from its parent.
+func (df *Diff) createTableObjects(table *Table) {
+	clonedIndexes := df.clonedIndexes(df.desiredTables, table)
 	for _, index := range table.Indexes {
-		index.CreateIndexStatement.WriteStringTo(df.stringBuilder)
+		if clonedIndexes[index.Name] {
+			continue
+		}
+		df.writeNode(index.CreateIndexStatement)
 		df.stringBuilder.WriteString("\n")
 	}
+	clonedConstraints := df.clonedConstraints(df.desiredTables, table)
 	for _, constraint := range table.TableConstraints {
+		if clonedConstraints[constraint.Name] {
+			continue
+		}
 		df.addTableConstraint(table, constraint)
 	}
-	for _, alterColumnSetDefault := range table.AlterColumnSetDefaults {
-		df.addAlterColumnSetDefault(table, alterColumnSetDefault)
+	for _, name := range table.Policies.SortedKeys() {
+		df.createPolicy(table, table.Policies[name])
 	}
+	newTable := &Table{CreateTableStatement: table.CreateTableStatement}
+	df.diffRowLevelSecurity(newTable, table)
+	df.diffIndexSettings(newTable, table)
 }
 
 func (df *Diff) dropTable(table *Table) {
-	df.WriteString(fmt.Sprintf("DROP TABLE %s;\n", table.Identifier))
+	df.WriteString(fmt.Sprintf("DROP %s %s;\n", table.keyword(), df.tableName(table)))
 }
 
 // generate DDL for a table which exists in both.
 func (df *Diff) diffTable(sourceTable, desiredTable *Table) {
+	if !df.checkPartitionBy(sourceTable, desiredTable) || !df.checkTemporary(sourceTable, desiredTable) {
+		return
+	}
+	df.diffInherits(sourceTable, desiredTable, true)
+	df.diffStorage(sourceTable, desiredTable)
+	df.diffOptions(sourceTable, desiredTable)
+	df.diffPolicies(sourceTable, desiredTable, true)
+
+	inheritedColumns := df.inheritedColumns(sourceTable, desiredTable)
 	for _, sourceColumn := range sourceTable.Columns {
+		if inheritedColumns[sourceColumn.Name] {
+			continue
+		}
 		desiredColumn, ok := desiredTable.Columns[sourceColumn.Name]
 		if ok {
 			df.alterColumn(sourceTable, sourceColumn, desiredColumn)
@@ -212,16 +443,21 @@ func (df *Diff) diffTable(sourceTable, desiredTable *Table) {
 
 	for _, desiredColumn := range desiredTable.Columns {
 		_, ok := sourceTable.Columns[desiredColumn.Name]
-		if !ok {
+		if !ok && !inheritedColumns[desiredColumn.Name] {
 			df.addColumn(sourceTable, desiredColumn)
 		}
 	}
 
+	inheritedIndexes := df.inheritedIndexes(sourceTable, desiredTable)
 	for _, sourceIndex := range sourceTable.Indexes {
+		if inheritedIndexes[sourceIndex.Name] {
+			continue
+		}
 		desiredIndex, ok := desiredTable.Indexes[sourceIndex.Name]
 		if ok {
-			// TODO: ALTER INDEX ?
-			_ = desiredIndex
+			if !df.equalIndex(sourceIndex, desiredIndex) {
+				df.replaceIndex(sourceTable, sourceIndex, desiredIndex)
+			}
 		} else {
 			df.dropIndex(sourceTable, sourceIndex)
 		}
@@ -229,189 +465,580 @@ func (df *Diff) diffTable(sourceTable, desiredTable *Table) {
 
 	for _, desiredIndex := range desiredTable.Indexes {
 		_, ok := sourceTable.Indexes[desiredIndex.Name]
-		if !ok {
+		if !ok && !inheritedIndexes[desiredIndex.Name] {
 			df.createIndex(sourceTable, desiredIndex)
 		}
 	}
 
+	inheritedConstraints := df.inheritedConstraints(sourceTable, desiredTable)
 	for _, sourceTableConstraint := range sourceTable.TableConstraints {
+		if df.handledConstraints[sourceTableConstraint] || inheritedConstraints[sourceTableConstraint.Name] {
+			continue
+		}
 		desiredTableConstraint, ok := desiredTable.TableConstraints[sourceTableConstraint.Name]
 		if ok {
-			// TODO: MODIFY CONSTRAINT ?
-			_ = desiredTableConstraint
+			if !df.equalTableConstraint(sourceTableConstraint, desiredTableConstraint) {
+				df.replaceTableConstraint(sourceTable, sourceTableConstraint, desiredTableConstraint)
+			}
 		} else {
 			df.dropTableConstraint(sourceTable, sourceTableConstraint)
 		}
 	}
 
 	for _, desiredTableConstraint := range desiredTable.TableConstraints {
+		if df.handledConstraints[desiredTableConstraint] || inheritedConstraints[desiredTableConstraint.Name] {
+			continue
+		}
 		_, ok := sourceTable.TableConstraints[desiredTableConstraint.Name]
 		if !ok {
 			df.addTableConstraint(sourceTable, desiredTableConstraint)
 		}
 	}
 
-	for _, sourceAlterColumnSetDefault := range sourceTable.AlterColumnSetDefaults {
-		desiredAlterColumnSetDefault, ok := desiredTable.AlterColumnSetDefaults[sourceAlterColumnSetDefault.Column]
-		if ok {
-			// TODO: MODIFY CONSTRAINT ?
-			_ = desiredAlterColumnSetDefault
-		} else {
-			// a little malfunctioned.
-			// df.dropAlterColumnSetDefault(sourceTable, sourceAlterColumnSetDefault)
-		}
-	}
-
-	for _, desiredAlterColumnSetDefault := range desiredTable.AlterColumnSetDefaults {
-		_, ok := sourceTable.AlterColumnSetDefaults[desiredAlterColumnSetDefault.Column]
-		if !ok {
-			df.addAlterColumnSetDefault(sourceTable, desiredAlterColumnSetDefault)
-		}
-	}
+	df.diffIndexSettings(sourceTable, desiredTable)
+	df.diffPolicies(sourceTable, desiredTable, false)
+	df.diffRowLevelSecurity(sourceTable, desiredTable)
+	df.diffInherits(sourceTable, desiredTable, false)
 }
 
 func (df *Diff) addColumn(table *Table, column *Column) {
-	df.WriteString(fmt.Sprintf("ALTER TABLE %s ADD COLUMN \"%s\" %s",
-		table.Identifier,
-		column.Name,
-		column.DataType,
+	if column.SequenceName != "" {
+		df.addSequence(table, column)
+	}
+	df.WriteString(fmt.Sprintf("ALTER %s %s ADD COLUMN %s %s",
+		table.keyword(), df.tableName(table),
+		df.quote(column.Name),
+		df.formatNode(column.DataType),
 	))
-	if column.Default != "" {
-		df.WriteString(" DEFAULT " + column.Default)
+	if column.Compression != "" {
+		df.WriteString(" COMPRESSION " + df.quote(column.Compression))
+	}
+	if len(column.Options) > 0 {
+		df.WriteString(" " + df.formatNode(column.Options))
+	}
+	if column.Collation != nil {
+		df.WriteString(" COLLATE " + df.formatNode(column.Collation))
+	}
+	if column.Default != nil {
+		df.WriteString(" DEFAULT " + df.formatNode(column.Default))
 	}
 	if column.NotNull {
 		df.WriteString(" NOT NULL")
 	}
 	df.WriteString(";\n")
+	if column.SequenceName != "" {
+		df.ownSequence(table, column)
+	}
+	df.setColumnStorage(table, column)
+}
+
+// setColumnStorage writes the statistics target and storage mode of a new
+// column when they are not the defaults.
+func (df *Diff) setColumnStorage(table *Table, column *Column) {
+	df.alterColumnStorage(table, &Column{Statistics: -1, Compression: column.Compression}, column)
 }
 
+// addSequence creates the sequence of a serial column, with the data type
+// PostgreSQL gives it. It must be created before the default using it, and
+// owned by the column with ownSequence once the column exists.
 func (df *Diff) addSequence(table *Table, column *Column) {
 	var (
 		schemaName = table.CreateTableStatement.TableName.SchemaIdentifier.Value
-		tableName  = table.CreateTableStatement.TableName.TableIdentifier.Value
-		columnName = column.Name
 		sequence   = column.SequenceName
+		dataType   string
 	)
+	// bigint is the default.
+	if typ := sequenceDataType(column); typ != "bigint" {
+		dataType = "\n    AS " + typ
+	}
 	df.WriteString(
-		fmt.Sprintf(`CREATE SEQUENCE "%s"."%s"
+		fmt.Sprintf(`CREATE SEQUENCE %s.%s%s
     START WITH 1
     INCREMENT BY 1
     NO MINVALUE
     NO MAXVALUE
-    CACHE 1;
-ALTER SEQUENCE "%s"."%s" OWNED BY "%s"."%s";`,
-			schemaName, sequence,
-			schemaName, sequence, tableName, columnName),
+    CACHE 1;`,
+			df.quote(schemaName), df.quote(sequence), dataType),
 	)
 	df.WriteString("\n")
 }
 
-func (df *Diff) addTableConstraint(table *Table, tableConstraint *TableConstraint) {
-	df.WriteString(fmt.Sprintf(
-		`ALTER TABLE ONLY %s ADD CONSTRAINT "%s" %s (`,
-		table.Identifier,
-		tableConstraint.Name,
-		tableConstraint.Type,
+func (df *Diff) ownSequence(table *Table, column *Column) {
+	var (
+		schemaName = table.CreateTableStatement.TableName.SchemaIdentifier.Value
+		tableName  = table.CreateTableStatement.TableName.TableIdentifier.Value
+		columnName = column.Name
+		sequence   = column.SequenceName
+	)
+	df.WriteString(fmt.Sprintf("ALTER SEQUENCE %s.%s OWNED BY %s.%s.%s;\n",
+		df.quote(schemaName), df.quote(sequence),
+		df.quote(schemaName), df.quote(tableName), df.quote(columnName),
+	))
+}
+
+// sequenceDataType returns the data type PostgreSQL gives the sequence of
+// the serial column.
+func sequenceDataType(column *Column) string {
+	switch column.DataType.(type) {
+	case *ast.DataTypeSmallint:
+		return "smallint"
+	case *ast.DataTypeInteger:
+		return "integer"
+	default:
+		return "bigint"
+	}
+}
+
+// alterSequence changes the data type of the sequence of a serial column
+// whose type changed, as serial to bigserial, which PostgreSQL leaves alone.
+func (df *Diff) alterSequence(table *Table, sourceColumn, desiredColumn *Column) {
+	if sourceColumn.SequenceName == "" || sourceColumn.SequenceName != desiredColumn.SequenceName {
+		return
+	}
+	if typ := sequenceDataType(desiredColumn); typ != sequenceDataType(sourceColumn) {
+		df.WriteString(fmt.Sprintf("ALTER SEQUENCE %s.%s AS %s;\n",
+			df.quote(table.CreateTableStatement.TableName.SchemaIdentifier.Value), df.quote(desiredColumn.SequenceName),
+			typ,
+		))
+	}
+}
+
+// dropSequence drops the sequence sourceColumn no longer owns, as when a
+// serial column becomes a plain integer. It must follow the change of the
+// default which uses it.
+func (df *Diff) dropSequence(table *Table, sourceColumn, desiredColumn *Column) {
+	if sourceColumn.SequenceName == "" || sourceColumn.SequenceName == desiredColumn.SequenceName {
+		return
+	}
+	df.WriteString(fmt.Sprintf("DROP SEQUENCE %s.%s;\n",
+		df.quote(table.CreateTableStatement.TableName.SchemaIdentifier.Value), df.quote(sourceColumn.SequenceName),
 	))
+}
+
+// addsSequence reports whether desiredColumn becomes serial, needing its
+// sequence to be created.
+func addsSequence(sourceColumn, desiredColumn *Column) bool {
+	return desiredColumn.SequenceName != "" && sourceColumn.SequenceName != desiredColumn.SequenceName
+}
+
+func (df *Diff) addTableConstraint(table *Table, tableConstraint *TableConstraint) {
+	df.WriteString(fmt.Sprintf("ALTER %s %s%s ", table.keyword(), df.only(table, tableConstraint), df.tableName(table)))
+	df.writeNode(tableConstraint.Constraint)
+	df.WriteString(";\n")
+}
+
+// replaceTableConstraint drops sourceConstraint and adds desiredConstraint of
+// the same name. Foreign keys referencing a replaced primary key or unique
+// constraint are dropped first and re-added last.
+func (df *Diff) replaceTableConstraint(table *Table, sourceConstraint, desiredConstraint *TableConstraint) {
+	dependents := df.dependentForeignKeys[sourceConstraint]
+	for _, dependent := range dependents {
+		df.dropTableConstraint(dependent.table, dependent.source)
+	}
+	df.dropTableConstraint(table, sourceConstraint)
+	df.addTableConstraint(table, desiredConstraint)
+	for _, dependent := range dependents {
+		if dependent.desired != nil {
+			df.addTableConstraint(dependent.table, dependent.desired)
+		}
+	}
+}
 
-	for i, c := range tableConstraint.Columns {
-		if i != 0 {
-			df.WriteString(", ")
+// equalTableConstraint reports whether a and b define the same constraint.
+// NOT VALID is ignored since it only skips the check of existing rows.
+func (df *Diff) equalTableConstraint(a, b *TableConstraint) bool {
+	return df.formatForComparison(normalizeTableConstraint(a.Constraint)) ==
+		df.formatForComparison(normalizeTableConstraint(b.Constraint))
+}
+
+// equalConstraintDefinition reports whether a and b are the same constraint
+// under any names.
+func (df *Diff) equalConstraintDefinition(a, b *TableConstraint) bool {
+	normalizedA, normalizedB := normalizeTableConstraint(a.Constraint), normalizeTableConstraint(b.Constraint)
+	normalizedA.Name, normalizedB.Name = nil, nil
+	return df.formatForComparison(normalizedA) == df.formatForComparison(normalizedB)
+}
+
+// normalizeTableConstraint returns a copy of tableConstraint without NOT
+// VALID, the default MATCH SIMPLE, NO ACTION and INITIALLY IMMEDIATE, and
+// with its storage parameters sorted.
+func normalizeTableConstraint(tableConstraint *ast.TableConstraint) *ast.TableConstraint {
+	normalized := *tableConstraint
+	normalized.NotValid = false
+	if normalized.Check != nil {
+		normalized.Check = normalizeGrouping(normalized.Check)
+	}
+	if normalized.References != nil {
+		references := *normalized.References
+		if references.Match == "SIMPLE" {
+			references.Match = ""
+		}
+		if references.OnDelete == "NO ACTION" {
+			references.OnDelete = ""
+		}
+		if references.OnUpdate == "NO ACTION" {
+			references.OnUpdate = ""
 		}
-		df.WriteString(fmt.Sprintf(`"%s"`, c))
+		normalized.References = &references
 	}
-	df.WriteString(");\n")
+	if normalized.IndexParameters != nil {
+		indexParameters := *normalized.IndexParameters
+		indexParameters.StorageParameters = append(ast.StorageParameters(nil), indexParameters.StorageParameters...)
+		sort.SliceStable(indexParameters.StorageParameters, func(i, j int) bool {
+			return ast.FormatNode(indexParameters.StorageParameters[i]) < ast.FormatNode(indexParameters.StorageParameters[j])
+		})
+		normalized.IndexParameters = &indexParameters
+	}
+	return &normalized
 }
 
-func (df *Diff) addAlterColumnSetDefault(table *Table, alterColumnSetDefault *AlterColumnSetDefault) {
+// collectDependentForeignKeys finds the foreign keys referencing a primary
+// key or unique constraint that is going to be replaced. PostgreSQL refuses
+// to drop such a constraint while a foreign key depends on it.
+func (df *Diff) collectDependentForeignKeys() {
+	df.dependentForeignKeys = make(map[*TableConstraint][]*foreignKeyReference)
+	df.handledConstraints = make(map[*TableConstraint]bool)
+
+	for _, identifier := range df.sourceTables.SortedKeys() {
+		sourceTable := df.sourceTables[identifier]
+		desiredTable := df.desiredTables.FindTable(identifier)
+		if desiredTable == nil {
+			continue
+		}
+		for _, sourceConstraint := range sourceTable.TableConstraints {
+			if sourceConstraint.Type != PrimaryKey && sourceConstraint.Type != Unique {
+				continue
+			}
+			desiredConstraint, ok := desiredTable.TableConstraints[sourceConstraint.Name]
+			if !ok || df.equalTableConstraint(sourceConstraint, desiredConstraint) {
+				continue
+			}
+			df.dependentForeignKeys[sourceConstraint] = df.findReferencingForeignKeys(sourceTable, sourceConstraint)
+		}
+	}
+}
+
+func (df *Diff) findReferencingForeignKeys(table *Table, referenced *TableConstraint) (dependents []*foreignKeyReference) {
+	for _, identifier := range df.sourceTables.SortedKeys() {
+		referencingTable := df.sourceTables[identifier]
+		desiredTable := df.desiredTables.FindTable(identifier)
+		if desiredTable == nil {
+			// The table is dropped along with its foreign keys.
+			continue
+		}
+		for _, name := range referencingTable.TableConstraints.SortedKeys() {
+			foreignKey := referencingTable.TableConstraints[name]
+			if foreignKey.Type != ForeignKey || df.handledConstraints[foreignKey] || !references(foreignKey, table, referenced) {
+				continue
+			}
+			dependent := &foreignKeyReference{table: referencingTable, source: foreignKey}
+			df.handledConstraints[foreignKey] = true
+			if desired, ok := desiredTable.TableConstraints[name]; ok {
+				dependent.desired = desired
+				df.handledConstraints[desired] = true
+			}
+			dependents = append(dependents, dependent)
+		}
+	}
+	return
+}
+
+// references reports whether foreignKey references the columns of the
+// primary key or unique constraint referenced on table.
+func references(foreignKey *TableConstraint, table *Table, referenced *TableConstraint) bool {
+	target := foreignKey.Constraint.References
+	if target.Table.String() != table.Identifier {
+		return false
+	}
+	if target.ColumnList == nil {
+		return referenced.Type == PrimaryKey
+	}
+	if len(target.ColumnList.ColumnNames) != len(referenced.Columns) {
+		return false
+	}
+	for i, column := range target.ColumnList.ColumnNames {
+		if column.Value != referenced.Columns[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (df *Diff) setDefault(table *Table, column *Column) {
 	df.WriteString(fmt.Sprintf(
-		"ALTER TABLE ONLY %s ALTER COLUMN \"%s\" SET DEFAULT %s;\n",
-		table.Identifier,
-		alterColumnSetDefault.Column,
-		alterColumnSetDefault.Default,
+		"ALTER %s ONLY %s ALTER COLUMN %s SET DEFAULT %s;\n",
+		table.keyword(), df.tableName(table),
+		df.quote(column.Name),
+		df.formatNode(column.Default),
 	))
 }
 
 func (df *Diff) dropColumn(table *Table, column *Column) {
-	df.WriteString(fmt.Sprintf("ALTER TABLE %s DROP COLUMN \"%s\";\n",
-		table.Identifier,
-		column.Name,
+	df.WriteString(fmt.Sprintf("ALTER %s %s DROP COLUMN %s;\n",
+		table.keyword(), df.tableName(table),
+		df.quote(column.Name),
 	))
 }
 
 func (df *Diff) alterColumn(table *Table, sourceColumn *Column, desiredColumn *Column) {
-	if strings.HasPrefix(sourceColumn.DataType, "character") && desiredColumn.DataType == "bytea" {
-		df.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN \"%s\" TYPE %s USING %s::bytea;\n",
-			table.Identifier,
-			desiredColumn.Name,
-			desiredColumn.DataType,
-			desiredColumn.Name,
+	if addsSequence(sourceColumn, desiredColumn) {
+		df.addSequence(table, desiredColumn)
+	}
+	var (
+		sourceDataType  = df.formatNode(sourceColumn.DataType)
+		desiredDataType = df.formatNode(desiredColumn.DataType)
+	)
+	if strings.HasPrefix(sourceDataType, "character") && desiredColumn.DataType.Name() == ast.Bytea {
+		df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s TYPE %s USING %s::bytea;\n",
+			table.keyword(), df.tableName(table),
+			df.quote(desiredColumn.Name),
+			df.formatNode(desiredColumn.DataType),
+			df.quote(desiredColumn.Name),
 		))
-	} else if sourceColumn.DataType != desiredColumn.DataType {
-		df.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN \"%s\" TYPE %s;\n",
-			table.Identifier,
-			desiredColumn.Name,
-			desiredColumn.DataType,
+	} else if sourceDataType != desiredDataType || !df.equalExpression(sourceColumn.Collation, desiredColumn.Collation) {
+		// Changing the type resets the collation, so it is always given.
+		var collate string
+		if desiredColumn.Collation != nil {
+			collate = " COLLATE " + df.formatNode(desiredColumn.Collation)
+		} else if sourceColumn.Collation != nil {
+			collate = " COLLATE " + df.quote("default")
+		}
+		df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s TYPE %s%s;\n",
+			table.keyword(), df.tableName(table),
+			df.quote(desiredColumn.Name),
+			df.formatNode(desiredColumn.DataType),
+			collate,
 		))
 	}
+	df.alterSequence(table, sourceColumn, desiredColumn)
 
 	if sourceColumn.NotNull != desiredColumn.NotNull {
 		if desiredColumn.NotNull {
 			// SET not null
-			df.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN \"%s\" SET NOT NULL;\n",
-				table.Identifier,
-				desiredColumn.Name,
+			df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s SET NOT NULL;\n",
+				table.keyword(), df.tableName(table),
+				df.quote(desiredColumn.Name),
 			))
 		} else {
 			// Drop not null
-			df.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN \"%s\" DROP NOT NULL;\n",
-				table.Identifier,
-				desiredColumn.Name,
+			df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s DROP NOT NULL;\n",
+				table.keyword(), df.tableName(table),
+				df.quote(desiredColumn.Name),
 			))
 		}
 	}
 
-	if sourceColumn.Default != desiredColumn.Default {
-		if desiredColumn.Default != "" {
+	df.alterColumnStorage(table, sourceColumn, desiredColumn)
+	df.alterColumnOptions(table, sourceColumn, desiredColumn)
+
+	if !df.equalExpression(sourceColumn.Default, desiredColumn.Default) {
+		if desiredColumn.Default != nil {
 			// SET DEFAULT
-			df.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN \"%s\" SET DEFAULT %s;\n",
-				table.Identifier,
-				desiredColumn.Name,
-				desiredColumn.Default,
+			df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s SET DEFAULT %s;\n",
+				table.keyword(), df.tableName(table),
+				df.quote(desiredColumn.Name),
+				df.formatNode(desiredColumn.Default),
 			))
 		} else {
 			// DROP DEFAULT
-			df.WriteString(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN \"%s\" DROP DEFAULT;\n",
-				table.Identifier,
-				desiredColumn.Name,
+			df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s DROP DEFAULT;\n",
+				table.keyword(), df.tableName(table),
+				df.quote(desiredColumn.Name),
 			))
 		}
 	}
+	if addsSequence(sourceColumn, desiredColumn) {
+		df.ownSequence(table, desiredColumn)
+	}
+	df.dropSequence(table, sourceColumn, desiredColumn)
+}
+
+// alterColumnStorage writes the changes to the statistics target, storage
+// mode and compression method of a column.
+func (df *Diff) alterColumnStorage(table *Table, sourceColumn *Column, desiredColumn *Column) {
+	if sourceColumn.Statistics != desiredColumn.Statistics {
+		df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s SET STATISTICS %d;\n",
+			table.keyword(), df.tableName(table),
+			df.quote(desiredColumn.Name),
+			desiredColumn.Statistics,
+		))
+	}
+	if sourceColumn.Storage != desiredColumn.Storage {
+		storage := desiredColumn.Storage
+		if storage == "" {
+			storage = "DEFAULT"
+		}
+		df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s SET STORAGE %s;\n",
+			table.keyword(), df.tableName(table),
+			df.quote(desiredColumn.Name),
+			storage,
+		))
+	}
+	if sourceColumn.Compression != desiredColumn.Compression {
+		compression := "DEFAULT"
+		if desiredColumn.Compression != "" {
+			compression = df.quote(desiredColumn.Compression)
+		}
+		df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s SET COMPRESSION %s;\n",
+			table.keyword(), df.tableName(table),
+			df.quote(desiredColumn.Name),
+			compression,
+		))
+	}
+}
+
+// equalIndex reports whether two indexes have the same definition once
+// spelled-out defaults are removed, regardless of how each is to be created.
+func (df *Diff) equalIndex(a, b *Index) bool {
+	return df.formatForComparison(normalizeIndex(a.CreateIndexStatement)) ==
+		df.formatForComparison(normalizeIndex(b.CreateIndexStatement))
+}
+
+// equalIndexDefinition reports whether a and b index their tables alike,
+// under any names.
+func (df *Diff) equalIndexDefinition(a, b *Index) bool {
+	normalizedA, normalizedB := normalizeIndex(a.CreateIndexStatement), normalizeIndex(b.CreateIndexStatement)
+	normalizedA.Name, normalizedB.Name = nil, nil
+	normalizedB.TableName = normalizedA.TableName
+	return df.formatForComparison(normalizedA) == df.formatForComparison(normalizedB)
+}
+
+// normalizeIndex returns a copy of createIndexStatement without what does not
+// change the index: CONCURRENTLY, IF NOT EXISTS, USING btree, the default
+// NULLS ordering and the order of storage parameters.
+func normalizeIndex(createIndexStatement *ast.CreateIndexStatement) *ast.CreateIndexStatement {
+	normalized := *createIndexStatement
+	normalized.Concurrently = false
+	normalized.IfNotExists = false
+	if normalized.UsingMethod != nil && normalized.UsingMethod.Value == "btree" {
+		normalized.UsingMethod = nil
+	}
+
+	normalized.IndexTargets = make([]*ast.IndexTarget, len(createIndexStatement.IndexTargets))
+	for i, indexTarget := range createIndexStatement.IndexTargets {
+		target := *indexTarget
+		if expr, ok := target.Node.(ast.Expression); ok {
+			target.Node = normalizeIndexExpression(expr)
+		}
+		// ASC implies NULLS LAST and DESC implies NULLS FIRST.
+		if target.IsDesc {
+			target.NullsFirst = false
+		} else {
+			target.NullsLast = false
+		}
+		normalized.IndexTargets[i] = &target
+	}
+
+	normalized.StorageParameters = append(ast.StorageParameters(nil), createIndexStatement.StorageParameters...)
+	sort.SliceStable(normalized.StorageParameters, func(i, j int) bool {
+		return ast.FormatNode(normalized.StorageParameters[i]) < ast.FormatNode(normalized.StorageParameters[j])
+	})
+	if normalized.Where != nil {
+		normalized.Where = normalizeGrouping(normalized.Where)
+	}
+	return &normalized
+}
+
+// normalizeIndexExpression normalizes the groupings of an index element,
+// which is bare only when it is a column or a function call.
+func normalizeIndexExpression(expr ast.Expression) ast.Expression {
+	normalized := normalizeGrouping(expr)
+	switch normalized.(type) {
+	case *ast.Identifier, *ast.CallExpression:
+		return normalized
+	default:
+		return &ast.GroupedExpression{Expression: normalized}
+	}
+}
+
+// formatForComparison formats node so that two nodes are written alike when
+// they are equivalent under the configured rules.
+func (df *Diff) formatForComparison(node ast.Node) string {
+	var options ast.FormatOptions
+	switch df.numericEquivalence {
+	case NumericEqualScale:
+		options.Numeric = ast.NumericNormalized
+	case NumericEqualValue:
+		options.Numeric = ast.NumericValue
+	}
+	return ast.FormatNodeWith(node, options)
+}
+
+// equalExpression reports whether a and b are the same expression under the
+// configured numeric equivalence.
+func (df *Diff) equalExpression(a, b ast.Expression) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return df.formatForComparison(a) == df.formatForComparison(b)
 }
 
 func (df *Diff) createIndex(_ *Table, index *Index) {
-	index.CreateIndexStatement.WriteStringTo(df.stringBuilder)
+	df.writeNode(index.CreateIndexStatement)
 	df.WriteString("\n")
 }
 
+// replaceIndex replaces sourceIndex with desiredIndex of the same name. When
+// zero-downtime replacement is requested, the new index is built
+// concurrently under a temporary name before the old one is dropped, so that
+// the table is never left without it. Such statements cannot run inside a
+// transaction block.
+func (df *Diff) replaceIndex(table *Table, sourceIndex, desiredIndex *Index) {
+	if !df.zeroDowntimeIndexes {
+		df.dropIndex(table, sourceIndex)
+		df.createIndex(table, desiredIndex)
+		return
+	}
+
+	temporaryName := makeObjectName(desiredIndex.Name, "", "new")
+	statement := *desiredIndex.CreateIndexStatement
+	statement.Concurrently = true
+	statement.IfNotExists = false
+	statement.Name = &ast.Identifier{
+		Token: token.Token{Type: token.Identifier, Literal: ast.QuoteIdentifier(temporaryName)},
+		Value: temporaryName,
+	}
+	df.writeNode(&statement)
+	df.WriteString("\n")
+	df.WriteString(fmt.Sprintf("DROP INDEX CONCURRENTLY %s;\n",
+		df.indexName(table, sourceIndex.Name),
+	))
+	df.WriteString(fmt.Sprintf("ALTER INDEX %s RENAME TO %s;\n",
+		df.indexName(table, temporaryName),
+		df.quote(desiredIndex.Name),
+	))
+}
+
 func (df *Diff) dropIndex(table *Table, index *Index) {
-	df.WriteString(fmt.Sprintf("DROP INDEX \"%s\";\n",
-		index.Name,
+	df.WriteString(fmt.Sprintf("DROP INDEX %s;\n",
+		df.indexName(table, index.Name),
 	))
 }
 
+// indexName qualifies the index name with the schema of table, which is
+// where an index lives.
+func (df *Diff) indexName(table *Table, name string) string {
+	return df.quote(table.CreateTableStatement.TableName.SchemaIdentifier.Value) + "." + df.quote(name)
+}
+
 func (df *Diff) dropTableConstraint(table *Table, tableConstraint *TableConstraint) {
-	df.WriteString(fmt.Sprintf("ALTER TABLE ONLY %s DROP CONSTRAINT \"%s\";\n",
-		table.Identifier,
-		tableConstraint.Name,
+	df.WriteString(fmt.Sprintf("ALTER %s %s%s DROP CONSTRAINT %s;\n",
+		table.keyword(), df.only(table, tableConstraint), df.tableName(table),
+		df.quote(tableConstraint.Name),
 	))
 }
 
-func (df *Diff) dropAlterColumnSetDefault(table *Table, alterColumnSetDefault *AlterColumnSetDefault) {
-	df.WriteString(fmt.Sprintf("ALTER TABLE ONLY %s ALTER COLUMN \"%s\" DROP DEFAULT;\n",
-		table.Identifier,
-		alterColumnSetDefault.Column,
-	))
+// only returns "ONLY " unless tableConstraint is a CHECK constraint the
+// tables inheriting from table share, which PostgreSQL only adds or drops
+// on the whole hierarchy.
+func (df *Diff) only(table *Table, tableConstraint *TableConstraint) string {
+	if tableConstraint.Type != Check || tableConstraint.Constraint.NoInherit {
+		return "ONLY "
+	}
+	if len(df.sourceTables.children(table.Identifier)) == 0 && len(df.desiredTables.children(table.Identifier)) == 0 {
+		return "ONLY "
+	}
+	return ""
 }
 
 func (tables Tables) FindTable(identifier string) *Table {
@@ -419,41 +1046,213 @@ func (tables Tables) FindTable(identifier string) *Table {
 }
 
 func (tables Tables) FindTableBy(searchPath, tableName string) *Table {
-	return tables[`"`+searchPath+`"."`+tableName+`"`]
+	return tables[ast.QuoteIdentifier(searchPath)+"."+ast.QuoteIdentifier(tableName)]
 }
 
-func (tables Tables) AddTable(searchPath string, createTableStatement *ast.CreateTableStatement) {
+// qualify sets the schema of an unqualified tableName to the first schema on
+// searchPath holding such a table, or to the creation schema if none does.
+func (tables Tables) qualify(searchPath SearchPath, tableName *ast.TableName) {
+	if tableName.SchemaIdentifier != nil {
+		return
+	}
+	for _, schema := range searchPath {
+		if tables.FindTableBy(schema, tableName.TableIdentifier.Value) != nil {
+			tableName.SetSchema(schema)
+			return
+		}
+	}
+	tableName.SetSchema(searchPath.creationSchema())
+}
+
+func (tables Tables) AddTable(searchPath SearchPath, defaults *tableDefaults, createTableStatement *ast.CreateTableStatement) {
 	if createTableStatement.TableName.SchemaIdentifier == nil {
-		createTableStatement.TableName.SetSchema(searchPath)
+		if createTableStatement.Temporary {
+			createTableStatement.TableName.SetSchema("pg_temp")
+		} else {
+			createTableStatement.TableName.SetSchema(searchPath.creationSchema())
+		}
 	}
 	identifier := createTableStatement.TableName.String()
+	if createTableStatement.IfNotExists && tables.FindTable(identifier) != nil {
+		return
+	}
+
+	table := &Table{
+		CreateTableStatement: createTableStatement,
+		Identifier:           identifier,
+		Columns:              make(map[string]*Column),
+		Indexes:              make(Indexes),
+		TableConstraints:     make(TableConstraints),
+		Policies:             make(Policies),
+		PartitionBound:       createTableStatement.PartitionBound,
+		PartitionBy:          createTableStatement.PartitionBy,
+		Temporary:            createTableStatement.Temporary,
+		Unlogged:             createTableStatement.Unlogged,
+		StorageParameters:    createTableStatement.StorageParameters,
+		Tablespace:           defaults.tablespace,
+		AccessMethod:         defaults.accessMethod,
+		Options:              createTableStatement.Options,
+	}
+	if createTableStatement.Server != nil {
+		// A foreign table has no storage of its own.
+		table.Server = createTableStatement.Server.Value
+		table.Tablespace = ""
+		table.AccessMethod = ""
+	}
+	if createTableStatement.Tablespace != nil {
+		table.Tablespace = createTableStatement.Tablespace.Value
+	}
+	if createTableStatement.AccessMethod != nil {
+		table.AccessMethod = createTableStatement.AccessMethod.Value
+	}
+	columnDefinitionList := createTableStatement.ColumnDefinitionList
+	createTableStatement.ColumnDefinitionList = nil
+	for _, columnDefinition := range columnDefinitionList {
+		table.addColumn(columnDefinition)
+	}
+	// The constraints are modelled as if added by ALTER TABLE, which is how
+	// they are created.
+	for _, tableConstraint := range createTableStatement.TableConstraints {
+		if tableConstraint.References != nil {
+			tables.qualify(searchPath, tableConstraint.References.Table)
+		}
+		table.addConstraint(tableConstraint)
+	}
+	createTableStatement.TableConstraints = nil
+	if partitionOf := createTableStatement.PartitionOf; partitionOf != nil {
+		tables.qualify(searchPath, partitionOf)
+		table.PartitionOf = partitionOf
+	}
+	for _, parent := range createTableStatement.Inherits {
+		tables.qualify(searchPath, parent)
+		table.Inherits = append(table.Inherits, parent)
+	}
+	for _, parent := range table.parents() {
+		if tables.FindTable(parent.String()) == nil {
+			log.Printf("irregular inherit from unknown table=%s", parent)
+		}
+	}
+	tables.inheritColumns(table)
+	createTableStatement.Inherits = nil
+	createTableStatement.PartitionOf = nil
+	createTableStatement.PartitionBound = nil
+	createTableStatement.PartitionBy = nil
+	createTableStatement.IfNotExists = false
+	createTableStatement.Temporary = false
+	createTableStatement.Unlogged = false
+	createTableStatement.StorageParameters = nil
+	createTableStatement.Tablespace = nil
+	createTableStatement.AccessMethod = nil
+	createTableStatement.Foreign = false
+	createTableStatement.Server = nil
+	createTableStatement.Options = nil
+
+	tables[identifier] = table
+}
 
-	columns := make(map[string]*Column)
-	for _, columnDefinition := range createTableStatement.ColumnDefinitionList {
-		col := columnFromAst(columnDefinition)
-		columns[col.Name] = col
+// expandSerial rewrites a smallserial, serial or bigserial column into its
+// underlying integer type with NOT NULL, as PostgreSQL does, and returns the
+// name of the implicit sequence. It returns "" for any other column.
+func expandSerial(tableName *ast.TableName, columnDefinition *ast.ColumnDefinition) string {
+	switch columnDefinition.Type.(type) {
+	case *ast.DataTypeSmallserial:
+		columnDefinition.Type = &ast.DataTypeSmallint{}
+	case *ast.DataTypeSerial:
+		columnDefinition.Type = &ast.DataTypeInteger{}
+	case *ast.DataTypeBigserial:
+		columnDefinition.Type = &ast.DataTypeBigint{}
+	default:
+		return ""
 	}
 
-	tables[identifier] = &Table{
-		CreateTableStatement:   createTableStatement,
-		Identifier:             identifier,
-		Columns:                columns,
-		Indexes:                make(Indexes),
-		TableConstraints:       make(TableConstraints),
-		AlterColumnSetDefaults: make(AlterColumnSetDefaults),
+	notNull := false
+	for _, constraint := range columnDefinition.ConstraintList {
+		if _, ok := constraint.(*ast.ColumnConstraintNotNull); ok {
+			notNull = true
+		}
+	}
+	if !notNull {
+		columnDefinition.ConstraintList = append(columnDefinition.ConstraintList, &ast.ColumnConstraintNotNull{})
 	}
+
+	return makeObjectName(tableName.TableIdentifier.Value, columnDefinition.Name.Value, "seq")
 }
 
-func (tables Tables) AddIndex(searchPath string, createIndexStatement *ast.CreateIndexStatement) {
-	if createIndexStatement.TableName.SchemaIdentifier == nil {
-		createIndexStatement.TableName.SetSchema(searchPath)
+// makeObjectName builds "name1_name2_label" the way PostgreSQL chooses names
+// for implicit objects, truncating the longer part first so the result fits
+// in NAMEDATALEN.
+func makeObjectName(name1, name2, label string) string {
+	const maxIdentifierLength = 63
+
+	overhead := len(label) + 1
+	if name2 != "" {
+		overhead++
 	}
+	name1chars, name2chars := len(name1), len(name2)
+	for name1chars+name2chars > maxIdentifierLength-overhead {
+		if name1chars > name2chars {
+			name1chars--
+		} else {
+			name2chars--
+		}
+	}
+	name1 = clipUTF8(name1, name1chars)
+	name2 = clipUTF8(name2, name2chars)
+
+	name := name1
+	if name2 != "" {
+		name += "_" + name2
+	}
+	return name + "_" + label
+}
+
+// clipUTF8 truncates s to at most n bytes without splitting a character.
+func clipUTF8(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// nextvalExpression builds nextval('schema.sequence'::regclass) in the form
+// pg_dump writes column defaults backed by a sequence.
+func nextvalExpression(schemaName, sequenceName string) ast.Expression {
+	regclass := quoteRegclassPart(schemaName) + "." + quoteRegclassPart(sequenceName)
+	return &ast.CallExpression{
+		Token:    token.Token{Type: token.LParen, Literal: "("},
+		Function: &ast.Identifier{Token: token.Token{Type: token.Identifier, Literal: "nextval"}, Value: "nextval"},
+		Arguments: []ast.Expression{
+			&ast.TypeCastExpression{
+				Expression: &ast.StringLiteral{Token: token.Token{Type: token.String, Literal: "'" + strings.Replace(regclass, "'", "''", -1) + "'", Value: regclass}},
+				Type: &ast.DataTypeUserDefined{
+					TypeName: &ast.Identifier{Token: token.Token{Type: token.Identifier, Literal: "regclass"}, Value: "regclass"},
+				},
+			},
+		},
+	}
+}
+
+// quoteRegclassPart quotes a name inside a regclass literal the way
+// PostgreSQL prints a regclass, only when it needs quoting.
+func quoteRegclassPart(name string) string {
+	return ast.FormatOptions{MinimalQuoting: true}.QuoteIdentifier(name)
+}
+
+func (tables Tables) AddIndex(searchPath SearchPath, createIndexStatement *ast.CreateIndexStatement) {
+	tables.qualify(searchPath, createIndexStatement.TableName)
 	tableName := createIndexStatement.TableName.String()
 	table := tables.FindTable(tableName)
 	if table == nil {
 		log.Printf("irregular create index to unknown table=%s", tableName)
 		return
 	}
+	// pg_dump creates the index of a partitioned table ON ONLY the table and
+	// attaches those of the partitions to it. It is modelled as the index
+	// PostgreSQL creates on all the partitions at once.
+	createIndexStatement.Only = false
 	indexName := createIndexStatement.Name.Value
 	table.Indexes[indexName] = &Index{
 		CreateIndexStatement: createIndexStatement,
@@ -461,9 +1260,44 @@ func (tables Tables) AddIndex(searchPath string, createIndexStatement *ast.Creat
 	}
 }
 
-func (tables Tables) AddSequence(searchPath string, alterSequenceStatement *ast.AlterSequenceStatement) {
-	tableName := alterSequenceStatement.OwnedByTable.TableIdentifier.Value
-	table := tables.FindTableBy(searchPath, tableName)
+// attachIndex checks the indexes of ALTER INDEX ... ATTACH PARTITION. The
+// index of a partition is known to be attached by matching the index of its
+// parent, so nothing else is recorded.
+func (tables Tables) attachIndex(searchPath SearchPath, alterIndexStatement *ast.AlterIndexStatement) {
+	for _, name := range []*ast.TableName{alterIndexStatement.Name, alterIndexStatement.AttachPartition} {
+		if !tables.hasIndex(searchPath, name) {
+			log.Printf("irregular attach partition to unknown index=%s", name)
+		}
+	}
+}
+
+// hasIndex reports whether there is an index, or a constraint with an
+// index, called name.
+func (tables Tables) hasIndex(searchPath SearchPath, name *ast.TableName) bool {
+	schemas := searchPath
+	if name.SchemaIdentifier != nil {
+		schemas = SearchPath{name.SchemaIdentifier.Value}
+	}
+	for _, schema := range schemas {
+		for _, table := range tables {
+			if table.CreateTableStatement.TableName.SchemaIdentifier.Value != schema {
+				continue
+			}
+			if _, ok := table.Indexes[name.TableIdentifier.Value]; ok {
+				return true
+			}
+			if _, ok := table.TableConstraints[name.TableIdentifier.Value]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (tables Tables) AddSequence(searchPath SearchPath, alterSequenceStatement *ast.AlterSequenceStatement) {
+	tables.qualify(searchPath, alterSequenceStatement.OwnedByTable)
+	tableName := alterSequenceStatement.OwnedByTable.String()
+	table := tables.FindTable(tableName)
 	if table == nil {
 		log.Printf("irregular alter sequence to unknown table=%s", tableName)
 		return
@@ -478,75 +1312,197 @@ func (tables Tables) AddSequence(searchPath string, alterSequenceStatement *ast.
 }
 
 // processDDL converts to schema and table mappings
-func processDDL(ddl *ast.DataDefinition) Tables {
+func processDDL(ddl *ast.DataDefinition) (Tables, *Catalog) {
 	var tables = make(Tables)
-	searchPath := "public"
+	catalog := newCatalog()
+	searchPathState := newSearchPathState()
+	defaults := &tableDefaults{}
 
 	for _, statement := range ddl.StatementList {
+		searchPath := searchPathState.current()
+		catalog.Collations.resolveCollations(searchPath, statement)
 		switch stmt := statement.(type) {
-		case *ast.SetStatement:
-			if stmt.Name.Value == "search_path" {
-				ident, ok := stmt.Values[0].(*ast.Identifier)
-				if ok {
-					searchPath = ident.Value
-				}
-			}
-		case *ast.CreateSchemaStatement:
+		case *ast.SetStatement, *ast.ResetStatement, *ast.SelectStatement, *ast.TransactionStatement:
+			searchPathState.apply(stmt)
+			defaults.apply(stmt)
+		case *ast.CreateSchemaStatement, *ast.MetaCommand:
 			// nop
 		case *ast.CreateTableStatement:
-			tables.AddTable(searchPath, stmt)
+			tables.AddTable(searchPath, defaults, stmt)
 		case *ast.CreateIndexStatement:
 			tables.AddIndex(searchPath, stmt)
+		case *ast.AlterIndexStatement:
+			tables.attachIndex(searchPath, stmt)
 		case *ast.AlterSequenceStatement:
 			tables.AddSequence(searchPath, stmt)
 		case *ast.AlterTableStatement:
 			processAlterTableStatement(searchPath, tables, stmt)
+		case *ast.CreateCollationStatement:
+			catalog.Collations.AddCollation(searchPath, stmt)
+		case *ast.CreatePolicyStatement:
+			tables.AddPolicy(searchPath, stmt)
+		case *ast.AlterPolicyStatement:
+			tables.alterPolicy(searchPath, stmt)
+		case *ast.CreateServerStatement:
+			catalog.Servers.AddServer(stmt)
+		case *ast.AlterServerStatement:
+			catalog.Servers.alterServer(stmt)
+		case *ast.CreateUserMappingStatement:
+			catalog.UserMappings.AddUserMapping(stmt)
+		case *ast.AlterUserMappingStatement:
+			catalog.UserMappings.alterUserMapping(stmt)
+		case *ast.DropUserMappingStatement:
+			catalog.UserMappings.dropUserMapping(stmt)
+		case *ast.ImportForeignSchemaStatement:
+			catalog.Imports = append(catalog.Imports, stmt)
+		case *ast.DropStatement:
+			processDropStatement(searchPath, tables, catalog, stmt)
 		default:
 			log.Printf("skip statement: %v", stmt)
 		}
 	}
-	return tables
+	return tables, catalog
 }
 
-func processAlterTableStatement(searchPath string, tables Tables, alterTableStatement *ast.AlterTableStatement) {
-	if alterTableStatement.Name.SchemaIdentifier == nil {
-		alterTableStatement.Name.SetSchema(searchPath)
-	}
+func processAlterTableStatement(searchPath SearchPath, tables Tables, alterTableStatement *ast.AlterTableStatement) {
+	tables.qualify(searchPath, alterTableStatement.Name)
 	tableName := alterTableStatement.Name.String()
 	table := tables.FindTable(tableName)
 	if table == nil {
-		log.Printf("irregular alter table to unknown table=%s", tableName)
+		if !alterTableStatement.IfExists {
+			log.Printf("irregular alter table to unknown table=%s", tableName)
+		}
 		return
 	}
 	for _, action := range alterTableStatement.Actions {
 		switch v := action.(type) {
 		case *ast.TableConstraint:
-			var typ ConstraintType
-			if v.PrimaryKey {
-				typ = PrimaryKey
+			if v.References != nil {
+				tables.qualify(searchPath, v.References.Table)
+			}
+			table.addConstraint(v)
+		case *ast.DropConstraint:
+			if _, ok := table.TableConstraints[v.Name.Value]; !ok && !v.IfExists {
+				log.Printf("irregular drop constraint to unknown constraint=%s", v.Name.Value)
 			}
-			if v.Unique {
-				typ = Unique
+			delete(table.TableConstraints, v.Name.Value)
+			table.renameIndexReference(v.Name.Value, "")
+		case *ast.RenameConstraint:
+			constraint := table.TableConstraints[v.Name.Value]
+			if constraint == nil {
+				log.Printf("irregular rename constraint to unknown constraint=%s", v.Name.Value)
+				continue
 			}
-			if typ == Unknown {
+			delete(table.TableConstraints, constraint.Name)
+			constraint.Name = v.NewName.Value
+			renamed := *constraint.Constraint
+			renamed.Name = v.NewName
+			constraint.Constraint = &renamed
+			table.TableConstraints[constraint.Name] = constraint
+			table.renameIndexReference(v.Name.Value, constraint.Name)
+		case *ast.AddColumn:
+			name := v.ColumnDefinition.Name.Value
+			if _, ok := table.Columns[name]; ok {
+				if !v.IfNotExists {
+					log.Printf("irregular add column to existing column=%s", name)
+				}
 				continue
 			}
-			var columns []string
-			for _, c := range v.ColumnList.ColumnNames {
-				columns = append(columns, c.Value)
+			table.addColumn(v.ColumnDefinition)
+			if !alterTableStatement.Only {
+				tables.propagateColumn(table, name)
 			}
-			table.TableConstraints[v.Name.Value] = &TableConstraint{
-				Name:    v.Name.Value,
-				Type:    typ,
-				Columns: columns,
+		case *ast.DropColumn:
+			if _, ok := table.Columns[v.Column.Value]; !ok {
+				if !v.IfExists {
+					log.Printf("irregular drop column to unknown column=%s", v.Column.Value)
+				}
+				continue
 			}
-		case *ast.AlterColumnSetDefault:
-			var builder strings.Builder
-			v.Expr.WriteStringTo(&builder)
-			table.AlterColumnSetDefaults[v.Column.Value] = &AlterColumnSetDefault{
-				Column:  v.Column.Value,
-				Default: builder.String(),
+			table.dropColumn(v.Column.Value)
+			if !alterTableStatement.Only {
+				tables.propagateColumn(table, v.Column.Value)
 			}
+		case *ast.RenameColumn:
+			if _, ok := table.Columns[v.Column.Value]; !ok {
+				log.Printf("irregular rename column to unknown column=%s", v.Column.Value)
+				continue
+			}
+			table.renameColumn(v.Column.Value, v.NewName.Value)
+			tables.renameReferencedColumn(table, v.Column.Value, v.NewName.Value)
+		case *ast.AlterColumnSetDefault, *ast.AlterColumnDropDefault, *ast.AlterColumnNotNull, *ast.AlterColumnType,
+			*ast.AlterColumnSetStatistics, *ast.AlterColumnSetStorage, *ast.AlterColumnSetCompression, *ast.AlterColumnOptions:
+			alterColumn(table, v)
+		case *ast.RenameTable:
+			renamed := *table.CreateTableStatement.TableName
+			renamed.TableIdentifier = v.NewName
+			tables.moveTable(table, &renamed)
+		case *ast.SetSchema:
+			renamed := *table.CreateTableStatement.TableName
+			renamed.SetSchema(v.Schema.Value)
+			tables.moveTable(table, &renamed)
+		case *ast.SetLogged:
+			table.Unlogged = !v.Logged
+		case *ast.SetStorageParameters:
+			table.setStorageParameters(v)
+		case *ast.SetOptions:
+			table.Options = applyGenericOptions(table.Options, v.Options)
+		case *ast.SetTablespace:
+			table.Tablespace = v.Tablespace.Value
+		case *ast.SetAccessMethod:
+			table.AccessMethod = v.AccessMethod.Value
+		case *ast.ReplicaIdentity:
+			if v.Index != nil && !table.hasIndex(v.Index.Value) {
+				log.Printf("irregular replica identity to unknown index=%s", v.Index.Value)
+				continue
+			}
+			table.setReplicaIdentity(v)
+		case *ast.ClusterOn:
+			if !table.hasIndex(v.Index.Value) {
+				log.Printf("irregular cluster on unknown index=%s", v.Index.Value)
+				continue
+			}
+			table.ClusterOn = v.Index.Value
+		case *ast.SetWithoutCluster:
+			table.ClusterOn = ""
+		case *ast.RowLevelSecurity:
+			if v.Force {
+				table.ForceRowLevelSecurity = v.Enable
+			} else {
+				table.RowLevelSecurity = v.Enable
+			}
+		case *ast.Inherit:
+			tables.qualify(searchPath, v.Parent)
+			var inherits []*ast.TableName
+			for _, parent := range table.Inherits {
+				if parent.String() != v.Parent.String() {
+					inherits = append(inherits, parent)
+				}
+			}
+			if !v.No {
+				inherits = append(inherits, v.Parent)
+			}
+			table.Inherits = inherits
+		case *ast.AttachPartition:
+			tables.qualify(searchPath, v.Name)
+			partition := tables.FindTable(v.Name.String())
+			if partition == nil {
+				log.Printf("irregular attach partition to unknown table=%s", v.Name)
+				continue
+			}
+			partition.PartitionOf = alterTableStatement.Name
+			partition.PartitionBound = v.Bound
+		case *ast.DetachPartition:
+			tables.qualify(searchPath, v.Name)
+			partition := tables.FindTable(v.Name.String())
+			if partition == nil || partition.PartitionOf == nil || partition.PartitionOf.String() != table.Identifier {
+				log.Printf("irregular detach partition to unknown partition=%s", v.Name)
+				continue
+			}
+			partition.PartitionOf = nil
+			partition.PartitionBound = nil
+		case *ast.AlterTrigger, *ast.OwnerTo:
+			// Triggers and owners are not part of the model.
 		default:
 			log.Printf("skipped table statement")
 			return
@@ -554,20 +1510,476 @@ func processAlterTableStatement(searchPath string, tables Tables, alterTableStat
 	}
 }
 
+// processDropStatement removes the dropped objects from tables and catalog.
+// Views, functions, types and foreign data wrappers are not part of the
+// model.
+func processDropStatement(searchPath SearchPath, tables Tables, catalog *Catalog, dropStatement *ast.DropStatement) {
+	for _, name := range dropStatement.Names {
+		schemas := searchPath
+		if name.SchemaIdentifier != nil {
+			schemas = SearchPath{name.SchemaIdentifier.Value}
+		}
+		// Sequences not owned by a column and empty schemas are not
+		// modelled, so only tables and indexes are known to be missing.
+		found := true
+		switch dropStatement.ObjectType {
+		case "TABLE", "FOREIGN TABLE":
+			tables.qualify(searchPath, name)
+			found = tables.dropTable(name.String())
+		case "INDEX":
+			found = false
+			for _, schema := range schemas {
+				if tables.dropIndex(schema, name.TableIdentifier.Value) {
+					found = true
+					break
+				}
+			}
+		case "SEQUENCE":
+			for _, schema := range schemas {
+				if tables.dropSequence(schema, name.TableIdentifier.Value) {
+					break
+				}
+			}
+		case "COLLATION":
+			found = catalog.Collations.dropCollation(searchPath, name)
+		case "POLICY":
+			found = tables.dropPolicy(searchPath, name.TableIdentifier.Value, dropStatement.Table)
+		case "SERVER":
+			found = catalog.dropServer(tables, name.TableIdentifier.Value)
+		case "SCHEMA":
+			tables.dropSchema(name.TableIdentifier.Value)
+		}
+		if !found && !dropStatement.IfExists {
+			log.Printf("irregular drop %s to unknown name=%s", strings.ToLower(dropStatement.ObjectType), name)
+		}
+	}
+}
+
+// dropTable removes a table along with the tables inheriting from it and
+// the foreign keys referencing it, as DROP TABLE ... CASCADE does.
+func (tables Tables) dropTable(identifier string) bool {
+	if _, ok := tables[identifier]; !ok {
+		return false
+	}
+	delete(tables, identifier)
+	for _, child := range tables.children(identifier) {
+		tables.dropTable(child.Identifier)
+	}
+	for _, table := range tables {
+		for name, constraint := range table.TableConstraints {
+			if constraint.Type == ForeignKey && constraint.Constraint.References.Table.String() == identifier {
+				delete(table.TableConstraints, name)
+			}
+		}
+	}
+	return true
+}
+
+// dropIndex removes the index named indexName in schema, which is the schema
+// of the table it belongs to.
+func (tables Tables) dropIndex(schema, indexName string) bool {
+	for _, table := range tables {
+		if table.CreateTableStatement.TableName.SchemaIdentifier.Value != schema {
+			continue
+		}
+		if _, ok := table.Indexes[indexName]; ok {
+			delete(table.Indexes, indexName)
+			table.renameIndexReference(indexName, "")
+			return true
+		}
+	}
+	return false
+}
+
+// dropSequence detaches a sequence from the column owning it, dropping the
+// nextval default which depends on the sequence.
+func (tables Tables) dropSequence(schema, sequenceName string) bool {
+	for _, table := range tables {
+		if table.CreateTableStatement.TableName.SchemaIdentifier.Value != schema {
+			continue
+		}
+		for _, column := range table.Columns {
+			if column.SequenceName == sequenceName {
+				column.SequenceName = ""
+				column.Default = nil
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dropSchema removes every table in schema, as DROP SCHEMA ... CASCADE does.
+func (tables Tables) dropSchema(schema string) {
+	for identifier, table := range tables {
+		if table.CreateTableStatement.TableName.SchemaIdentifier.Value == schema {
+			tables.dropTable(identifier)
+		}
+	}
+}
+
+// alterColumn applies an ALTER COLUMN action to the column it names.
+func alterColumn(table *Table, action ast.Node) {
+	var name string
+	switch v := action.(type) {
+	case *ast.AlterColumnSetDefault:
+		name = v.Column.Value
+	case *ast.AlterColumnDropDefault:
+		name = v.Column.Value
+	case *ast.AlterColumnNotNull:
+		name = v.Column.Value
+	case *ast.AlterColumnType:
+		name = v.Column.Value
+	case *ast.AlterColumnSetStatistics:
+		name = v.Column.Value
+	case *ast.AlterColumnSetStorage:
+		name = v.Column.Value
+	case *ast.AlterColumnSetCompression:
+		name = v.Column.Value
+	case *ast.AlterColumnOptions:
+		name = v.Column.Value
+	}
+	column := table.Columns[name]
+	if column == nil {
+		log.Printf("irregular alter column to unknown column=%s", name)
+		return
+	}
+	switch v := action.(type) {
+	case *ast.AlterColumnSetDefault:
+		column.Default = v.Expr
+	case *ast.AlterColumnDropDefault:
+		column.Default = nil
+	case *ast.AlterColumnNotNull:
+		column.NotNull = v.NotNull
+	case *ast.AlterColumnType:
+		column.DataType = v.Type
+		column.Collation = v.Collation
+	case *ast.AlterColumnSetStatistics:
+		column.Statistics = v.Statistics
+	case *ast.AlterColumnSetStorage:
+		column.Storage = storageMode(v.Storage)
+	case *ast.AlterColumnSetCompression:
+		column.Compression = compressionMethod(v.Compression)
+	case *ast.AlterColumnOptions:
+		column.Options = applyGenericOptions(column.Options, v.Options)
+	}
+}
+
+// addConstraint adds a table constraint, naming it the way PostgreSQL does
+// when the statement does not.
+func (table *Table) addConstraint(tableConstraint *ast.TableConstraint) {
+	var (
+		typ     ConstraintType
+		label   string
+		columns []string
+	)
+	if tableConstraint.ColumnList != nil {
+		for _, c := range tableConstraint.ColumnList.ColumnNames {
+			columns = append(columns, c.Value)
+		}
+	}
+	switch {
+	case tableConstraint.PrimaryKey:
+		typ, label = PrimaryKey, "pkey"
+	case tableConstraint.Unique:
+		typ, label = Unique, "key"
+	case tableConstraint.Check != nil:
+		typ, label = Check, "check"
+	case tableConstraint.ForeignKey:
+		typ, label = ForeignKey, "fkey"
+	}
+	if tableConstraint.Name == nil {
+		var name2 string
+		if typ != PrimaryKey {
+			// PostgreSQL uses the first referenced column of a CHECK
+			// expression, which is not tracked here.
+			name2 = strings.Join(columns, "_")
+		}
+		tableConstraint.Name = newIdentifier(makeObjectName(table.CreateTableStatement.TableName.TableIdentifier.Value, name2, label))
+	}
+	table.TableConstraints[tableConstraint.Name.Value] = &TableConstraint{
+		Name:       tableConstraint.Name.Value,
+		Type:       typ,
+		Columns:    columns,
+		Constraint: tableConstraint,
+	}
+}
+
+func (table *Table) addColumn(columnDefinition *ast.ColumnDefinition) {
+	createTableStatement := table.CreateTableStatement
+	sequenceName := expandSerial(createTableStatement.TableName, columnDefinition)
+	column := columnFromAst(columnDefinition)
+	if sequenceName != "" {
+		column.SequenceName = sequenceName
+		column.Default = nextvalExpression(createTableStatement.TableName.SchemaIdentifier.Value, sequenceName)
+	}
+	table.Columns[column.Name] = column
+	createTableStatement.ColumnDefinitionList = append(createTableStatement.ColumnDefinitionList, columnDefinition)
+}
+
+// dropColumn removes a column along with the constraints and indexes on
+// it, as PostgreSQL does.
+func (table *Table) dropColumn(name string) {
+	delete(table.Columns, name)
+	var definitions []*ast.ColumnDefinition
+	for _, columnDefinition := range table.CreateTableStatement.ColumnDefinitionList {
+		if columnDefinition.Name.Value != name {
+			definitions = append(definitions, columnDefinition)
+		}
+	}
+	table.CreateTableStatement.ColumnDefinitionList = definitions
+	for constraintName, constraint := range table.TableConstraints {
+		if constraint.referencesColumn(name) {
+			delete(table.TableConstraints, constraintName)
+			table.renameIndexReference(constraintName, "")
+		}
+	}
+	for indexName, index := range table.Indexes {
+		if indexReferencesColumn(index.CreateIndexStatement, name) {
+			delete(table.Indexes, indexName)
+			table.renameIndexReference(indexName, "")
+		}
+	}
+}
+
+func (constraint *TableConstraint) referencesColumn(name string) bool {
+	for _, column := range constraint.Columns {
+		if column == name {
+			return true
+		}
+	}
+	return constraint.Type == Check && referencesColumn(constraint.Constraint.Check, name)
+}
+
+func indexReferencesColumn(createIndexStatement *ast.CreateIndexStatement, name string) bool {
+	for _, indexTarget := range createIndexStatement.IndexTargets {
+		if expr, ok := indexTarget.Node.(ast.Expression); ok && referencesColumn(expr, name) {
+			return true
+		}
+	}
+	for _, include := range createIndexStatement.Include {
+		if include.Value == name {
+			return true
+		}
+	}
+	return referencesColumn(createIndexStatement.Where, name)
+}
+
+// renameColumn renames a column and the references to it in table
+// constraints, indexes and policies.
+func (table *Table) renameColumn(name, newName string) {
+	column := table.Columns[name]
+	delete(table.Columns, name)
+	column.Name = newName
+	table.Columns[newName] = column
+
+	createTableStatement := *table.CreateTableStatement
+	createTableStatement.ColumnDefinitionList = make([]*ast.ColumnDefinition, len(table.CreateTableStatement.ColumnDefinitionList))
+	for i, columnDefinition := range table.CreateTableStatement.ColumnDefinitionList {
+		if columnDefinition.Name.Value == name {
+			renamed := *columnDefinition
+			renamed.Name = newIdentifier(newName)
+			columnDefinition = &renamed
+		}
+		createTableStatement.ColumnDefinitionList[i] = columnDefinition
+	}
+	table.CreateTableStatement = &createTableStatement
+
+	for _, constraint := range table.TableConstraints {
+		renamed := false
+		for i, c := range constraint.Columns {
+			if c == name {
+				constraint.Columns[i] = newName
+				renamed = true
+			}
+		}
+		if !renamed {
+			continue
+		}
+		tableConstraint := *constraint.Constraint
+		tableConstraint.ColumnList = &ast.ColumnList{}
+		for _, c := range constraint.Columns {
+			tableConstraint.ColumnList.ColumnNames = append(tableConstraint.ColumnList.ColumnNames, newIdentifier(c))
+		}
+		constraint.Constraint = &tableConstraint
+	}
+	for _, constraint := range table.TableConstraints {
+		if constraint.Type != Check || !referencesColumn(constraint.Constraint.Check, name) {
+			continue
+		}
+		tableConstraint := *constraint.Constraint
+		tableConstraint.Check = renameColumnReferences(tableConstraint.Check, name, newName)
+		constraint.Constraint = &tableConstraint
+	}
+
+	for _, index := range table.Indexes {
+		if !indexReferencesColumn(index.CreateIndexStatement, name) {
+			continue
+		}
+		createIndexStatement := *index.CreateIndexStatement
+		createIndexStatement.IndexTargets = make([]*ast.IndexTarget, len(index.CreateIndexStatement.IndexTargets))
+		for i, indexTarget := range index.CreateIndexStatement.IndexTargets {
+			if expr, ok := indexTarget.Node.(ast.Expression); ok {
+				renamed := *indexTarget
+				renamed.Node = renameColumnReferences(expr, name, newName)
+				indexTarget = &renamed
+			}
+			createIndexStatement.IndexTargets[i] = indexTarget
+		}
+		createIndexStatement.Include = make([]*ast.Identifier, len(index.CreateIndexStatement.Include))
+		for i, include := range index.CreateIndexStatement.Include {
+			if include.Value == name {
+				include = newIdentifier(newName)
+			}
+			createIndexStatement.Include[i] = include
+		}
+		createIndexStatement.Where = renameColumnReferences(createIndexStatement.Where, name, newName)
+		index.CreateIndexStatement = &createIndexStatement
+	}
+
+	for policyName, policy := range table.Policies {
+		createPolicyStatement := *policy
+		createPolicyStatement.Using = renameColumnReferences(policy.Using, name, newName)
+		createPolicyStatement.WithCheck = renameColumnReferences(policy.WithCheck, name, newName)
+		table.Policies[policyName] = &createPolicyStatement
+	}
+}
+
+// renameReferencedColumn renames the column name of table to newName in the
+// foreign keys referencing it.
+func (tables Tables) renameReferencedColumn(table *Table, name, newName string) {
+	for _, other := range tables {
+		for _, constraint := range other.TableConstraints {
+			if constraint.Type != ForeignKey || constraint.Constraint.References.Table.String() != table.Identifier {
+				continue
+			}
+			columnList := constraint.Constraint.References.ColumnList
+			if columnList == nil {
+				continue
+			}
+			renamedList := &ast.ColumnList{}
+			for _, column := range columnList.ColumnNames {
+				if column.Value == name {
+					column = newIdentifier(newName)
+				}
+				renamedList.ColumnNames = append(renamedList.ColumnNames, column)
+			}
+			tableConstraint := *constraint.Constraint
+			references := *tableConstraint.References
+			references.ColumnList = renamedList
+			tableConstraint.References = &references
+			constraint.Constraint = &tableConstraint
+		}
+	}
+}
+
+// moveTable renames table to tableName, updating the indexes on it and the
+// foreign keys referencing it.
+func (tables Tables) moveTable(table *Table, tableName *ast.TableName) {
+	oldIdentifier := table.Identifier
+	delete(tables, oldIdentifier)
+	createTableStatement := *table.CreateTableStatement
+	createTableStatement.TableName = tableName
+	table.CreateTableStatement = &createTableStatement
+	table.Identifier = tableName.String()
+	tables[table.Identifier] = table
+
+	for _, index := range table.Indexes {
+		createIndexStatement := *index.CreateIndexStatement
+		createIndexStatement.TableName = tableName
+		index.CreateIndexStatement = &createIndexStatement
+	}
+	for name, policy := range table.Policies {
+		createPolicyStatement := *policy
+		createPolicyStatement.Table = tableName
+		table.Policies[name] = &createPolicyStatement
+	}
+	tables.replaceParent(oldIdentifier, tableName)
+	for _, other := range tables {
+		for _, constraint := range other.TableConstraints {
+			if constraint.Type != ForeignKey || constraint.Constraint.References.Table.String() != oldIdentifier {
+				continue
+			}
+			tableConstraint := *constraint.Constraint
+			references := *tableConstraint.References
+			references.Table = tableName
+			tableConstraint.References = &references
+			constraint.Constraint = &tableConstraint
+		}
+	}
+}
+
+// newIdentifier makes an identifier node for name.
+func newIdentifier(name string) *ast.Identifier {
+	return &ast.Identifier{
+		Token: token.Token{Type: token.Identifier, Literal: ast.QuoteIdentifier(name), Value: name},
+		Value: name,
+	}
+}
+
 func columnFromAst(columnDefinition *ast.ColumnDefinition) *Column {
 	column := &Column{
-		Name:     columnDefinition.Name.Value,
-		DataType: ast.FormatNode(columnDefinition.Type),
+		Name:        columnDefinition.Name.Value,
+		DataType:    columnDefinition.Type,
+		Statistics:  -1,
+		Storage:     storageMode(columnDefinition.Storage),
+		Compression: compressionMethod(columnDefinition.Compression),
+		Collation:   columnDefinition.Collation,
+		Options:     columnDefinition.Options,
 	}
 	for _, constraint := range columnDefinition.ConstraintList {
-		switch v := constraint.(type) {
-		case *ast.ColumnConstraintNotNull:
+		if _, ok := constraint.(*ast.ColumnConstraintNotNull); ok {
 			column.NotNull = true
-		case *ast.ColumnConstraintDefault:
-			var buf bytes.Buffer
-			v.Expr.WriteStringTo(&buf)
-			column.Default = buf.String()
 		}
 	}
+	column.Default = declaredDefault(columnDefinition)
 	return column
 }
+
+// definition returns the column definition of column for CREATE TABLE,
+// leaving out its default unless withDefault is set.
+func (column *Column) definition(withDefault bool) *ast.ColumnDefinition {
+	columnDefinition := &ast.ColumnDefinition{
+		Name: newIdentifier(column.Name),
+		Type: column.DataType,
+	}
+	if column.Compression != "" {
+		columnDefinition.Compression = newIdentifier(column.Compression)
+	}
+	columnDefinition.Options = column.Options
+	columnDefinition.Collation = column.Collation
+	if column.Default != nil && withDefault {
+		columnDefinition.ConstraintList = append(columnDefinition.ConstraintList, &ast.ColumnConstraintDefault{Expr: column.Default})
+	}
+	if column.NotNull {
+		columnDefinition.ConstraintList = append(columnDefinition.ConstraintList, &ast.ColumnConstraintNotNull{})
+	}
+	return columnDefinition
+}
+
+// storageMode returns storage as modelled, with "" for DEFAULT.
+func storageMode(storage string) string {
+	if storage == "DEFAULT" {
+		return ""
+	}
+	return storage
+}
+
+// compressionMethod returns the name of compression as modelled, with ""
+// for DEFAULT.
+func compressionMethod(compression *ast.Identifier) string {
+	if compression == nil || compression.Value == "default" {
+		return ""
+	}
+	return compression.Value
+}
+
+// declaredDefault returns the DEFAULT written in columnDefinition, or nil.
+func declaredDefault(columnDefinition *ast.ColumnDefinition) ast.Expression {
+	for _, constraint := range columnDefinition.ConstraintList {
+		if v, ok := constraint.(*ast.ColumnConstraintDefault); ok {
+			return v.Expr
+		}
+	}
+	return nil
+}
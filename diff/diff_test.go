@@ -2,7 +2,11 @@ package diff
 
 import (
 	"bytes"
+	"fmt"
 	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
@@ -34,6 +38,7 @@ func TestProcess(t *testing.T) {
 	type args struct {
 		source  fileReader
 		desired fileReader
+		options []Option
 	}
 	tests := []struct {
 		name    string
@@ -82,6 +87,94 @@ CREATE TABLE "myschema"."x" (
 );`,
 			wantErr: false,
 		},
+		{
+			name: "set empty search_path",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+SET search_path = '';
+CREATE TABLE x ( id bigint );
+SET search_path = 'app';
+CREATE TABLE y ( id bigint );`),
+			},
+			want: `
+-- Table: "app"."y"
+CREATE TABLE "app"."y" (
+    "id" bigint
+);
+
+-- Table: "public"."x"
+CREATE TABLE "public"."x" (
+    "id" bigint
+);`,
+			wantErr: false,
+		},
+		{
+			name: "set_config and set local search_path",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+SELECT pg_catalog.set_config('search_path', 'app', false);
+CREATE TABLE a ( id bigint );
+BEGIN;
+SET LOCAL search_path = public;
+CREATE TABLE b ( id bigint );
+COMMIT;
+CREATE TABLE c ( id bigint );`),
+			},
+			want: `
+-- Table: "app"."a"
+CREATE TABLE "app"."a" (
+    "id" bigint
+);
+
+-- Table: "app"."c"
+CREATE TABLE "app"."c" (
+    "id" bigint
+);
+
+-- Table: "public"."b"
+CREATE TABLE "public"."b" (
+    "id" bigint
+);`,
+			wantErr: false,
+		},
+		{
+			name: "resolve unqualified names through the whole search_path",
+			args: args{
+				source: newReader(`
+CREATE TABLE app.users ( id bigint );
+CREATE TABLE public.logs ( id bigint );`),
+				desired: newReader(`
+SET search_path = app, public;
+CREATE TABLE users ( id bigint );
+CREATE TABLE public.logs ( id bigint );
+CREATE INDEX logs_id_idx ON logs USING btree (id);`),
+			},
+			want: `
+-- Table: "public"."logs"
+CREATE INDEX "logs_id_idx" ON "public"."logs" USING "btree" ("id");`,
+			wantErr: false,
+		},
+		{
+			name: "skip copy data of a full dump",
+			args: args{
+				source: newReader(`CREATE TABLE users ( id bigint, name text );`),
+				desired: newReader(`
+CREATE TABLE users ( id bigint, name text, note text );
+
+COPY public.users (id, name, note) FROM stdin;
+1	O'Reilly	a; b
+2	\N	/* not a comment
+\.
+
+SELECT pg_catalog.setval('public.users_id_seq', 2, true);`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE "public"."users" ADD COLUMN "note" text;`,
+			wantErr: false,
+		},
 		{
 			name: "create table with primary key",
 			args: args{
@@ -130,6 +223,144 @@ CREATE TABLE "public"."users" (
 ALTER TABLE ONLY "public"."users" ALTER COLUMN "id" SET DEFAULT "nextval"('public.users_id_seq'::"regclass");`,
 			wantErr: false,
 		},
+		{
+			name: "create table with bigserial",
+			args: args{
+				source:  newReader(``),
+				desired: newReader(`CREATE TABLE users (id bigserial, name text);`),
+			},
+			want: `
+-- Table: "public"."users"
+CREATE TABLE "public"."users" (
+    "id" bigint NOT NULL,
+    "name" text
+);
+CREATE SEQUENCE "public"."users_id_seq"
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+ALTER SEQUENCE "public"."users_id_seq" OWNED BY "public"."users"."id";
+ALTER TABLE ONLY "public"."users" ALTER COLUMN "id" SET DEFAULT "nextval"('public.users_id_seq'::"regclass");`,
+			wantErr: false,
+		},
+		{
+			name: "add serial column",
+			args: args{
+				source:  newReader(`CREATE TABLE users (name text);`),
+				desired: newReader(`CREATE TABLE users (name text, id serial);`),
+			},
+			want: `
+-- Table: "public"."users"
+CREATE SEQUENCE "public"."users_id_seq"
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+ALTER TABLE "public"."users" ADD COLUMN "id" integer DEFAULT "nextval"('public.users_id_seq'::"regclass") NOT NULL;
+ALTER SEQUENCE "public"."users_id_seq" OWNED BY "public"."users"."id";`,
+			wantErr: false,
+		},
+		{
+			name: "change integer column to smallserial",
+			args: args{
+				source:  newReader(`CREATE TABLE users (id smallint NOT NULL);`),
+				desired: newReader(`CREATE TABLE users (id smallserial);`),
+			},
+			want: `
+-- Table: "public"."users"
+CREATE SEQUENCE "public"."users_id_seq"
+    AS smallint
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+ALTER TABLE "public"."users" ALTER COLUMN "id" SET DEFAULT "nextval"('public.users_id_seq'::"regclass");
+ALTER SEQUENCE "public"."users_id_seq" OWNED BY "public"."users"."id";`,
+			wantErr: false,
+		},
+		{
+			name: "add serial column in another schema",
+			args: args{
+				source:  newReader(`CREATE TABLE app.users (name text);`),
+				desired: newReader(`CREATE TABLE app.users (name text, id bigserial);`),
+			},
+			want: `
+-- Table: "app"."users"
+CREATE SEQUENCE "app"."users_id_seq"
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+ALTER TABLE "app"."users" ADD COLUMN "id" bigint DEFAULT "nextval"('app.users_id_seq'::"regclass") NOT NULL;
+ALTER SEQUENCE "app"."users_id_seq" OWNED BY "app"."users"."id";`,
+			wantErr: false,
+		},
+		{
+			name: "change serial column to bigserial",
+			args: args{
+				source:  newReader(`CREATE TABLE users (id serial);`),
+				desired: newReader(`CREATE TABLE users (id bigserial);`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE "public"."users" ALTER COLUMN "id" TYPE bigint;
+ALTER SEQUENCE "public"."users_id_seq" AS bigint;`,
+			wantErr: false,
+		},
+		{
+			name: "change serial column to integer",
+			args: args{
+				source:  newReader(`CREATE TABLE users (id serial);`),
+				desired: newReader(`CREATE TABLE users (id integer NOT NULL);`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE "public"."users" ALTER COLUMN "id" DROP DEFAULT;
+DROP SEQUENCE "public"."users_id_seq";`,
+			wantErr: false,
+		},
+		{
+			name: "serial equals pg_dump sequence",
+			args: args{
+				source: newReader(`
+CREATE TABLE public.users (
+    id integer NOT NULL
+);
+CREATE SEQUENCE public.users_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);`),
+				desired: newReader(`CREATE TABLE users (id serial);`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "compare type aliases with the types pg_dump writes",
+			args: args{
+				source: newReader(`
+CREATE TABLE public.users (
+    name character varying(255),
+    score double precision,
+    active boolean,
+    created_at timestamp with time zone,
+    amount numeric(10,2)
+);`),
+				desired: newReader(`CREATE TABLE users (name varchar(255), score float8, active bool, created_at timestamptz, amount decimal(10, 2));`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
 		{
 			name: "drop table",
 			args: args{
@@ -204,7 +435,7 @@ ALTER TABLE "public"."x" ALTER COLUMN "n" TYPE text;`,
 			},
 			want: `
 -- Table: "public"."x"
-ALTER TABLE "public"."x" ALTER COLUMN "n" TYPE bytea USING n::bytea;`,
+ALTER TABLE "public"."x" ALTER COLUMN "n" TYPE bytea USING "n"::bytea;`,
 			wantErr: false,
 		},
 		{
@@ -240,6 +471,117 @@ ALTER TABLE "public"."x" ALTER COLUMN "n" DROP NOT NULL;`,
 ALTER TABLE "public"."x" ALTER COLUMN "n" SET DEFAULT 42;`,
 			wantErr: false,
 		},
+		{
+			name: "equivalent string defaults",
+			args: args{
+				source:  newReader(`CREATE TABLE "x" ( id bigint, s text DEFAULT E'it\'s', nl text DEFAULT E'a\nb', p text DEFAULT E'C:\\');`),
+				desired: newReader("CREATE TABLE \"x\" ( id bigint, s text DEFAULT 'it''s', nl text DEFAULT 'a\nb', p text DEFAULT 'C:\\');"),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "equivalent numeric defaults",
+			args: args{
+				source:  newReader(`CREATE TABLE "x" ( id bigint, a bigint DEFAULT 0x1F, b numeric DEFAULT 5e2, c bigint DEFAULT 1_000);`),
+				desired: newReader(`CREATE TABLE "x" ( id bigint, a bigint DEFAULT 31, b numeric DEFAULT 500, c bigint DEFAULT 1000);`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "numeric default scale differs",
+			args: args{
+				source:  newReader(`CREATE TABLE "x" ( id bigint, n numeric DEFAULT 1.0);`),
+				desired: newReader(`CREATE TABLE "x" ( id bigint, n numeric DEFAULT 1.00);`),
+			},
+			want: `
+-- Table: "public"."x"
+ALTER TABLE "public"."x" ALTER COLUMN "n" SET DEFAULT 1.00;`,
+			wantErr: false,
+		},
+		{
+			name: "numeric default scale ignored by value equivalence",
+			args: args{
+				source:  newReader(`CREATE TABLE "x" ( id bigint, n numeric DEFAULT 1.0);`),
+				desired: newReader(`CREATE TABLE "x" ( id bigint, n numeric DEFAULT 1.00);`),
+				options: []Option{WithNumericEquivalence(NumericEqualValue)},
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "numeric default compared literally",
+			args: args{
+				source:  newReader(`CREATE TABLE "x" ( id bigint, n bigint DEFAULT 5e2);`),
+				desired: newReader(`CREATE TABLE "x" ( id bigint, n bigint DEFAULT 500);`),
+				options: []Option{WithNumericEquivalence(NumericEqualLiteral)},
+			},
+			want: `
+-- Table: "public"."x"
+ALTER TABLE "public"."x" ALTER COLUMN "n" SET DEFAULT 500;`,
+			wantErr: false,
+		},
+		{
+			name: "unquoted identifiers are folded to lower case",
+			args: args{
+				source:  newReader(`CREATE TABLE Users ( ID bigint, Name text);`),
+				desired: newReader(`CREATE TABLE "users" ( "id" bigint, name text);`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "quoted identifiers with embedded quotes",
+			args: args{
+				source:  newReader(`CREATE TABLE "Users" ( id bigint);`),
+				desired: newReader(`CREATE TABLE "Users" ( id bigint, "say ""hi""" text);`),
+			},
+			want: `
+-- Table: "public"."Users"
+ALTER TABLE "public"."Users" ADD COLUMN "say ""hi""" text;`,
+			wantErr: false,
+		},
+		{
+			name: "create table with minimal quoting",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`CREATE TABLE "Users" ( id bigserial, "select" text DEFAULT 'x'::text, "a b" int, name text);
+CREATE INDEX users_name ON "Users" (name);`),
+				options: []Option{WithMinimalQuoting()},
+			},
+			want: `
+-- Table: public."Users"
+CREATE TABLE public."Users" (
+    id bigint NOT NULL,
+    "select" text DEFAULT 'x'::text,
+    "a b" integer,
+    name text
+);
+CREATE SEQUENCE public."Users_id_seq"
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+ALTER SEQUENCE public."Users_id_seq" OWNED BY public."Users".id;
+CREATE INDEX users_name ON public."Users" (name);
+ALTER TABLE ONLY public."Users" ALTER COLUMN id SET DEFAULT nextval('public."Users_id_seq"'::regclass);`,
+			wantErr: false,
+		},
+		{
+			name: "alter table with minimal quoting",
+			args: args{
+				source:  newReader(`CREATE TABLE users ( id bigint, "default" integer);`),
+				desired: newReader(`CREATE TABLE users ( id bigint, "default" bigint DEFAULT 0);`),
+				options: []Option{WithMinimalQuoting()},
+			},
+			want: `
+-- Table: public.users
+ALTER TABLE public.users ALTER COLUMN "default" TYPE bigint;
+ALTER TABLE public.users ALTER COLUMN "default" SET DEFAULT 0;`,
+			wantErr: false,
+		},
 		{
 			name: "alter column drop default",
 			args: args{
@@ -251,6 +593,181 @@ ALTER TABLE "public"."x" ALTER COLUMN "n" SET DEFAULT 42;`,
 ALTER TABLE "public"."x" ALTER COLUMN "n" DROP DEFAULT;`,
 			wantErr: false,
 		},
+		{
+			name: "default set by alter table equals declared default",
+			args: args{
+				source: newReader(`
+CREATE TABLE "x" ( id bigint, n bigint);
+ALTER TABLE ONLY "x" ALTER COLUMN n SET DEFAULT 42;`),
+				desired: newReader(`CREATE TABLE "x" ( id bigint, n bigint DEFAULT 42);`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "change default set by alter table",
+			args: args{
+				source: newReader(`
+CREATE TABLE "x" ( id bigint, n bigint);
+ALTER TABLE ONLY "x" ALTER COLUMN n SET DEFAULT 42;`),
+				desired: newReader(`
+CREATE TABLE "x" ( id bigint, n bigint);
+ALTER TABLE ONLY "x" ALTER COLUMN n SET DEFAULT 7;`),
+			},
+			want: `
+-- Table: "public"."x"
+ALTER TABLE "public"."x" ALTER COLUMN "n" SET DEFAULT 7;`,
+			wantErr: false,
+		},
+		{
+			name: "drop default set by alter table",
+			args: args{
+				source: newReader(`
+CREATE TABLE "x" ( id bigint, n bigint);
+ALTER TABLE ONLY "x" ALTER COLUMN n SET DEFAULT 42;`),
+				desired: newReader(`CREATE TABLE "x" ( id bigint, n bigint);`),
+			},
+			want: `
+-- Table: "public"."x"
+ALTER TABLE "public"."x" ALTER COLUMN "n" DROP DEFAULT;`,
+			wantErr: false,
+		},
+		{
+			name: "desired schema built by alter table",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id bigint NOT NULL, full_name text, age integer DEFAULT 0);
+ALTER TABLE ONLY users ADD CONSTRAINT users_pkey PRIMARY KEY (id);`),
+				desired: newReader(`
+CREATE TABLE people (id integer, name text, tmp text);
+ALTER TABLE people RENAME TO users;
+ALTER TABLE users ADD COLUMN age integer, DROP COLUMN tmp, ALTER COLUMN id TYPE bigint, ALTER COLUMN id SET NOT NULL, ALTER age SET DEFAULT 0;
+ALTER TABLE users RENAME name TO full_name;
+ALTER TABLE users ADD PRIMARY KEY (id);`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "drop column drops the indexes on it",
+			args: args{
+				source: newReader(`
+CREATE TABLE t (a integer);
+CREATE INDEX t_a ON t USING btree (a);`),
+				desired: newReader(`
+CREATE TABLE t (a integer, b integer);
+CREATE INDEX t_a ON t (a);
+CREATE INDEX t_b ON t (b);
+CREATE INDEX t_a_positive ON t (a) WHERE b > 0;
+CREATE INDEX t_a_b ON t (a) INCLUDE (b);
+CREATE INDEX t_sum ON t ((a + b));
+ALTER TABLE t DROP COLUMN b;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "rename column updates the indexes on it",
+			args: args{
+				source: newReader(`
+CREATE TABLE t (a integer, c text);
+CREATE INDEX t_c ON t USING btree (c);
+CREATE INDEX t_lower ON t USING btree (lower(c)) INCLUDE (c) WHERE (c <> ''::text);`),
+				desired: newReader(`
+CREATE TABLE t (a integer, b text);
+CREATE INDEX t_c ON t (b);
+CREATE INDEX t_lower ON t (lower(b)) INCLUDE (b) WHERE b <> ''::text;
+ALTER TABLE t RENAME COLUMN b TO c;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "drop column drops the check constraints on it",
+			args: args{
+				source: newReader(`
+CREATE TABLE t (a integer);
+ALTER TABLE t ADD CONSTRAINT t_a_check CHECK (a > 0);`),
+				desired: newReader(`
+CREATE TABLE t (a integer, b integer, CONSTRAINT t_a_check CHECK (a > 0), CONSTRAINT t_b_check CHECK (b > a));
+ALTER TABLE t DROP COLUMN b;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "rename column updates checks, policies and foreign keys",
+			args: args{
+				source: newReader(`
+CREATE TABLE parents (key integer NOT NULL, owner text, CONSTRAINT parents_key_check CHECK (key > 0));
+ALTER TABLE ONLY parents ADD CONSTRAINT parents_pkey PRIMARY KEY (key);
+CREATE POLICY owners ON parents USING (owner = CURRENT_USER) WITH CHECK (key > 0);
+CREATE TABLE children (parent_id integer);
+ALTER TABLE ONLY children ADD CONSTRAINT children_parent_id_fkey FOREIGN KEY (parent_id) REFERENCES parents(key);`),
+				desired: newReader(`
+CREATE TABLE parents (id integer NOT NULL, name text, PRIMARY KEY (id), CONSTRAINT parents_key_check CHECK (id > 0));
+CREATE POLICY owners ON parents USING (name = current_user) WITH CHECK (id > 0);
+CREATE TABLE children (parent_id integer, FOREIGN KEY (parent_id) REFERENCES parents (id));
+ALTER TABLE parents RENAME COLUMN id TO key;
+ALTER TABLE parents RENAME name TO owner;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "create table altered by alter table",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE TABLE x (id integer, tmp text);
+ALTER TABLE x ADD COLUMN name text NOT NULL, DROP COLUMN tmp, ALTER COLUMN id TYPE bigint;`),
+			},
+			want: `
+-- Table: "public"."x"
+CREATE TABLE "public"."x" (
+    "id" bigint,
+    "name" text NOT NULL
+);`,
+			wantErr: false,
+		},
+		{
+			name: "objects created and dropped by the script",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id bigint);`),
+				desired: newReader(`
+DROP TABLE IF EXISTS users;
+CREATE TABLE users (id bigint);
+CREATE TABLE tmp (id bigint);
+CREATE INDEX users_id_idx ON users USING btree (id);
+DROP INDEX public.users_id_idx;
+DROP TABLE tmp;
+DROP VIEW IF EXISTS user_names;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "drop table cascade drops referencing foreign keys",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id bigint);
+CREATE TABLE posts (user_id bigint);
+ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id);`),
+				desired: newReader(`
+CREATE TABLE users (id bigint);
+CREATE TABLE posts (user_id bigint);
+ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id);
+DROP TABLE users CASCADE;`),
+			},
+			want: `
+-- Table: "public"."posts"
+ALTER TABLE ONLY "public"."posts" DROP CONSTRAINT "posts_user_id_fkey";
+
+-- Table: "public"."users"
+DROP TABLE "public"."users";`,
+			wantErr: false,
+		},
 		{
 			name: "alter column type,not null",
 			args: args{
@@ -300,11 +817,164 @@ CREATE TABLE users (name text);`),
 			},
 			want: `
 -- Table: "public"."users"
-DROP INDEX "idx";`,
+DROP INDEX "public"."idx";`,
 			wantErr: false,
 		},
 		{
-			name: "add constraint unique",
+			name: "replace index in another schema",
+			args: args{
+				source: newReader(`
+SELECT pg_catalog.set_config('search_path', '', false);
+CREATE TABLE app.users (name text);
+CREATE INDEX idx ON app.users USING btree (name);`),
+				desired: newReader(`
+SET search_path = app;
+CREATE TABLE users (name text);
+CREATE INDEX idx ON users (lower(name));`),
+			},
+			want: `
+-- Table: "app"."users"
+DROP INDEX "app"."idx";
+CREATE INDEX "idx" ON "app"."users" ("lower"("name"));`,
+			wantErr: false,
+		},
+		{
+			name: "change index predicate",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (email text, deleted_at timestamp);
+CREATE UNIQUE INDEX users_email_key ON users USING btree (email) WHERE deleted_at IS NULL;`),
+				desired: newReader(`
+CREATE TABLE users (email text, deleted_at timestamp);
+CREATE UNIQUE INDEX users_email_key ON users USING btree (email) WHERE (deleted_at IS NULL AND email <> '');`),
+			},
+			want: `
+-- Table: "public"."users"
+DROP INDEX "public"."users_email_key";
+CREATE UNIQUE INDEX "users_email_key" ON "public"."users" USING "btree" ("email") WHERE ("deleted_at" IS NULL AND "email"<>'');`,
+			wantErr: false,
+		},
+		{
+			name: "equal index despite parentheses",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (a integer, b integer, name text);
+CREATE INDEX users_sum ON users USING btree (((a + b))) WHERE ((name IS NOT NULL) AND (a > 0));`),
+				desired: newReader(`
+CREATE TABLE users (a integer, b integer, name text);
+CREATE INDEX users_sum ON users ((a + b)) WHERE name IS NOT NULL AND a > 0;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "change index expression grouping",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (a integer, b integer);
+CREATE INDEX users_product ON users (((a + b) * 2));`),
+				desired: newReader(`
+CREATE TABLE users (a integer, b integer);
+CREATE INDEX users_product ON users ((a + b * 2));`),
+			},
+			want: `
+-- Table: "public"."users"
+DROP INDEX "public"."users_product";
+CREATE INDEX "users_product" ON "public"."users" (("a"+"b"*2));`,
+			wantErr: false,
+		},
+		{
+			name: "create index on special functions",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (email text, nickname text);`),
+				desired: newReader(`
+CREATE TABLE users (email text, nickname text);
+CREATE INDEX users_domain ON users ((coalesce(nickname, substring(email from position('@' in email) + 1))));`),
+			},
+			want: `
+-- Table: "public"."users"
+CREATE INDEX "users_domain" ON "public"."users" ((COALESCE("nickname", SUBSTRING("email" FROM POSITION('@' IN "email")+1))));`,
+			wantErr: false,
+		},
+		{
+			name: "change index include and storage parameters",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE INDEX users_email_key ON users USING btree (email) WITH (fillfactor=90);`),
+				desired: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE INDEX users_email_key ON users USING btree (email) INCLUDE (name) WITH (fillfactor=70);`),
+			},
+			want: `
+-- Table: "public"."users"
+DROP INDEX "public"."users_email_key";
+CREATE INDEX "users_email_key" ON "public"."users" USING "btree" ("email") INCLUDE ("name") WITH ("fillfactor"='70');`,
+			wantErr: false,
+		},
+		{
+			name: "same index created concurrently",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (email text);
+CREATE INDEX users_email_key ON users USING btree (email) WITH (fillfactor='70');`),
+				desired: newReader(`
+CREATE TABLE users (email text);
+CREATE INDEX CONCURRENTLY IF NOT EXISTS users_email_key ON users USING btree (email) WITH (fillfactor=70);`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "equivalent index definitions",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE INDEX users_email_key ON users USING btree (email ASC NULLS LAST, name DESC NULLS FIRST) NULLS DISTINCT WITH (fillfactor=70, deduplicate_items=off);`),
+				desired: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE INDEX users_email_key ON public.users (email, name DESC) WITH (deduplicate_items=off, fillfactor=70);`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "change index columns and method",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE INDEX users_email_key ON users USING btree (email);`),
+				desired: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE UNIQUE INDEX users_email_key ON users USING hash (email, name);`),
+			},
+			want: `
+-- Table: "public"."users"
+DROP INDEX "public"."users_email_key";
+CREATE UNIQUE INDEX "users_email_key" ON "public"."users" USING "hash" ("email", "name");`,
+			wantErr: false,
+		},
+		{
+			name: "replace index with zero downtime",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE INDEX users_email_key ON users USING btree (email);`),
+				desired: newReader(`
+CREATE TABLE users (email text, name text);
+CREATE INDEX users_email_key ON users USING btree (email, name);`),
+				options: []Option{WithZeroDowntimeIndexes()},
+			},
+			want: `
+-- Table: "public"."users"
+CREATE INDEX CONCURRENTLY "users_email_key_new" ON "public"."users" USING "btree" ("email", "name");
+DROP INDEX CONCURRENTLY "public"."users_email_key";
+ALTER INDEX "public"."users_email_key_new" RENAME TO "users_email_key";`,
+			wantErr: false,
+		},
+		{
+			name: "add constraint unique",
 			args: args{
 				source: newReader(`
 CREATE TABLE users (id bigint);`),
@@ -331,10 +1001,732 @@ CREATE TABLE users (id bigint);`),
 ALTER TABLE ONLY "public"."users" DROP CONSTRAINT "users_pkey";`,
 			wantErr: false,
 		},
+		{
+			name: "replace changed check constraint",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (age integer);
+ALTER TABLE users ADD CONSTRAINT users_age_check CHECK (age >= 0);`),
+				desired: newReader(`
+CREATE TABLE users (age integer);
+ALTER TABLE users ADD CONSTRAINT users_age_check CHECK (age >= 18);`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE ONLY "public"."users" DROP CONSTRAINT "users_age_check";
+ALTER TABLE ONLY "public"."users" ADD CONSTRAINT "users_age_check" CHECK ("age">=18);`,
+			wantErr: false,
+		},
+		{
+			name: "compare check constraint in create table",
+			args: args{
+				source: newReader(`
+CREATE TABLE public.users (
+    age integer,
+    CONSTRAINT users_age_check CHECK ((age >= 0))
+);`),
+				desired: newReader(`
+CREATE TABLE users (age integer, CONSTRAINT users_age_check CHECK (age >= 18));`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE ONLY "public"."users" DROP CONSTRAINT "users_age_check";
+ALTER TABLE ONLY "public"."users" ADD CONSTRAINT "users_age_check" CHECK ("age">=18);`,
+			wantErr: false,
+		},
+		{
+			name: "create table with check constraint",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE TABLE users (age integer, CHECK (age >= 0));`),
+			},
+			want: `
+-- Table: "public"."users"
+CREATE TABLE "public"."users" (
+    "age" integer
+);
+ALTER TABLE ONLY "public"."users" ADD CONSTRAINT "users_check" CHECK ("age">=0);`,
+			wantErr: false,
+		},
+		{
+			name: "equal check constraint despite parentheses",
+			args: args{
+				source: newReader(`
+CREATE TABLE public.users (id integer, age integer);
+ALTER TABLE public.users ADD CONSTRAINT users_check CHECK (((id > 0) AND ((age >= 0) OR (age IS NULL))));`),
+				desired: newReader(`
+CREATE TABLE users (id integer, age integer);
+ALTER TABLE users ADD CONSTRAINT users_check CHECK (id > 0 AND (age >= 0 OR age IS NULL));`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "ignore not valid and default foreign key options",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id bigint);
+CREATE TABLE posts (user_id bigint);
+ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id) NOT VALID;`),
+				desired: newReader(`
+CREATE TABLE users (id bigint);
+CREATE TABLE posts (user_id bigint);
+ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users (id) MATCH SIMPLE ON DELETE NO ACTION;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "replace primary key with dependent foreign key",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id bigint, uid bigint);
+CREATE TABLE posts (user_id bigint);
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (id);`),
+				desired: newReader(`
+CREATE TABLE users (id bigint, uid bigint);
+CREATE TABLE posts (user_id bigint);
+ALTER TABLE users ADD CONSTRAINT users_pkey PRIMARY KEY (uid);
+ALTER TABLE posts ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES users (uid) ON DELETE CASCADE;`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE ONLY "public"."posts" DROP CONSTRAINT "posts_user_id_fkey";
+ALTER TABLE ONLY "public"."users" DROP CONSTRAINT "users_pkey";
+ALTER TABLE ONLY "public"."users" ADD CONSTRAINT "users_pkey" PRIMARY KEY ("uid");
+ALTER TABLE ONLY "public"."posts" ADD CONSTRAINT "posts_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("uid") ON DELETE CASCADE;`,
+			wantErr: false,
+		},
+		{
+			name: "create partitioned table and partitions",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE TABLE events (id bigint, day date) PARTITION BY RANGE (day);
+CREATE TABLE events_2020 PARTITION OF events FOR VALUES FROM ('2020-01-01') TO ('2021-01-01');
+CREATE TABLE archived_events PARTITION OF events DEFAULT;`),
+			},
+			want: `
+-- Table: "public"."events"
+CREATE TABLE "public"."events" (
+    "id" bigint,
+    "day" date
+) PARTITION BY RANGE ("day");
+
+-- Table: "public"."archived_events"
+CREATE TABLE "public"."archived_events" PARTITION OF "public"."events" DEFAULT;
+
+-- Table: "public"."events_2020"
+CREATE TABLE "public"."events_2020" PARTITION OF "public"."events" FOR VALUES FROM ('2020-01-01') TO ('2021-01-01');`,
+			wantErr: false,
+		},
+		{
+			name: "attach partition and change partition bounds",
+			args: args{
+				source: newReader(`
+CREATE TABLE events (id bigint, day date) PARTITION BY RANGE (day);
+CREATE TABLE events_2020 (id bigint, day date);
+CREATE TABLE events_2021 (id bigint, day date);
+ALTER TABLE ONLY events ATTACH PARTITION events_2021 FOR VALUES FROM ('2021-01-01') TO ('2021-07-01');`),
+				desired: newReader(`
+CREATE TABLE events (id bigint, day date) PARTITION BY RANGE (day);
+CREATE TABLE events_2020 (id bigint, day date);
+CREATE TABLE events_2021 (id bigint, day date);
+ALTER TABLE ONLY events ATTACH PARTITION events_2020 FOR VALUES FROM ('2020-01-01') TO ('2021-01-01');
+ALTER TABLE ONLY events ATTACH PARTITION events_2021 FOR VALUES FROM ('2021-01-01') TO ('2022-01-01');`),
+			},
+			want: `
+-- Table: "public"."events_2021"
+ALTER TABLE "public"."events" DETACH PARTITION "public"."events_2021";
+
+-- Table: "public"."events_2020"
+ALTER TABLE "public"."events" ATTACH PARTITION "public"."events_2020" FOR VALUES FROM ('2020-01-01') TO ('2021-01-01');
+
+-- Table: "public"."events_2021"
+ALTER TABLE "public"."events" ATTACH PARTITION "public"."events_2021" FOR VALUES FROM ('2021-01-01') TO ('2022-01-01');`,
+			wantErr: false,
+		},
+		{
+			name: "create partition without what it takes from its parent",
+			args: args{
+				source: newReader(`
+CREATE TABLE public.ev (id bigint NOT NULL, ts date NOT NULL) PARTITION BY RANGE (ts);
+CREATE TABLE public.ev_2024 (id bigint NOT NULL, ts date NOT NULL);
+ALTER TABLE ONLY public.ev ATTACH PARTITION public.ev_2024 FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+ALTER TABLE ONLY public.ev ADD CONSTRAINT ev_pkey PRIMARY KEY (id, ts);
+ALTER TABLE ONLY public.ev_2024 ADD CONSTRAINT ev_2024_pkey PRIMARY KEY (id, ts);
+CREATE INDEX ev_ts_idx ON ONLY public.ev USING btree (ts);
+CREATE INDEX ev_2024_ts_idx ON public.ev_2024 USING btree (ts);
+ALTER INDEX public.ev_pkey ATTACH PARTITION public.ev_2024_pkey;
+ALTER INDEX public.ev_ts_idx ATTACH PARTITION public.ev_2024_ts_idx;`),
+				desired: newReader(`
+CREATE TABLE ev (id bigint NOT NULL, ts date NOT NULL, PRIMARY KEY (id, ts)) PARTITION BY RANGE (ts);
+CREATE INDEX ev_ts_idx ON ev (ts);
+CREATE TABLE ev_2024 PARTITION OF ev FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+CREATE TABLE public.ev_2025 (id bigint NOT NULL, ts date NOT NULL);
+ALTER TABLE ONLY public.ev ATTACH PARTITION public.ev_2025 FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');
+ALTER TABLE ONLY public.ev_2025 ADD CONSTRAINT ev_2025_pkey PRIMARY KEY (id, ts);
+CREATE INDEX ev_2025_ts_idx ON public.ev_2025 USING btree (ts);
+ALTER INDEX public.ev_pkey ATTACH PARTITION public.ev_2025_pkey;
+ALTER INDEX public.ev_ts_idx ATTACH PARTITION public.ev_2025_ts_idx;`),
+			},
+			want: `
+-- Table: "public"."ev_2025"
+CREATE TABLE "public"."ev_2025" PARTITION OF "public"."ev" FOR VALUES FROM ('2025-01-01') TO ('2026-01-01');`,
+			wantErr: false,
+		},
+		{
+			name: "create index on partitioned table",
+			args: args{
+				source: newReader(`
+CREATE TABLE public.ev (id bigint NOT NULL, ts date NOT NULL) PARTITION BY RANGE (ts);
+CREATE TABLE public.ev_2024 (id bigint NOT NULL, ts date NOT NULL);
+ALTER TABLE ONLY public.ev ATTACH PARTITION public.ev_2024 FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+CREATE INDEX ev_id_idx ON ONLY public.ev USING btree (id);
+CREATE INDEX ev_2024_id_idx ON public.ev_2024 USING btree (id);
+ALTER INDEX public.ev_id_idx ATTACH PARTITION public.ev_2024_id_idx;`),
+				desired: newReader(`
+CREATE TABLE public.ev (id bigint NOT NULL, ts date NOT NULL) PARTITION BY RANGE (ts);
+CREATE TABLE public.ev_2024 (id bigint NOT NULL, ts date NOT NULL);
+ALTER TABLE ONLY public.ev ATTACH PARTITION public.ev_2024 FOR VALUES FROM ('2024-01-01') TO ('2025-01-01');
+CREATE INDEX ev_ts_idx ON ONLY public.ev USING btree (ts);
+CREATE INDEX ev_2024_ts_idx ON public.ev_2024 USING btree (ts);
+ALTER INDEX public.ev_ts_idx ATTACH PARTITION public.ev_2024_ts_idx;`),
+			},
+			want: `
+-- Table: "public"."ev"
+DROP INDEX "public"."ev_id_idx";
+CREATE INDEX "ev_ts_idx" ON "public"."ev" USING "btree" ("ts");`,
+			wantErr: false,
+		},
+		{
+			name: "drop partitioned table with its partitions",
+			args: args{
+				source: newReader(`
+CREATE TABLE events (id bigint, day date) PARTITION BY LIST (day);
+CREATE TABLE events_a PARTITION OF events FOR VALUES IN ('2020-01-01');`),
+				desired: newReader(``),
+			},
+			want: `
+-- Table: "public"."events"
+DROP TABLE "public"."events";`,
+			wantErr: false,
+		},
+		{
+			name: "create inheriting table after its parent",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE TABLE cities (name text);
+CREATE TABLE capitals (state text) INHERITS (cities);`),
+			},
+			want: `
+-- Table: "public"."cities"
+CREATE TABLE "public"."cities" (
+    "name" text
+);
+
+-- Table: "public"."capitals"
+CREATE TABLE "public"."capitals" (
+    "state" text
+) INHERITS ("public"."cities");`,
+			wantErr: false,
+		},
+		{
+			name: "inherit from an existing table",
+			args: args{
+				source: newReader(`
+CREATE TABLE cities (name text);
+CREATE TABLE capitals (name text, state text);`),
+				desired: newReader(`
+CREATE TABLE cities (name text);
+CREATE TABLE capitals (state text) INHERITS (cities);`),
+			},
+			want: `
+-- Table: "public"."capitals"
+ALTER TABLE "public"."capitals" INHERIT "public"."cities";`,
+			wantErr: false,
+		},
+		{
+			name: "add column to parent only",
+			args: args{
+				source: newReader(`
+CREATE TABLE cities (name text);
+CREATE TABLE capitals (state text) INHERITS (cities);`),
+				desired: newReader(`
+CREATE TABLE cities (name text);
+CREATE TABLE capitals (state text) INHERITS (cities);
+ALTER TABLE cities ADD COLUMN population integer;`),
+			},
+			want: `
+-- Table: "public"."cities"
+ALTER TABLE "public"."cities" ADD COLUMN "population" integer;`,
+			wantErr: false,
+		},
+		{
+			name: "change check constraint inherited by children",
+			args: args{
+				source: newReader(`
+CREATE TABLE cities (name text, population integer);
+CREATE TABLE capitals (state text) INHERITS (cities);
+ALTER TABLE cities ADD CONSTRAINT cities_population_check CHECK (population >= 0);`),
+				desired: newReader(`
+CREATE TABLE cities (name text, population integer);
+CREATE TABLE capitals (state text) INHERITS (cities);
+ALTER TABLE cities ADD CONSTRAINT cities_population_check CHECK (population > 0);`),
+			},
+			want: `
+-- Table: "public"."cities"
+ALTER TABLE "public"."cities" DROP CONSTRAINT "cities_population_check";
+ALTER TABLE "public"."cities" ADD CONSTRAINT "cities_population_check" CHECK ("population">0);`,
+			wantErr: false,
+		},
+		{
+			name: "stop inheriting before dropping the parent",
+			args: args{
+				source: newReader(`
+CREATE TABLE cities (name text);
+CREATE TABLE capitals (state text) INHERITS (cities);`),
+				desired: newReader(`
+CREATE TABLE capitals (name text, state text);`),
+			},
+			want: `
+-- Table: "public"."capitals"
+ALTER TABLE "public"."capitals" NO INHERIT "public"."cities";
+
+-- Table: "public"."cities"
+DROP TABLE "public"."cities";`,
+			wantErr: false,
+		},
+		{
+			name: "create unlogged table with storage options",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+SET default_tablespace = fast;
+SET default_table_access_method = heap;
+CREATE UNLOGGED TABLE IF NOT EXISTS hits (id bigint) WITH (fillfactor=70);`),
+			},
+			want: `
+-- Table: "public"."hits"
+CREATE UNLOGGED TABLE "public"."hits" (
+    "id" bigint
+) WITH ("fillfactor"='70') TABLESPACE "fast";`,
+			wantErr: false,
+		},
+		{
+			name: "change storage options",
+			args: args{
+				source: newReader(`
+SET default_tablespace = '';
+CREATE UNLOGGED TABLE hits (id bigint) WITH (fillfactor=70, autovacuum_enabled=false);`),
+				desired: newReader(`
+CREATE TABLE hits (id bigint) USING columnar WITH (fillfactor='80') TABLESPACE fast;
+ALTER TABLE hits SET (toast.autovacuum_enabled = off);`),
+			},
+			want: `
+-- Table: "public"."hits"
+ALTER TABLE "public"."hits" SET LOGGED;
+ALTER TABLE "public"."hits" RESET ("autovacuum_enabled");
+ALTER TABLE "public"."hits" SET ("fillfactor"='80', "toast"."autovacuum_enabled"='off');
+ALTER TABLE "public"."hits" SET TABLESPACE "fast";
+ALTER TABLE "public"."hits" SET ACCESS METHOD "columnar";`,
+			wantErr: false,
+		},
+		{
+			name: "create table with column storage settings",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE TABLE documents (body text COMPRESSION lz4);
+ALTER TABLE ONLY documents ALTER COLUMN body SET STATISTICS 1000;
+ALTER TABLE ONLY documents ALTER COLUMN body SET STORAGE EXTERNAL;`),
+			},
+			want: `
+-- Table: "public"."documents"
+CREATE TABLE "public"."documents" (
+    "body" text COMPRESSION "lz4"
+);
+ALTER TABLE "public"."documents" ALTER COLUMN "body" SET STATISTICS 1000;
+ALTER TABLE "public"."documents" ALTER COLUMN "body" SET STORAGE EXTERNAL;`,
+			wantErr: false,
+		},
+		{
+			name: "change column storage settings",
+			args: args{
+				source: newReader(`
+CREATE TABLE documents (body text COMPRESSION lz4);
+ALTER TABLE ONLY documents ALTER COLUMN body SET STATISTICS 1000;`),
+				desired: newReader(`
+CREATE TABLE documents (body text);
+ALTER TABLE ONLY documents ALTER COLUMN body SET STORAGE MAIN;`),
+			},
+			want: `
+-- Table: "public"."documents"
+ALTER TABLE "public"."documents" ALTER COLUMN "body" SET STATISTICS -1;
+ALTER TABLE "public"."documents" ALTER COLUMN "body" SET STORAGE MAIN;
+ALTER TABLE "public"."documents" ALTER COLUMN "body" SET COMPRESSION DEFAULT;`,
+			wantErr: false,
+		},
+		{
+			name: "change column collation only",
+			args: args{
+				source:  newReader(`CREATE TABLE users (name text COLLATE pg_catalog."C");`),
+				desired: newReader(`CREATE TABLE users (name text COLLATE "POSIX");`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE "public"."users" ALTER COLUMN "name" TYPE text COLLATE "POSIX";`,
+			wantErr: false,
+		},
+		{
+			name: "create collation before the column using it",
+			args: args{
+				source: newReader(`CREATE TABLE users (name text);`),
+				desired: newReader(`
+CREATE COLLATION case_insensitive (provider = icu, locale = 'und-u-ks-level2', deterministic = false);
+CREATE TABLE users (name text COLLATE case_insensitive);`),
+			},
+			want: `
+-- Collation: "public"."case_insensitive"
+CREATE COLLATION "public"."case_insensitive" ("provider"='icu', "locale"='und-u-ks-level2', "deterministic"='false');
+
+-- Table: "public"."users"
+ALTER TABLE "public"."users" ALTER COLUMN "name" TYPE text COLLATE "public"."case_insensitive";`,
+			wantErr: false,
+		},
+		{
+			name: "drop collation after the column stops using it",
+			args: args{
+				source: newReader(`
+CREATE COLLATION german FROM "de_DE";
+CREATE TABLE users (name text COLLATE german);`),
+				desired: newReader(`CREATE TABLE users (name text COLLATE "default");`),
+			},
+			want: `
+-- Table: "public"."users"
+ALTER TABLE "public"."users" ALTER COLUMN "name" TYPE text COLLATE "default";
+
+-- Collation: "public"."german"
+DROP COLLATION "public"."german";`,
+			wantErr: false,
+		},
+		{
+			name: "change collation definition",
+			args: args{
+				source:  newReader(`CREATE COLLATION german FROM "de_DE";`),
+				desired: newReader(`CREATE COLLATION german (locale = 'de_DE.utf8');`),
+			},
+			wantErr: true,
+		},
+		{
+			name: "create table with row level security",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE TABLE accounts (tenant_id integer);
+ALTER TABLE accounts ENABLE ROW LEVEL SECURITY;
+CREATE POLICY tenant_isolation ON accounts USING (tenant_id = current_setting('app.tenant')::integer);`),
+			},
+			want: `
+-- Table: "public"."accounts"
+CREATE TABLE "public"."accounts" (
+    "tenant_id" integer
+);
+CREATE POLICY "tenant_isolation" ON "public"."accounts" USING ("tenant_id"="current_setting"('app.tenant')::integer);
+ALTER TABLE "public"."accounts" ENABLE ROW LEVEL SECURITY;`,
+			wantErr: false,
+		},
+		{
+			name: "alter and recreate policies",
+			args: args{
+				source: newReader(`
+CREATE TABLE accounts (tenant_id integer, owner text);
+ALTER TABLE accounts ENABLE ROW LEVEL SECURITY;
+CREATE POLICY owners ON accounts FOR SELECT USING (owner = current_user);
+CREATE POLICY tenants ON accounts TO app_user USING (tenant_id > 0);
+CREATE POLICY unused ON accounts USING (true);`),
+				desired: newReader(`
+CREATE TABLE accounts (tenant_id integer, owner text);
+ALTER TABLE accounts ENABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY;
+CREATE POLICY owners ON accounts FOR UPDATE USING (owner = current_user);
+CREATE POLICY tenants ON accounts TO PUBLIC USING (tenant_id > 1);
+DROP POLICY IF EXISTS missing ON accounts;`),
+			},
+			want: `
+-- Table: "public"."accounts"
+DROP POLICY "owners" ON "public"."accounts";
+DROP POLICY "unused" ON "public"."accounts";
+CREATE POLICY "owners" ON "public"."accounts" FOR UPDATE USING ("owner"=CURRENT_USER);
+ALTER POLICY "tenants" ON "public"."accounts" TO PUBLIC USING ("tenant_id">1);
+ALTER TABLE "public"."accounts" FORCE ROW LEVEL SECURITY;`,
+			wantErr: false,
+		},
+		{
+			name: "drop policy before the column it uses",
+			args: args{
+				source: newReader(`
+CREATE TABLE accounts (id integer, tenant_id integer);
+CREATE POLICY tenants ON accounts USING (tenant_id > 0);
+ALTER TABLE ONLY accounts ENABLE ROW LEVEL SECURITY;`),
+				desired: newReader(`CREATE TABLE accounts (id integer);`),
+			},
+			want: `
+-- Table: "public"."accounts"
+DROP POLICY "tenants" ON "public"."accounts";
+ALTER TABLE "public"."accounts" DROP COLUMN "tenant_id";
+ALTER TABLE "public"."accounts" DISABLE ROW LEVEL SECURITY;`,
+			wantErr: false,
+		},
+		{
+			name: "create table with replica identity and cluster index",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE TABLE users (id integer NOT NULL, email text NOT NULL);
+CREATE UNIQUE INDEX users_email_key ON users (email);
+ALTER TABLE ONLY users REPLICA IDENTITY USING INDEX users_email_key;
+ALTER TABLE users CLUSTER ON users_email_key;`),
+			},
+			want: `
+-- Table: "public"."users"
+CREATE TABLE "public"."users" (
+    "id" integer NOT NULL,
+    "email" text NOT NULL
+);
+CREATE UNIQUE INDEX "users_email_key" ON "public"."users" ("email");
+ALTER TABLE ONLY "public"."users" REPLICA IDENTITY USING INDEX "users_email_key";
+ALTER TABLE "public"."users" CLUSTER ON "users_email_key";`,
+			wantErr: false,
+		},
+		{
+			name: "use new index as replica identity",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id integer NOT NULL, email text NOT NULL);
+ALTER TABLE ONLY users REPLICA IDENTITY FULL;`),
+				desired: newReader(`
+CREATE TABLE users (id integer NOT NULL, email text NOT NULL);
+CREATE UNIQUE INDEX users_email_key ON users (email);
+ALTER TABLE ONLY users REPLICA IDENTITY USING INDEX users_email_key;`),
+			},
+			want: `
+-- Table: "public"."users"
+CREATE UNIQUE INDEX "users_email_key" ON "public"."users" ("email");
+ALTER TABLE ONLY "public"."users" REPLICA IDENTITY USING INDEX "users_email_key";`,
+			wantErr: false,
+		},
+		{
+			name: "set replica identity and cluster again on a recreated index",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id integer NOT NULL, email text NOT NULL);
+CREATE UNIQUE INDEX users_email_key ON users (email);
+ALTER TABLE ONLY users REPLICA IDENTITY USING INDEX users_email_key;
+ALTER TABLE users CLUSTER ON users_email_key;`),
+				desired: newReader(`
+CREATE TABLE users (id integer NOT NULL, email text NOT NULL);
+CREATE UNIQUE INDEX users_email_key ON users (email, id);
+ALTER TABLE ONLY users REPLICA IDENTITY USING INDEX users_email_key;
+ALTER TABLE users CLUSTER ON users_email_key;`),
+			},
+			want: `
+-- Table: "public"."users"
+DROP INDEX "public"."users_email_key";
+CREATE UNIQUE INDEX "users_email_key" ON "public"."users" ("email", "id");
+ALTER TABLE ONLY "public"."users" REPLICA IDENTITY USING INDEX "users_email_key";
+ALTER TABLE "public"."users" CLUSTER ON "users_email_key";`,
+			wantErr: false,
+		},
+		{
+			name: "reset replica identity of a dropped index",
+			args: args{
+				source: newReader(`
+CREATE TABLE users (id integer NOT NULL, email text NOT NULL);
+CREATE UNIQUE INDEX users_email_key ON users (email);
+ALTER TABLE ONLY users REPLICA IDENTITY USING INDEX users_email_key;
+ALTER TABLE users CLUSTER ON users_email_key;`),
+				desired: newReader(`CREATE TABLE users (id integer NOT NULL, email text NOT NULL);`),
+			},
+			want: `
+-- Table: "public"."users"
+DROP INDEX "public"."users_email_key";
+ALTER TABLE ONLY "public"."users" REPLICA IDENTITY DEFAULT;`,
+			wantErr: false,
+		},
+		{
+			name: "create server, user mapping and foreign table",
+			args: args{
+				source: newReader(``),
+				desired: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'reports', dbname 'reports');
+CREATE USER MAPPING FOR app SERVER reporting OPTIONS (user 'app', password 'secret');
+CREATE FOREIGN TABLE orders (id bigint OPTIONS (column_name 'order_id') NOT NULL, total integer) SERVER reporting OPTIONS (table_name 'orders');
+IMPORT FOREIGN SCHEMA billing FROM SERVER reporting INTO public;`),
+			},
+			want: `
+-- Server: "reporting"
+CREATE SERVER "reporting" FOREIGN DATA WRAPPER "postgres_fdw" OPTIONS ("host" 'reports', "dbname" 'reports');
+
+-- User Mapping: "app" SERVER "reporting"
+CREATE USER MAPPING FOR "app" SERVER "reporting" OPTIONS ("user" 'app', "password" 'secret');
+
+-- Table: "public"."orders"
+CREATE FOREIGN TABLE "public"."orders" (
+    "id" bigint OPTIONS ("column_name" 'order_id') NOT NULL,
+    "total" integer
+) SERVER "reporting" OPTIONS ("table_name" 'orders');
+
+-- Foreign Schema: "billing"
+IMPORT FOREIGN SCHEMA "billing" FROM SERVER "reporting" INTO "public";`,
+			wantErr: false,
+		},
+		{
+			name: "keep foreign tables created by import foreign schema",
+			args: args{
+				source: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw;
+CREATE FOREIGN TABLE invoices (id bigint) SERVER reporting OPTIONS (schema_name 'billing', table_name 'invoices');
+CREATE FOREIGN TABLE payments (id bigint) SERVER reporting OPTIONS (schema_name 'billing', table_name 'payments');
+CREATE FOREIGN TABLE audits (id bigint) SERVER reporting OPTIONS (schema_name 'billing', table_name 'audits');`),
+				desired: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw;
+IMPORT FOREIGN SCHEMA billing EXCEPT (audits) FROM SERVER reporting INTO public;`),
+			},
+			want: `
+-- Table: "public"."audits"
+DROP FOREIGN TABLE "public"."audits";`,
+			wantErr: false,
+		},
+		{
+			name: "ignore import foreign schema only in source",
+			args: args{
+				source: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw;
+IMPORT FOREIGN SCHEMA billing FROM SERVER reporting INTO public;`),
+				desired: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw;`),
+			},
+			want:    ``,
+			wantErr: false,
+		},
+		{
+			name: "alter server, user mapping and foreign table options",
+			args: args{
+				source: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'reports', port '5432');
+CREATE USER MAPPING FOR app SERVER reporting OPTIONS (user 'app', password 'secret');
+CREATE FOREIGN TABLE orders (id bigint OPTIONS (column_name 'order_id'), total integer) SERVER reporting OPTIONS (table_name 'orders');`),
+				desired: newReader(`
+CREATE SERVER reporting VERSION '16' FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'reports2', dbname 'reports');
+CREATE USER MAPPING FOR app SERVER reporting OPTIONS (user 'app', password 'changed');
+CREATE FOREIGN TABLE orders (id bigint NOT NULL, total integer, note text OPTIONS (column_name 'memo')) SERVER reporting;
+ALTER FOREIGN TABLE orders OPTIONS (ADD schema_name 'sales'), ALTER COLUMN id OPTIONS (ADD column_name 'id');`),
+			},
+			want: `
+-- Server: "reporting"
+ALTER SERVER "reporting" VERSION '16' OPTIONS (DROP "port", SET "host" 'reports2', ADD "dbname" 'reports');
+
+-- User Mapping: "app" SERVER "reporting"
+ALTER USER MAPPING FOR "app" SERVER "reporting" OPTIONS (SET "password" 'changed');
+
+-- Table: "public"."orders"
+ALTER FOREIGN TABLE "public"."orders" OPTIONS (DROP "table_name", ADD "schema_name" 'sales');
+ALTER FOREIGN TABLE "public"."orders" ALTER COLUMN "id" SET NOT NULL;
+ALTER FOREIGN TABLE "public"."orders" ALTER COLUMN "id" OPTIONS (SET "column_name" 'id');
+ALTER FOREIGN TABLE "public"."orders" ADD COLUMN "note" text OPTIONS ("column_name" 'memo');`,
+			wantErr: false,
+		},
+		{
+			name: "drop server cascading to its user mappings and foreign tables",
+			args: args{
+				source: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw;
+CREATE USER MAPPING FOR PUBLIC SERVER reporting;
+CREATE FOREIGN TABLE orders (id bigint) SERVER reporting;`),
+				desired: newReader(`
+CREATE SERVER reporting FOREIGN DATA WRAPPER postgres_fdw;
+CREATE USER MAPPING FOR PUBLIC SERVER reporting;
+CREATE FOREIGN TABLE orders (id bigint) SERVER reporting;
+DROP SERVER reporting CASCADE;`),
+			},
+			want: `
+-- Table: "public"."orders"
+DROP FOREIGN TABLE "public"."orders";
+
+-- User Mapping: PUBLIC SERVER "reporting"
+DROP USER MAPPING FOR PUBLIC SERVER "reporting";
+
+-- Server: "reporting"
+DROP SERVER "reporting";`,
+			wantErr: false,
+		},
+		{
+			name: "move foreign table to another server",
+			args: args{
+				source: newReader(`
+CREATE SERVER a FOREIGN DATA WRAPPER postgres_fdw;
+CREATE SERVER b FOREIGN DATA WRAPPER postgres_fdw;
+CREATE FOREIGN TABLE orders (id bigint) SERVER a;`),
+				desired: newReader(`
+CREATE SERVER a FOREIGN DATA WRAPPER postgres_fdw;
+CREATE SERVER b FOREIGN DATA WRAPPER postgres_fdw;
+CREATE FOREIGN TABLE orders (id bigint) SERVER b;`),
+			},
+			want: `
+-- Table: "public"."orders"
+DROP FOREIGN TABLE "public"."orders";
+
+-- Table: "public"."orders"
+CREATE FOREIGN TABLE "public"."orders" (
+    "id" bigint
+) SERVER "b";`,
+			wantErr: false,
+		},
+		{
+			name: "switch between table and foreign table",
+			args: args{
+				source: newReader(`
+CREATE SERVER a FOREIGN DATA WRAPPER postgres_fdw;
+CREATE TABLE orders (id bigint);
+CREATE FOREIGN TABLE users (id bigint) SERVER a;`),
+				desired: newReader(`
+CREATE SERVER a FOREIGN DATA WRAPPER postgres_fdw;
+CREATE FOREIGN TABLE orders (id bigint) SERVER a OPTIONS (table_name 'orders');
+CREATE TABLE users (id bigint);`),
+			},
+			want: `
+-- Table: "public"."orders"
+DROP TABLE "public"."orders";
+
+-- Table: "public"."users"
+DROP FOREIGN TABLE "public"."users";
+
+-- Table: "public"."orders"
+CREATE FOREIGN TABLE "public"."orders" (
+    "id" bigint
+) SERVER "a" OPTIONS ("table_name" 'orders');
+
+-- Table: "public"."users"
+CREATE TABLE "public"."users" (
+    "id" bigint
+);`,
+			wantErr: false,
+		},
+		{
+			name: "change partition key",
+			args: args{
+				source:  newReader(`CREATE TABLE events (id bigint, day date) PARTITION BY RANGE (day);`),
+				desired: newReader(`CREATE TABLE events (id bigint, day date) PARTITION BY HASH (id);`),
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := Process(tt.args.source, tt.args.desired)
+			got, err := Process(tt.args.source, tt.args.desired, tt.args.options...)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Process() error = %v, wantErr %v", err, tt.wantErr)
 				type detailError interface{ Detail() string }
@@ -349,3 +1741,63 @@ ALTER TABLE ONLY "public"."users" DROP CONSTRAINT "users_pkey";`,
 		})
 	}
 }
+
+func TestProcessInclude(t *testing.T) {
+	dir, err := ioutil.TempDir("", "pgconverger")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	files := map[string]string{
+		"root.sql":         "\\restrict key\n\\ir tables/users.sql\nCREATE TABLE posts (id bigint);\n\\unrestrict key\n",
+		"tables/users.sql": "\\i ../common.sql\nCREATE TABLE users (id bigint);\n",
+		"common.sql":       "SET search_path = app;\n",
+		"cycle.sql":        "\n\\ir tables/cycle.sql\n",
+		"tables/cycle.sql": "CREATE TABLE users (id bigint);\n\\ir ../cycle.sql\n",
+	}
+	for name, data := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(data), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	open := func(name string) fileReader {
+		file, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return file
+	}
+
+	got, err := Process(newReader(``), open("root.sql"))
+	if err != nil {
+		t.Fatalf("Process() error = %+v", err)
+	}
+	want := `
+-- Table: "app"."posts"
+CREATE TABLE "app"."posts" (
+    "id" bigint
+);
+
+-- Table: "app"."users"
+CREATE TABLE "app"."users" (
+    "id" bigint
+);`
+	if canonical(got) != canonical(want) {
+		t.Errorf("Process() = %v, want %v", got, want)
+	}
+
+	_, err = Process(newReader(``), open("cycle.sql"))
+	if err == nil {
+		t.Fatal("Process() error = nil, want include cycle")
+	}
+	wantDetail := fmt.Sprintf("%s:2: include cycle: %s -> %s -> %s",
+		filepath.Join(dir, "tables/cycle.sql"),
+		filepath.Join(dir, "cycle.sql"), filepath.Join(dir, "tables/cycle.sql"), filepath.Join(dir, "cycle.sql"))
+	if detail := err.(*Error).Detail(); !strings.Contains(detail, wantDetail) {
+		t.Errorf("Error.Detail() = %q, want %q", detail, wantDetail)
+	}
+}
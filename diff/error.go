@@ -21,6 +21,9 @@ func (err *Error) Error() string {
 	if df.desiredErrors != nil {
 		errors = append(errors, fmt.Sprintf("desired has %d errors", len(df.desiredErrors)))
 	}
+	for _, e := range df.patchErrors {
+		errors = append(errors, e.Error())
+	}
 	return strings.Join(errors, "; ")
 }
 
@@ -41,6 +44,9 @@ func (err *Error) Detail() string {
 			lines = append(lines, fmt.Sprintf("  %s", e.Error()))
 		}
 	}
+	for _, e := range df.patchErrors {
+		lines = append(lines, e.Error())
+	}
 	return strings.Join(lines, "\n")
 }
 
@@ -0,0 +1,285 @@
+package diff
+
+import (
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// normalizeGrouping returns a copy of expr with the parentheses rewritten
+// the same way whatever was written: every grouping is removed, and every
+// operand which is not a single term is grouped. pg_dump groups every
+// subexpression, so this lets WHERE ((a > 0) AND (b IS NOT NULL)) compare
+// equal to WHERE a > 0 AND b IS NOT NULL, while (a + b) * c still differs
+// from a + b * c.
+func normalizeGrouping(expr ast.Expression) ast.Expression {
+	switch v := expr.(type) {
+	case *ast.GroupedExpression:
+		return normalizeGrouping(v.Expression)
+	case *ast.InfixExpression:
+		normalized := *v
+		normalized.Left = normalizeOperand(v.Left)
+		normalized.Right = normalizeOperand(v.Right)
+		return &normalized
+	case *ast.PrefixExpression:
+		normalized := *v
+		normalized.Right = normalizeOperand(v.Right)
+		return &normalized
+	case *ast.TypeCastExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		return &normalized
+	case *ast.CastExpression:
+		normalized := *v
+		normalized.Expression = normalizeGrouping(v.Expression)
+		return &normalized
+	case *ast.IsExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		if v.DistinctFrom != nil {
+			normalized.DistinctFrom = normalizeOperand(v.DistinctFrom)
+		}
+		return &normalized
+	case *ast.LikeExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		normalized.Pattern = normalizeOperand(v.Pattern)
+		if v.Escape != nil {
+			normalized.Escape = normalizeOperand(v.Escape)
+		}
+		return &normalized
+	case *ast.InExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		normalized.List = normalizeGroupings(v.List)
+		return &normalized
+	case *ast.BetweenExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		normalized.Low = normalizeOperand(v.Low)
+		normalized.High = normalizeOperand(v.High)
+		return &normalized
+	case *ast.SubLinkExpression:
+		normalized := *v
+		normalized.Expression = normalizeGrouping(v.Expression)
+		return &normalized
+	case *ast.CaseExpression:
+		normalized := *v
+		if v.Argument != nil {
+			normalized.Argument = normalizeGrouping(v.Argument)
+		}
+		normalized.Whens = make([]*ast.CaseWhen, len(v.Whens))
+		for i, when := range v.Whens {
+			normalized.Whens[i] = &ast.CaseWhen{
+				Condition: normalizeGrouping(when.Condition),
+				Result:    normalizeGrouping(when.Result),
+			}
+		}
+		if v.Else != nil {
+			normalized.Else = normalizeGrouping(v.Else)
+		}
+		return &normalized
+	case *ast.ArrayExpression:
+		normalized := *v
+		normalized.Elements = normalizeGroupings(v.Elements)
+		return &normalized
+	case *ast.SubscriptExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		if v.Lower != nil {
+			normalized.Lower = normalizeGrouping(v.Lower)
+		}
+		if v.Upper != nil {
+			normalized.Upper = normalizeGrouping(v.Upper)
+		}
+		return &normalized
+	case *ast.RowExpression:
+		normalized := *v
+		normalized.Fields = normalizeGroupings(v.Fields)
+		return &normalized
+	case *ast.CollateExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		return &normalized
+	case *ast.AtTimeZoneExpression:
+		normalized := *v
+		normalized.Expression = normalizeOperand(v.Expression)
+		normalized.Zone = normalizeOperand(v.Zone)
+		return &normalized
+	case *ast.CallExpression:
+		normalized := *v
+		normalized.Arguments = normalizeGroupings(v.Arguments)
+		return &normalized
+	case *ast.SpecialFunction:
+		normalized := *v
+		normalized.Arguments = normalizeGroupings(v.Arguments)
+		return &normalized
+	default:
+		return expr
+	}
+}
+
+func normalizeGroupings(list []ast.Expression) []ast.Expression {
+	normalized := make([]ast.Expression, len(list))
+	for i, expr := range list {
+		normalized[i] = normalizeGrouping(expr)
+	}
+	return normalized
+}
+
+// normalizeOperand normalizes an operand of an operator, grouping it unless
+// it is a single term.
+func normalizeOperand(expr ast.Expression) ast.Expression {
+	normalized := normalizeGrouping(expr)
+	if isTerm(normalized) {
+		return normalized
+	}
+	return &ast.GroupedExpression{Expression: normalized}
+}
+
+// isTerm reports whether expr is written as a single term, which needs no
+// parentheses as an operand.
+func isTerm(expr ast.Expression) bool {
+	switch expr.(type) {
+	case *ast.Identifier, *ast.QualifiedName, *ast.StringLiteral, *ast.NumberLiteral, *ast.BooleanLiteral,
+		*ast.NullLiteral, *ast.CallExpression, *ast.SpecialFunction, *ast.KeywordFunction, *ast.CastExpression, *ast.CaseExpression,
+		*ast.ArrayExpression, *ast.RowExpression, *ast.SubLinkExpression:
+		return true
+	default:
+		return false
+	}
+}
+
+// mapExpression returns a copy of expr with fn applied to every
+// subexpression, innermost first. The names of functions, types and
+// collations are not subexpressions.
+func mapExpression(expr ast.Expression, fn func(ast.Expression) ast.Expression) ast.Expression {
+	if expr == nil {
+		return nil
+	}
+	switch v := expr.(type) {
+	case *ast.GroupedExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		expr = &mapped
+	case *ast.InfixExpression:
+		mapped := *v
+		mapped.Left = mapExpression(v.Left, fn)
+		mapped.Right = mapExpression(v.Right, fn)
+		expr = &mapped
+	case *ast.PrefixExpression:
+		mapped := *v
+		mapped.Right = mapExpression(v.Right, fn)
+		expr = &mapped
+	case *ast.TypeCastExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		expr = &mapped
+	case *ast.CastExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		expr = &mapped
+	case *ast.IsExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		mapped.DistinctFrom = mapExpression(v.DistinctFrom, fn)
+		expr = &mapped
+	case *ast.LikeExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		mapped.Pattern = mapExpression(v.Pattern, fn)
+		mapped.Escape = mapExpression(v.Escape, fn)
+		expr = &mapped
+	case *ast.InExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		mapped.List = mapExpressions(v.List, fn)
+		expr = &mapped
+	case *ast.BetweenExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		mapped.Low = mapExpression(v.Low, fn)
+		mapped.High = mapExpression(v.High, fn)
+		expr = &mapped
+	case *ast.SubLinkExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		expr = &mapped
+	case *ast.CaseExpression:
+		mapped := *v
+		mapped.Argument = mapExpression(v.Argument, fn)
+		mapped.Whens = make([]*ast.CaseWhen, len(v.Whens))
+		for i, when := range v.Whens {
+			mapped.Whens[i] = &ast.CaseWhen{
+				Condition: mapExpression(when.Condition, fn),
+				Result:    mapExpression(when.Result, fn),
+			}
+		}
+		mapped.Else = mapExpression(v.Else, fn)
+		expr = &mapped
+	case *ast.ArrayExpression:
+		mapped := *v
+		mapped.Elements = mapExpressions(v.Elements, fn)
+		expr = &mapped
+	case *ast.SubscriptExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		mapped.Lower = mapExpression(v.Lower, fn)
+		mapped.Upper = mapExpression(v.Upper, fn)
+		expr = &mapped
+	case *ast.RowExpression:
+		mapped := *v
+		mapped.Fields = mapExpressions(v.Fields, fn)
+		expr = &mapped
+	case *ast.CollateExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		expr = &mapped
+	case *ast.AtTimeZoneExpression:
+		mapped := *v
+		mapped.Expression = mapExpression(v.Expression, fn)
+		mapped.Zone = mapExpression(v.Zone, fn)
+		expr = &mapped
+	case *ast.CallExpression:
+		mapped := *v
+		mapped.Arguments = mapExpressions(v.Arguments, fn)
+		expr = &mapped
+	case *ast.SpecialFunction:
+		mapped := *v
+		mapped.Arguments = mapExpressions(v.Arguments, fn)
+		expr = &mapped
+	}
+	return fn(expr)
+}
+
+func mapExpressions(list []ast.Expression, fn func(ast.Expression) ast.Expression) []ast.Expression {
+	if list == nil {
+		return nil
+	}
+	mapped := make([]ast.Expression, len(list))
+	for i, expr := range list {
+		mapped[i] = mapExpression(expr, fn)
+	}
+	return mapped
+}
+
+// referencesColumn reports whether expr refers to the column name.
+func referencesColumn(expr ast.Expression, name string) bool {
+	found := false
+	mapExpression(expr, func(expr ast.Expression) ast.Expression {
+		if identifier, ok := expr.(*ast.Identifier); ok && identifier.Value == name {
+			found = true
+		}
+		return expr
+	})
+	return found
+}
+
+// renameColumnReferences returns a copy of expr referring to the column
+// newName instead of name.
+func renameColumnReferences(expr ast.Expression, name, newName string) ast.Expression {
+	return mapExpression(expr, func(expr ast.Expression) ast.Expression {
+		if identifier, ok := expr.(*ast.Identifier); ok && identifier.Value == name {
+			return newIdentifier(newName)
+		}
+		return expr
+	})
+}
@@ -0,0 +1,392 @@
+package diff
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// Servers maps the name of a foreign server to its definition.
+type Servers map[string]*ast.CreateServerStatement
+
+// UserMappings maps the user and server of a user mapping, as given by
+// userMappingKey, to its definition.
+type UserMappings map[string]*ast.CreateUserMappingStatement
+
+func (servers Servers) SortedKeys() (keys []string) {
+	for k := range servers {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+func (userMappings UserMappings) SortedKeys() (keys []string) {
+	for k := range userMappings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// foreign reports whether table is a foreign table.
+func (table *Table) foreign() bool {
+	return table.Server != ""
+}
+
+// keyword returns the kind of table as written in ALTER and DROP.
+func (table *Table) keyword() string {
+	if table.foreign() {
+		return "FOREIGN TABLE"
+	}
+	return "TABLE"
+}
+
+// AddServer records a CREATE SERVER.
+func (servers Servers) AddServer(createServerStatement *ast.CreateServerStatement) {
+	name := createServerStatement.Name.Value
+	if _, ok := servers[name]; ok {
+		if !createServerStatement.IfNotExists {
+			log.Printf("irregular create server to existing server=%s", name)
+		}
+		return
+	}
+	createServerStatement.IfNotExists = false
+	servers[name] = createServerStatement
+}
+
+// alterServer applies ALTER SERVER, which changes the version or the options
+// of a server.
+func (servers Servers) alterServer(alterServerStatement *ast.AlterServerStatement) {
+	server, ok := servers[alterServerStatement.Name.Value]
+	if !ok {
+		log.Printf("irregular alter server to unknown server=%s", alterServerStatement.Name.Value)
+		return
+	}
+	altered := *server
+	if alterServerStatement.Version != "" {
+		altered.Version = alterServerStatement.Version
+	}
+	altered.Options = applyGenericOptions(server.Options, alterServerStatement.Options)
+	servers[altered.Name.Value] = &altered
+}
+
+// dropServer removes the server name along with its user mappings and
+// foreign tables, as DROP SERVER ... CASCADE does.
+func (catalog *Catalog) dropServer(tables Tables, name string) bool {
+	if _, ok := catalog.Servers[name]; !ok {
+		return false
+	}
+	delete(catalog.Servers, name)
+	for key, userMapping := range catalog.UserMappings {
+		if userMapping.Server.Value == name {
+			delete(catalog.UserMappings, key)
+		}
+	}
+	for identifier, table := range tables {
+		if table.Server == name {
+			tables.dropTable(identifier)
+		}
+	}
+	return true
+}
+
+// userMappingKey identifies the user mapping of user on server.
+func userMappingKey(user *ast.RoleSpecification, server *ast.Identifier) string {
+	if user.Name != nil {
+		return ast.QuoteIdentifier(user.Name.Value) + " " + ast.QuoteIdentifier(server.Value)
+	}
+	return user.Keyword + " " + ast.QuoteIdentifier(server.Value)
+}
+
+// AddUserMapping records a CREATE USER MAPPING.
+func (userMappings UserMappings) AddUserMapping(createUserMappingStatement *ast.CreateUserMappingStatement) {
+	key := userMappingKey(createUserMappingStatement.User, createUserMappingStatement.Server)
+	if _, ok := userMappings[key]; ok {
+		if !createUserMappingStatement.IfNotExists {
+			log.Printf("irregular create user mapping to existing user mapping=%s", key)
+		}
+		return
+	}
+	createUserMappingStatement.IfNotExists = false
+	userMappings[key] = createUserMappingStatement
+}
+
+// alterUserMapping applies ALTER USER MAPPING, which changes its options.
+func (userMappings UserMappings) alterUserMapping(alterUserMappingStatement *ast.AlterUserMappingStatement) {
+	key := userMappingKey(alterUserMappingStatement.User, alterUserMappingStatement.Server)
+	userMapping, ok := userMappings[key]
+	if !ok {
+		log.Printf("irregular alter user mapping to unknown user mapping=%s", key)
+		return
+	}
+	altered := *userMapping
+	altered.Options = applyGenericOptions(userMapping.Options, alterUserMappingStatement.Options)
+	userMappings[key] = &altered
+}
+
+// dropUserMapping applies DROP USER MAPPING.
+func (userMappings UserMappings) dropUserMapping(dropUserMappingStatement *ast.DropUserMappingStatement) {
+	key := userMappingKey(dropUserMappingStatement.User, dropUserMappingStatement.Server)
+	if _, ok := userMappings[key]; !ok {
+		if !dropUserMappingStatement.IfExists {
+			log.Printf("irregular drop user mapping to unknown user mapping=%s", key)
+		}
+		return
+	}
+	delete(userMappings, key)
+}
+
+// findGenericOption returns the position of the option name in options, or
+// -1.
+func findGenericOption(options ast.GenericOptions, name string) int {
+	for i, option := range options {
+		if option.Name.Value == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// applyGenericOptions returns options with the ADD, SET and DROP of changes
+// applied, keeping the order in which the options were added.
+func applyGenericOptions(options, changes ast.GenericOptions) ast.GenericOptions {
+	applied := append(ast.GenericOptions(nil), options...)
+	for _, change := range changes {
+		i := findGenericOption(applied, change.Name.Value)
+		switch change.Action {
+		case "SET":
+			if i < 0 {
+				log.Printf("irregular set option to unknown option=%s", change.Name.Value)
+				continue
+			}
+			applied[i] = &ast.GenericOption{Name: change.Name, Value: change.Value}
+		case "DROP":
+			if i < 0 {
+				log.Printf("irregular drop option to unknown option=%s", change.Name.Value)
+				continue
+			}
+			applied = append(applied[:i], applied[i+1:]...)
+		default:
+			if i >= 0 {
+				log.Printf("irregular add option to existing option=%s", change.Name.Value)
+				continue
+			}
+			applied = append(applied, &ast.GenericOption{Name: change.Name, Value: change.Value})
+		}
+	}
+	return applied
+}
+
+// diffGenericOptions returns the OPTIONS changes turning source into
+// desired, dropping the removed options before setting and adding the
+// others. It returns nil when they are equal.
+func diffGenericOptions(source, desired ast.GenericOptions) (changes ast.GenericOptions) {
+	for _, option := range source {
+		if findGenericOption(desired, option.Name.Value) < 0 {
+			changes = append(changes, &ast.GenericOption{Action: "DROP", Name: option.Name})
+		}
+	}
+	for _, option := range desired {
+		i := findGenericOption(source, option.Name.Value)
+		switch {
+		case i < 0:
+			changes = append(changes, &ast.GenericOption{Action: "ADD", Name: option.Name, Value: option.Value})
+		case source[i].Value != option.Value:
+			changes = append(changes, &ast.GenericOption{Action: "SET", Name: option.Name, Value: option.Value})
+		}
+	}
+	return
+}
+
+// recreatedForeign reports whether a table changes between foreign and
+// regular, or moves to another server. PostgreSQL cannot do that in place,
+// so the table is dropped and created again, which loses no data of a
+// foreign table.
+func recreatedForeign(sourceTable, desiredTable *Table) bool {
+	return sourceTable.Server != desiredTable.Server
+}
+
+// diffOptions writes the changes to the options of a foreign table.
+func (df *Diff) diffOptions(sourceTable, desiredTable *Table) {
+	if options := diffGenericOptions(sourceTable.Options, desiredTable.Options); options != nil {
+		df.WriteString(fmt.Sprintf("ALTER %s %s %s;\n", sourceTable.keyword(), df.tableName(sourceTable), df.formatNode(options)))
+	}
+}
+
+// alterColumnOptions writes the changes to the options of a column of a
+// foreign table.
+func (df *Diff) alterColumnOptions(table *Table, sourceColumn *Column, desiredColumn *Column) {
+	if options := diffGenericOptions(sourceColumn.Options, desiredColumn.Options); options != nil {
+		df.WriteString(fmt.Sprintf("ALTER %s %s ALTER COLUMN %s %s;\n",
+			table.keyword(), df.tableName(table),
+			df.quote(desiredColumn.Name),
+			df.formatNode(options),
+		))
+	}
+}
+
+func (df *Diff) writeServerAnnotation(server *ast.CreateServerStatement) {
+	df.WriteString("-- Server: " + df.formatNode(server.Name) + "\n")
+}
+
+func (df *Diff) writeUserMappingAnnotation(userMapping *ast.CreateUserMappingStatement) {
+	df.WriteString("-- User Mapping: " + df.formatNode(userMapping.User) + " SERVER " + df.formatNode(userMapping.Server) + "\n")
+}
+
+// createServers creates and alters the servers and their user mappings,
+// before the foreign tables which use them. A server cannot change its type
+// or foreign data wrapper, nor lose its version, so such a change is
+// recorded as an error.
+func (df *Diff) createServers() {
+	sourceServers := df.sourceCatalog.Servers
+	desiredServers := df.desiredCatalog.Servers
+	for _, name := range desiredServers.SortedKeys() {
+		desiredServer := desiredServers[name]
+		sourceServer, ok := sourceServers[name]
+		if !ok {
+			df.writeServerAnnotation(desiredServer)
+			df.writeNode(desiredServer)
+			df.WriteString("\n")
+			continue
+		}
+		if sourceServer.Type != desiredServer.Type || sourceServer.Wrapper.Value != desiredServer.Wrapper.Value ||
+			sourceServer.Version != "" && desiredServer.Version == "" {
+			df.patchErrors = append(df.patchErrors, fmt.Errorf(
+				"cannot change the type, version or foreign data wrapper of server %s in place; it must be recreated",
+				df.formatNode(sourceServer.Name),
+			))
+			continue
+		}
+		alterServerStatement := &ast.AlterServerStatement{
+			Name:    desiredServer.Name,
+			Options: diffGenericOptions(sourceServer.Options, desiredServer.Options),
+		}
+		if sourceServer.Version != desiredServer.Version {
+			alterServerStatement.Version = desiredServer.Version
+		}
+		if alterServerStatement.Version != "" || alterServerStatement.Options != nil {
+			df.writeServerAnnotation(desiredServer)
+			df.writeNode(alterServerStatement)
+			df.WriteString("\n")
+		}
+	}
+
+	sourceUserMappings := df.sourceCatalog.UserMappings
+	desiredUserMappings := df.desiredCatalog.UserMappings
+	for _, key := range desiredUserMappings.SortedKeys() {
+		desiredUserMapping := desiredUserMappings[key]
+		sourceUserMapping, ok := sourceUserMappings[key]
+		if !ok {
+			df.writeUserMappingAnnotation(desiredUserMapping)
+			df.writeNode(desiredUserMapping)
+			df.WriteString("\n")
+			continue
+		}
+		if options := diffGenericOptions(sourceUserMapping.Options, desiredUserMapping.Options); options != nil {
+			df.writeUserMappingAnnotation(desiredUserMapping)
+			df.writeNode(&ast.AlterUserMappingStatement{
+				User:    desiredUserMapping.User,
+				Server:  desiredUserMapping.Server,
+				Options: options,
+			})
+			df.WriteString("\n")
+		}
+	}
+}
+
+// dropServers drops the removed user mappings and servers, after the
+// foreign tables using them have been dropped.
+func (df *Diff) dropServers() {
+	sourceUserMappings := df.sourceCatalog.UserMappings
+	for _, key := range sourceUserMappings.SortedKeys() {
+		if _, ok := df.desiredCatalog.UserMappings[key]; ok {
+			continue
+		}
+		userMapping := sourceUserMappings[key]
+		df.writeUserMappingAnnotation(userMapping)
+		df.writeNode(&ast.DropUserMappingStatement{User: userMapping.User, Server: userMapping.Server})
+		df.WriteString("\n")
+	}
+
+	sourceServers := df.sourceCatalog.Servers
+	for _, name := range sourceServers.SortedKeys() {
+		if _, ok := df.desiredCatalog.Servers[name]; ok {
+			continue
+		}
+		df.writeServerAnnotation(sourceServers[name])
+		df.WriteString(fmt.Sprintf("DROP SERVER %s;\n", df.formatNode(sourceServers[name].Name)))
+		df.WriteString("\n")
+	}
+}
+
+// imports reports whether the foreign table table is one of the tables
+// importForeignSchemaStatement creates: a table of its server in its local
+// schema, allowed by its LIMIT TO or EXCEPT list.
+func imports(importForeignSchemaStatement *ast.ImportForeignSchemaStatement, table *Table) bool {
+	tableName := table.CreateTableStatement.TableName
+	if table.Server != importForeignSchemaStatement.Server.Value ||
+		tableName.SchemaIdentifier.Value != importForeignSchemaStatement.LocalSchema.Value {
+		return false
+	}
+	listed := false
+	for _, name := range importForeignSchemaStatement.Tables {
+		if name.Value == tableName.TableIdentifier.Value {
+			listed = true
+			break
+		}
+	}
+	switch importForeignSchemaStatement.Restriction {
+	case "LIMIT TO":
+		return listed
+	case "EXCEPT":
+		return !listed
+	default:
+		return true
+	}
+}
+
+// importedTable reports whether sourceTable is created by one of the desired
+// IMPORT FOREIGN SCHEMA statements, so it is kept even though the desired
+// schema does not define it.
+func (df *Diff) importedTable(sourceTable *Table) bool {
+	for _, importForeignSchemaStatement := range df.desiredCatalog.Imports {
+		if imports(importForeignSchemaStatement, sourceTable) {
+			return true
+		}
+	}
+	return false
+}
+
+// importForeignSchemas runs the new IMPORT FOREIGN SCHEMA statements, after
+// the tables have been created. pg_dump writes the foreign tables an import
+// created rather than the import, so an import whose tables are already in
+// the source has run. The tables of an import found only in the source are
+// not known, so the import is left alone.
+func (df *Diff) importForeignSchemas() {
+	sourceImports := make(map[string]bool)
+	for _, importForeignSchemaStatement := range df.sourceCatalog.Imports {
+		sourceImports[df.formatForComparison(importForeignSchemaStatement)] = true
+	}
+	for _, importForeignSchemaStatement := range df.desiredCatalog.Imports {
+		if sourceImports[df.formatForComparison(importForeignSchemaStatement)] || df.imported(importForeignSchemaStatement) {
+			continue
+		}
+		df.WriteString("-- Foreign Schema: " + df.formatNode(importForeignSchemaStatement.RemoteSchema) + "\n")
+		df.writeNode(importForeignSchemaStatement)
+		df.WriteString("\n")
+	}
+}
+
+// imported reports whether the source has any of the foreign tables
+// importForeignSchemaStatement creates.
+func (df *Diff) imported(importForeignSchemaStatement *ast.ImportForeignSchemaStatement) bool {
+	for _, table := range df.sourceTables {
+		if imports(importForeignSchemaStatement, table) {
+			return true
+		}
+	}
+	return false
+}
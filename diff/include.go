@@ -0,0 +1,99 @@
+package diff
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/ttakezawa/pgconverger/ast"
+	"github.com/ttakezawa/pgconverger/lexer"
+	"github.com/ttakezawa/pgconverger/parser"
+)
+
+// includeCommands are the psql meta-commands that read another file.
+var includeCommands = map[string]bool{
+	"i":                true,
+	"include":          true,
+	"ir":               true,
+	"include_relative": true,
+}
+
+// includeError reports a failed include at the meta-command naming it.
+type includeError struct {
+	name string
+	line int
+	err  error
+}
+
+func (e *includeError) Error() string {
+	return fmt.Sprintf("%s:%d: %s", e.name, e.line, e.err.Error())
+}
+
+// includer parses a file and splices the statements of the files it
+// includes in place of the \i and \ir meta-commands.
+type includer struct {
+	// names and absolutePaths hold the files being read, outermost first.
+	names         []string
+	absolutePaths []string
+	errs          []error
+}
+
+// parse parses input read from name, expanding its includes. A relative
+// include path is resolved against the directory of the including file.
+func (in *includer) parse(name string, input string) []ast.Statement {
+	absolutePath, err := filepath.Abs(name)
+	if err != nil {
+		absolutePath = name
+	}
+	in.names = append(in.names, name)
+	in.absolutePaths = append(in.absolutePaths, absolutePath)
+	defer func() {
+		in.names = in.names[:len(in.names)-1]
+		in.absolutePaths = in.absolutePaths[:len(in.absolutePaths)-1]
+	}()
+
+	p := parser.New(lexer.Lex(name, input))
+	ddl := p.ParseDataDefinition()
+	in.errs = append(in.errs, p.Errors()...)
+
+	var statements []ast.Statement
+	for _, statement := range ddl.StatementList {
+		metaCommand, ok := statement.(*ast.MetaCommand)
+		if !ok || !includeCommands[metaCommand.Command] {
+			statements = append(statements, statement)
+			continue
+		}
+		statements = append(statements, in.include(name, metaCommand)...)
+	}
+	return statements
+}
+
+func (in *includer) include(name string, metaCommand *ast.MetaCommand) []ast.Statement {
+	fail := func(format string, args ...interface{}) []ast.Statement {
+		in.errs = append(in.errs, &includeError{name, metaCommand.Token.Line, fmt.Errorf(format, args...)})
+		return nil
+	}
+	if len(metaCommand.Arguments) != 1 {
+		return fail("\\%s requires a file name", metaCommand.Command)
+	}
+	path := metaCommand.Arguments[0]
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(filepath.Dir(name), path)
+	}
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return fail("%v", err)
+	}
+	for i, includingPath := range in.absolutePaths {
+		if includingPath == absolutePath {
+			cycle := append(append([]string{}, in.names[i:]...), path)
+			return fail("include cycle: %s", strings.Join(cycle, " -> "))
+		}
+	}
+	input, err := ioutil.ReadFile(path)
+	if err != nil {
+		return fail("%v", err)
+	}
+	return in.parse(path, string(input))
+}
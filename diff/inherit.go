@@ -0,0 +1,285 @@
+package diff
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// parents returns the tables table inherits its columns from: the tables
+// named in INHERITS and the table it is a partition of.
+func (table *Table) parents() []*ast.TableName {
+	if table.PartitionOf != nil {
+		return append([]*ast.TableName{table.PartitionOf}, table.Inherits...)
+	}
+	return table.Inherits
+}
+
+// children returns the tables which inherit from or are partitions of
+// parent.
+func (tables Tables) children(parent string) (children []*Table) {
+	for _, table := range tables {
+		for _, name := range table.parents() {
+			if name.String() == parent {
+				children = append(children, table)
+				break
+			}
+		}
+	}
+	return
+}
+
+// parentDepth returns the length of the longest chain of parents above
+// table. limit stops the walk on inheritance cycles.
+func (tables Tables) parentDepth(table *Table, limit int) int {
+	depth := 0
+	if limit <= 0 {
+		return depth
+	}
+	for _, name := range table.parents() {
+		if parent := tables.FindTable(name.String()); parent != nil {
+			if d := tables.parentDepth(parent, limit-1) + 1; d > depth {
+				depth = d
+			}
+		}
+	}
+	return depth
+}
+
+// sortedKeysParentsFirst returns the keys of tables sorted by name, with
+// each table after the tables it inherits from. When childrenFirst is set
+// the order of the levels is reversed instead.
+func (tables Tables) sortedKeysParentsFirst(childrenFirst bool) []string {
+	keys := tables.SortedKeys()
+	depths := make(map[string]int, len(keys))
+	for _, key := range keys {
+		depths[key] = tables.parentDepth(tables[key], len(tables))
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		if childrenFirst {
+			return depths[keys[i]] > depths[keys[j]]
+		}
+		return depths[keys[i]] < depths[keys[j]]
+	})
+	return keys
+}
+
+// inheritColumns copies the columns of the parents of table it does not
+// define itself, as CREATE TABLE ... INHERITS merges them.
+func (tables Tables) inheritColumns(table *Table) {
+	for _, name := range table.parents() {
+		parent := tables.FindTable(name.String())
+		if parent == nil {
+			continue
+		}
+		for columnName, column := range parent.Columns {
+			if _, ok := table.Columns[columnName]; !ok {
+				inheritedColumn := *column
+				table.Columns[columnName] = &inheritedColumn
+			}
+		}
+	}
+}
+
+// propagateColumn applies the addition or removal of the column name on
+// table to the tables inheriting from it, as ALTER TABLE without ONLY does.
+func (tables Tables) propagateColumn(table *Table, name string) {
+	column := table.Columns[name]
+	for _, child := range tables.children(table.Identifier) {
+		if column != nil {
+			if _, ok := child.Columns[name]; ok {
+				continue
+			}
+			inheritedColumn := *column
+			child.Columns[name] = &inheritedColumn
+		} else {
+			if _, ok := child.Columns[name]; !ok {
+				continue
+			}
+			child.dropColumn(name)
+		}
+		tables.propagateColumn(child, name)
+	}
+}
+
+// replaceParent makes the tables inheriting from oldParent refer to
+// newParent instead.
+func (tables Tables) replaceParent(oldParent string, newParent *ast.TableName) {
+	for _, table := range tables {
+		if table.PartitionOf != nil && table.PartitionOf.String() == oldParent {
+			table.PartitionOf = newParent
+		}
+		var inherits []*ast.TableName
+		for _, name := range table.Inherits {
+			if name.String() == oldParent {
+				name = newParent
+			}
+			inherits = append(inherits, name)
+		}
+		table.Inherits = inherits
+	}
+}
+
+// commonParents returns the tables both sourceTable and desiredTable inherit
+// from, taken from both sides.
+func (df *Diff) commonParents(sourceTable, desiredTable *Table) (parents []*Table) {
+	desiredParents := make(map[string]bool)
+	for _, name := range desiredTable.parents() {
+		desiredParents[name.String()] = true
+	}
+	for _, name := range sourceTable.parents() {
+		identifier := name.String()
+		if !desiredParents[identifier] {
+			continue
+		}
+		if parent := df.sourceTables.FindTable(identifier); parent != nil {
+			parents = append(parents, parent)
+		}
+		if parent := df.desiredTables.FindTable(identifier); parent != nil {
+			parents = append(parents, parent)
+		}
+	}
+	return
+}
+
+// inheritedColumns returns the names of the columns which sourceTable and
+// desiredTable inherit from a parent they share. Changes to them are made
+// on the parent. All the columns of a partition are inherited.
+func (df *Diff) inheritedColumns(sourceTable, desiredTable *Table) map[string]bool {
+	inherited := make(map[string]bool)
+	if samePartitionOf(sourceTable, desiredTable) {
+		for name := range sourceTable.Columns {
+			inherited[name] = true
+		}
+		for name := range desiredTable.Columns {
+			inherited[name] = true
+		}
+	}
+	for _, parent := range df.commonParents(sourceTable, desiredTable) {
+		for name := range parent.Columns {
+			inherited[name] = true
+		}
+	}
+	return inherited
+}
+
+// inheritedConstraints returns the names of the CHECK constraints which
+// sourceTable and desiredTable inherit from a parent they share, and of the
+// constraints cloned from the parent they are both partitions of.
+func (df *Diff) inheritedConstraints(sourceTable, desiredTable *Table) map[string]bool {
+	inherited := make(map[string]bool)
+	for _, parent := range df.commonParents(sourceTable, desiredTable) {
+		for name, constraint := range parent.TableConstraints {
+			if constraint.Type == Check && !constraint.Constraint.NoInherit {
+				inherited[name] = true
+			}
+		}
+	}
+	if samePartitionOf(sourceTable, desiredTable) {
+		for name := range df.clonedConstraints(df.sourceTables, sourceTable) {
+			inherited[name] = true
+		}
+		for name := range df.clonedConstraints(df.desiredTables, desiredTable) {
+			inherited[name] = true
+		}
+	}
+	return inherited
+}
+
+// inheritedIndexes returns the names of the indexes cloned from the parent
+// sourceTable and desiredTable are both partitions of. Changes to them are
+// made on the parent.
+func (df *Diff) inheritedIndexes(sourceTable, desiredTable *Table) map[string]bool {
+	inherited := make(map[string]bool)
+	if samePartitionOf(sourceTable, desiredTable) {
+		for name := range df.clonedIndexes(df.sourceTables, sourceTable) {
+			inherited[name] = true
+		}
+		for name := range df.clonedIndexes(df.desiredTables, desiredTable) {
+			inherited[name] = true
+		}
+	}
+	return inherited
+}
+
+// samePartitionOf reports whether sourceTable and desiredTable are
+// partitions of the same parent, whatever their bounds.
+func samePartitionOf(sourceTable, desiredTable *Table) bool {
+	return sourceTable.PartitionOf != nil && desiredTable.PartitionOf != nil &&
+		sourceTable.PartitionOf.String() == desiredTable.PartitionOf.String()
+}
+
+// partitionParent returns the table in tables which table is a partition
+// of, or nil.
+func (tables Tables) partitionParent(table *Table) *Table {
+	if table.PartitionOf == nil {
+		return nil
+	}
+	return tables.FindTable(table.PartitionOf.String())
+}
+
+// clonedIndexes returns the names of the indexes of the partition table in
+// tables which match an index of its parent. PostgreSQL creates them along
+// with the partition or the index of the parent, and attaches them to it.
+func (df *Diff) clonedIndexes(tables Tables, table *Table) map[string]bool {
+	cloned := make(map[string]bool)
+	parent := tables.partitionParent(table)
+	if parent == nil {
+		return cloned
+	}
+	for name, index := range table.Indexes {
+		for _, parentIndex := range parent.Indexes {
+			if df.equalIndexDefinition(index, parentIndex) {
+				cloned[name] = true
+				break
+			}
+		}
+	}
+	return cloned
+}
+
+// clonedConstraints returns the names of the constraints of the partition
+// table in tables which match a constraint of its parent, and so are
+// created along with the partition.
+func (df *Diff) clonedConstraints(tables Tables, table *Table) map[string]bool {
+	cloned := make(map[string]bool)
+	parent := tables.partitionParent(table)
+	if parent == nil {
+		return cloned
+	}
+	for name, constraint := range table.TableConstraints {
+		for _, parentConstraint := range parent.TableConstraints {
+			if df.equalConstraintDefinition(constraint, parentConstraint) {
+				cloned[name] = true
+				break
+			}
+		}
+	}
+	return cloned
+}
+
+// diffInherits writes NO INHERIT for the parents sourceTable stops
+// inheriting from, or INHERIT for the ones desiredTable starts to.
+func (df *Diff) diffInherits(sourceTable, desiredTable *Table, no bool) {
+	from, to := sourceTable.Inherits, desiredTable.Inherits
+	keyword := "INHERIT"
+	if no {
+		from, to = to, from
+		keyword = "NO INHERIT"
+	}
+	kept := make(map[string]bool)
+	for _, name := range from {
+		kept[name.String()] = true
+	}
+	for _, name := range to {
+		if kept[name.String()] {
+			continue
+		}
+		df.WriteString(fmt.Sprintf("ALTER %s %s %s %s;\n",
+			sourceTable.keyword(), df.tableName(sourceTable),
+			keyword,
+			df.formatNode(name),
+		))
+	}
+}
@@ -0,0 +1,103 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// samePartition reports whether sourceTable and desiredTable are the same
+// partition of the same parent, or both no partition at all.
+func (df *Diff) samePartition(sourceTable, desiredTable *Table) bool {
+	if sourceTable.PartitionOf == nil || desiredTable.PartitionOf == nil {
+		return sourceTable.PartitionOf == nil && desiredTable.PartitionOf == nil
+	}
+	return sourceTable.PartitionOf.String() == desiredTable.PartitionOf.String() &&
+		df.formatForComparison(sourceTable.PartitionBound) == df.formatForComparison(desiredTable.PartitionBound)
+}
+
+// equalPartitionBy reports whether a and b partition a table alike. Either
+// may be nil for a table which is not partitioned.
+func (df *Diff) equalPartitionBy(a, b *ast.PartitionBy) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return df.formatForComparison(a) == df.formatForComparison(b)
+}
+
+// describePartitionBy formats partitionBy for an error message.
+func (df *Diff) describePartitionBy(partitionBy *ast.PartitionBy) string {
+	if partitionBy == nil {
+		return "no partitioning"
+	}
+	return df.formatNode(partitionBy)
+}
+
+// checkPartitionBy records an error when the partitioning of a table
+// changes, which PostgreSQL cannot do in place.
+func (df *Diff) checkPartitionBy(sourceTable, desiredTable *Table) bool {
+	if df.equalPartitionBy(sourceTable.PartitionBy, desiredTable.PartitionBy) {
+		return true
+	}
+	df.patchErrors = append(df.patchErrors, fmt.Errorf(
+		"cannot change the partition key of %s from %s to %s in place; the table must be recreated",
+		df.tableName(sourceTable),
+		df.describePartitionBy(sourceTable.PartitionBy),
+		df.describePartitionBy(desiredTable.PartitionBy),
+	))
+	return false
+}
+
+// detachPartitions detaches the partitions which move to another parent,
+// change bounds or stop being partitions. It runs before any table is
+// dropped, since dropping a parent drops its partitions.
+func (df *Diff) detachPartitions() {
+	for _, identifier := range df.sourceTables.SortedKeys() {
+		sourceTable := df.sourceTables[identifier]
+		desiredTable := df.desiredTables.FindTable(identifier)
+		if desiredTable == nil || sourceTable.PartitionOf == nil || df.samePartition(sourceTable, desiredTable) {
+			continue
+		}
+		df.writeTableAnnotation(sourceTable)
+		df.WriteString(fmt.Sprintf("ALTER TABLE %s DETACH PARTITION %s;\n",
+			df.formatNode(sourceTable.PartitionOf),
+			df.tableName(sourceTable),
+		))
+		df.WriteString("\n")
+	}
+}
+
+// attachPartitions attaches the existing tables which become partitions or
+// change bounds. It runs after new tables are created, since the parent may
+// be one of them.
+func (df *Diff) attachPartitions() {
+	for _, identifier := range df.desiredTables.sortedKeysParentsFirst(false) {
+		desiredTable := df.desiredTables[identifier]
+		sourceTable := df.sourceTables.FindTable(identifier)
+		if sourceTable == nil || desiredTable.PartitionOf == nil || df.samePartition(sourceTable, desiredTable) ||
+			recreatedForeign(sourceTable, desiredTable) {
+			continue
+		}
+		df.writeTableAnnotation(desiredTable)
+		df.attachPartition(desiredTable)
+		df.WriteString("\n")
+	}
+}
+
+func (df *Diff) attachPartition(table *Table) {
+	df.WriteString(fmt.Sprintf("ALTER TABLE %s ATTACH PARTITION %s %s;\n",
+		df.formatNode(table.PartitionOf),
+		df.tableName(table),
+		df.formatNode(table.PartitionBound),
+	))
+}
+
+// droppedWithParent reports whether sourceTable is a partition of a table
+// which is dropped, and so goes away along with it.
+func (df *Diff) droppedWithParent(sourceTable *Table) bool {
+	if sourceTable.PartitionOf == nil {
+		return false
+	}
+	parent := sourceTable.PartitionOf.String()
+	return df.sourceTables.FindTable(parent) != nil && df.desiredTables.FindTable(parent) == nil
+}
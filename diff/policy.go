@@ -0,0 +1,175 @@
+package diff
+
+import (
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// Policies maps the name of a row-level security policy to its definition.
+type Policies map[string]*ast.CreatePolicyStatement
+
+func (policies Policies) SortedKeys() (keys []string) {
+	for k := range policies {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return
+}
+
+// AddPolicy records a CREATE POLICY on the table it names.
+func (tables Tables) AddPolicy(searchPath SearchPath, createPolicyStatement *ast.CreatePolicyStatement) {
+	tables.qualify(searchPath, createPolicyStatement.Table)
+	table := tables.FindTable(createPolicyStatement.Table.String())
+	if table == nil {
+		log.Printf("irregular create policy to unknown table=%s", createPolicyStatement.Table)
+		return
+	}
+	if _, ok := table.Policies[createPolicyStatement.Name.Value]; ok {
+		log.Printf("irregular create policy to existing policy=%s", createPolicyStatement.Name.Value)
+		return
+	}
+	table.Policies[createPolicyStatement.Name.Value] = createPolicyStatement
+}
+
+// alterPolicy applies ALTER POLICY, which renames a policy or replaces the
+// clauses it gives.
+func (tables Tables) alterPolicy(searchPath SearchPath, alterPolicyStatement *ast.AlterPolicyStatement) {
+	tables.qualify(searchPath, alterPolicyStatement.Table)
+	table := tables.FindTable(alterPolicyStatement.Table.String())
+	if table == nil {
+		log.Printf("irregular alter policy to unknown table=%s", alterPolicyStatement.Table)
+		return
+	}
+	policy, ok := table.Policies[alterPolicyStatement.Name.Value]
+	if !ok {
+		log.Printf("irregular alter policy to unknown policy=%s", alterPolicyStatement.Name.Value)
+		return
+	}
+	altered := *policy
+	if alterPolicyStatement.NewName != nil {
+		delete(table.Policies, policy.Name.Value)
+		altered.Name = alterPolicyStatement.NewName
+	}
+	if alterPolicyStatement.Roles != nil {
+		altered.Roles = alterPolicyStatement.Roles
+	}
+	if alterPolicyStatement.Using != nil {
+		altered.Using = alterPolicyStatement.Using
+	}
+	if alterPolicyStatement.WithCheck != nil {
+		altered.WithCheck = alterPolicyStatement.WithCheck
+	}
+	table.Policies[altered.Name.Value] = &altered
+}
+
+// dropPolicy removes the policy name from the table it is on.
+func (tables Tables) dropPolicy(searchPath SearchPath, name string, tableName *ast.TableName) bool {
+	tables.qualify(searchPath, tableName)
+	table := tables.FindTable(tableName.String())
+	if table == nil {
+		return false
+	}
+	if _, ok := table.Policies[name]; !ok {
+		return false
+	}
+	delete(table.Policies, name)
+	return true
+}
+
+// normalizePolicy returns a copy of createPolicyStatement without what does
+// not change the policy: TO PUBLIC, which is the default.
+func normalizePolicy(createPolicyStatement *ast.CreatePolicyStatement) *ast.CreatePolicyStatement {
+	normalized := *createPolicyStatement
+	if len(normalized.Roles) == 1 && normalized.Roles[0].Keyword == "PUBLIC" {
+		normalized.Roles = nil
+	}
+	return &normalized
+}
+
+func (df *Diff) equalPolicy(a, b *ast.CreatePolicyStatement) bool {
+	return df.formatForComparison(normalizePolicy(a)) == df.formatForComparison(normalizePolicy(b))
+}
+
+// alterablePolicy reports whether ALTER POLICY can turn sourcePolicy into
+// desiredPolicy. It cannot change the command or the kind of a policy, nor
+// remove its USING or WITH CHECK expression.
+func alterablePolicy(sourcePolicy, desiredPolicy *ast.CreatePolicyStatement) bool {
+	return sourcePolicy.Restrictive == desiredPolicy.Restrictive &&
+		sourcePolicy.Command == desiredPolicy.Command &&
+		(sourcePolicy.Using == nil || desiredPolicy.Using != nil) &&
+		(sourcePolicy.WithCheck == nil || desiredPolicy.WithCheck != nil)
+}
+
+// diffPolicies writes the policy changes of a table. With drop set it drops
+// the policies which are removed or must be recreated, before the columns
+// they use can be dropped; otherwise it creates and alters policies, after
+// the columns they use have been added.
+func (df *Diff) diffPolicies(sourceTable, desiredTable *Table, drop bool) {
+	for _, name := range sourceTable.Policies.SortedKeys() {
+		sourcePolicy := sourceTable.Policies[name]
+		desiredPolicy, ok := desiredTable.Policies[name]
+		switch {
+		case ok && df.equalPolicy(sourcePolicy, desiredPolicy):
+		case ok && alterablePolicy(sourcePolicy, desiredPolicy):
+			if !drop {
+				df.alterPolicy(sourceTable, desiredPolicy)
+			}
+		case drop:
+			df.dropPolicy(sourceTable, sourcePolicy)
+		case ok:
+			df.createPolicy(sourceTable, desiredPolicy)
+		}
+	}
+	if drop {
+		return
+	}
+	for _, name := range desiredTable.Policies.SortedKeys() {
+		if _, ok := sourceTable.Policies[name]; !ok {
+			df.createPolicy(sourceTable, desiredTable.Policies[name])
+		}
+	}
+}
+
+func (df *Diff) createPolicy(table *Table, policy *ast.CreatePolicyStatement) {
+	createPolicyStatement := *policy
+	createPolicyStatement.Table = table.CreateTableStatement.TableName
+	df.writeNode(&createPolicyStatement)
+}
+
+// alterPolicy sets every clause of policy, giving TO PUBLIC for a policy
+// which names no roles.
+func (df *Diff) alterPolicy(table *Table, policy *ast.CreatePolicyStatement) {
+	alterPolicyStatement := &ast.AlterPolicyStatement{
+		Name:      policy.Name,
+		Table:     table.CreateTableStatement.TableName,
+		Roles:     policy.Roles,
+		Using:     policy.Using,
+		WithCheck: policy.WithCheck,
+	}
+	if len(alterPolicyStatement.Roles) == 0 {
+		alterPolicyStatement.Roles = []*ast.RoleSpecification{{Keyword: "PUBLIC"}}
+	}
+	df.writeNode(alterPolicyStatement)
+}
+
+func (df *Diff) dropPolicy(table *Table, policy *ast.CreatePolicyStatement) {
+	df.WriteString(fmt.Sprintf("DROP POLICY %s ON %s;\n", df.formatNode(policy.Name), df.tableName(table)))
+}
+
+// diffRowLevelSecurity writes the changes to whether row-level security is
+// enabled and forced on a table.
+func (df *Diff) diffRowLevelSecurity(sourceTable, desiredTable *Table) {
+	if sourceTable.RowLevelSecurity != desiredTable.RowLevelSecurity {
+		df.alterRowLevelSecurity(sourceTable, &ast.RowLevelSecurity{Enable: desiredTable.RowLevelSecurity})
+	}
+	if sourceTable.ForceRowLevelSecurity != desiredTable.ForceRowLevelSecurity {
+		df.alterRowLevelSecurity(sourceTable, &ast.RowLevelSecurity{Force: true, Enable: desiredTable.ForceRowLevelSecurity})
+	}
+}
+
+func (df *Diff) alterRowLevelSecurity(table *Table, rowLevelSecurity *ast.RowLevelSecurity) {
+	df.WriteString(fmt.Sprintf("ALTER TABLE %s %s;\n", df.tableName(table), df.formatNode(rowLevelSecurity)))
+}
@@ -0,0 +1,91 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// setReplicaIdentity applies REPLICA IDENTITY, modelling DEFAULT as nil.
+func (table *Table) setReplicaIdentity(replicaIdentity *ast.ReplicaIdentity) {
+	if replicaIdentity.Index == nil && replicaIdentity.Identity == "DEFAULT" {
+		table.ReplicaIdentity = nil
+		return
+	}
+	table.ReplicaIdentity = replicaIdentity
+}
+
+// hasIndex reports whether table has an index named name, either created on
+// its own or by a primary key or unique constraint.
+func (table *Table) hasIndex(name string) bool {
+	if _, ok := table.Indexes[name]; ok {
+		return true
+	}
+	constraint, ok := table.TableConstraints[name]
+	return ok && (constraint.Type == PrimaryKey || constraint.Type == Unique)
+}
+
+// renameIndexReference makes the replica identity and CLUSTER ON settings
+// of table follow the index name when it is renamed to newName, or forget
+// it when newName is "" because the index is dropped.
+func (table *Table) renameIndexReference(name, newName string) {
+	if table.ReplicaIdentity != nil && table.ReplicaIdentity.Index != nil && table.ReplicaIdentity.Index.Value == name {
+		if newName == "" {
+			table.ReplicaIdentity = nil
+		} else {
+			table.ReplicaIdentity = &ast.ReplicaIdentity{Index: newIdentifier(newName)}
+		}
+	}
+	if table.ClusterOn == name {
+		table.ClusterOn = newName
+	}
+}
+
+// recreatedIndex reports whether the index name of a table is dropped and
+// created again, which loses the settings referring to it.
+func (df *Diff) recreatedIndex(sourceTable, desiredTable *Table, name string) bool {
+	if sourceIndex, ok := sourceTable.Indexes[name]; ok {
+		desiredIndex, ok := desiredTable.Indexes[name]
+		return ok && !df.equalIndex(sourceIndex, desiredIndex)
+	}
+	if sourceConstraint, ok := sourceTable.TableConstraints[name]; ok {
+		desiredConstraint, ok := desiredTable.TableConstraints[name]
+		return ok && !df.equalTableConstraint(sourceConstraint, desiredConstraint)
+	}
+	return false
+}
+
+// diffIndexSettings writes the changes to the replica identity and the
+// CLUSTER ON index of a table. It runs after the indexes and constraints of
+// the table have been created, since both settings name an index, and sets
+// them again on an index which has been recreated.
+func (df *Diff) diffIndexSettings(sourceTable, desiredTable *Table) {
+	replicaIdentity := desiredTable.ReplicaIdentity
+	changed := replicaIdentity != nil && replicaIdentity.Index != nil &&
+		df.recreatedIndex(sourceTable, desiredTable, replicaIdentity.Index.Value)
+	if sourceTable.ReplicaIdentity == nil || replicaIdentity == nil {
+		changed = changed || sourceTable.ReplicaIdentity != replicaIdentity
+	} else {
+		changed = changed || df.formatForComparison(sourceTable.ReplicaIdentity) != df.formatForComparison(replicaIdentity)
+	}
+	if changed {
+		if replicaIdentity == nil {
+			replicaIdentity = &ast.ReplicaIdentity{Identity: "DEFAULT"}
+		}
+		df.WriteString(fmt.Sprintf("ALTER TABLE ONLY %s %s;\n", df.tableName(sourceTable), df.formatNode(replicaIdentity)))
+	}
+
+	// Dropping an index clears its CLUSTER mark, whereas a replica identity
+	// using a dropped index stays and acts as NOTHING.
+	clusterOn := desiredTable.ClusterOn
+	if sourceTable.ClusterOn != clusterOn || clusterOn != "" && df.recreatedIndex(sourceTable, desiredTable, clusterOn) {
+		if clusterOn == "" && !desiredTable.hasIndex(sourceTable.ClusterOn) {
+			return
+		}
+		if clusterOn == "" {
+			df.WriteString(fmt.Sprintf("ALTER TABLE %s SET WITHOUT CLUSTER;\n", df.tableName(sourceTable)))
+		} else {
+			df.WriteString(fmt.Sprintf("ALTER TABLE %s CLUSTER ON %s;\n", df.tableName(sourceTable), df.quote(clusterOn)))
+		}
+	}
+}
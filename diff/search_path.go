@@ -0,0 +1,187 @@
+package diff
+
+import (
+	"strings"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// SearchPath is the ordered list of schemas that unqualified names are
+// looked up in. Tables are created in the first schema on the path.
+type SearchPath []string
+
+// defaultSearchPath is PostgreSQL's default search_path. The schema named
+// after the user is assumed not to exist.
+var defaultSearchPath = SearchPath{"$user", "public"}
+
+// creationSchema returns the schema unqualified tables are created in. With
+// no usable schema on the path PostgreSQL refuses to create anything, so
+// "public" is assumed.
+func (searchPath SearchPath) creationSchema() string {
+	for _, schema := range searchPath {
+		if schema != "$user" && schema != "pg_catalog" {
+			return schema
+		}
+	}
+	return "public"
+}
+
+// searchPathState tracks search_path through a script, including SET LOCAL
+// which lasts until the end of the transaction.
+type searchPathState struct {
+	session       SearchPath
+	local         SearchPath
+	inTransaction bool
+	// sessionAtBegin is restored by ROLLBACK.
+	sessionAtBegin SearchPath
+}
+
+func newSearchPathState() *searchPathState {
+	return &searchPathState{session: defaultSearchPath}
+}
+
+// current returns the search_path in effect.
+func (state *searchPathState) current() SearchPath {
+	if state.local != nil {
+		return state.local
+	}
+	return state.session
+}
+
+func (state *searchPathState) set(searchPath SearchPath, local bool) {
+	if local {
+		// SET LOCAL outside a transaction has no effect.
+		if state.inTransaction {
+			state.local = searchPath
+		}
+		return
+	}
+	state.session = searchPath
+	state.local = nil
+}
+
+func (state *searchPathState) begin() {
+	state.inTransaction = true
+	state.sessionAtBegin = state.session
+}
+
+func (state *searchPathState) end(commit bool) {
+	if state.inTransaction && !commit {
+		state.session = state.sessionAtBegin
+	}
+	state.inTransaction = false
+	state.local = nil
+}
+
+// apply updates the state for a statement changing search_path: SET, RESET
+// or a SELECT calling set_config.
+func (state *searchPathState) apply(statement ast.Statement) {
+	switch stmt := statement.(type) {
+	case *ast.SetStatement:
+		if stmt.Name.Value != "search_path" {
+			return
+		}
+		if len(stmt.Values) == 0 {
+			state.set(defaultSearchPath, stmt.Local)
+			return
+		}
+		searchPath := SearchPath{}
+		for _, value := range stmt.Values {
+			switch v := value.(type) {
+			case *ast.Identifier:
+				searchPath = append(searchPath, v.Value)
+			case *ast.StringLiteral:
+				// '' is an empty search_path rather than a schema named "".
+				searchPath = append(searchPath, parseSearchPath(v.Token.Value)...)
+			}
+		}
+		state.set(searchPath, stmt.Local)
+	case *ast.ResetStatement:
+		if stmt.Name == nil || stmt.Name.Value == "search_path" {
+			state.set(defaultSearchPath, false)
+		}
+	case *ast.SelectStatement:
+		for _, target := range stmt.Targets {
+			if value, local, ok := setConfigSearchPath(target); ok {
+				state.set(parseSearchPath(value), local)
+			}
+		}
+	case *ast.TransactionStatement:
+		switch stmt.Command {
+		case "BEGIN":
+			state.begin()
+		case "COMMIT":
+			state.end(true)
+		case "ROLLBACK":
+			state.end(false)
+		}
+	}
+}
+
+// setConfigSearchPath recognizes set_config('search_path', value, is_local)
+// and returns its value and is_local.
+func setConfigSearchPath(expr ast.Expression) (value string, local bool, ok bool) {
+	call, isCall := expr.(*ast.CallExpression)
+	if !isCall || len(call.Arguments) != 3 {
+		return "", false, false
+	}
+	switch function := call.Function.(type) {
+	case *ast.Identifier:
+		if function.Value != "set_config" {
+			return "", false, false
+		}
+	case *ast.QualifiedName:
+		if len(function.Identifiers) != 2 ||
+			function.Identifiers[0].Value != "pg_catalog" || function.Identifiers[1].Value != "set_config" {
+			return "", false, false
+		}
+	default:
+		return "", false, false
+	}
+	name, isString := call.Arguments[0].(*ast.StringLiteral)
+	if !isString || name.Token.Value != "search_path" {
+		return "", false, false
+	}
+	newValue, isString := call.Arguments[1].(*ast.StringLiteral)
+	if !isString {
+		return "", false, false
+	}
+	isLocal, isBoolean := call.Arguments[2].(*ast.BooleanLiteral)
+	if !isBoolean {
+		return "", false, false
+	}
+	return newValue.Token.Value, isLocal.IsTrue(), true
+}
+
+// parseSearchPath splits a search_path setting such as `"$user", public`
+// into schema names, folding unquoted names to lower case.
+func parseSearchPath(value string) SearchPath {
+	searchPath := SearchPath{}
+	for i := 0; i < len(value); {
+		switch c := value[i]; {
+		case c == ' ' || c == '\t' || c == '\n' || c == ',':
+			i++
+		case c == '"':
+			var name strings.Builder
+			for i++; i < len(value); i++ {
+				if value[i] == '"' {
+					if i+1 < len(value) && value[i+1] == '"' {
+						i++
+					} else {
+						i++
+						break
+					}
+				}
+				name.WriteByte(value[i])
+			}
+			searchPath = append(searchPath, name.String())
+		default:
+			start := i
+			for i < len(value) && !strings.ContainsRune(" \t\n,", rune(value[i])) {
+				i++
+			}
+			searchPath = append(searchPath, strings.ToLower(value[start:i]))
+		}
+	}
+	return searchPath
+}
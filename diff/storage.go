@@ -0,0 +1,150 @@
+package diff
+
+import (
+	"fmt"
+
+	"github.com/ttakezawa/pgconverger/ast"
+)
+
+// tableDefaults tracks default_tablespace and default_table_access_method,
+// which pg_dump sets before CREATE TABLE instead of writing TABLESPACE and
+// USING.
+type tableDefaults struct {
+	tablespace   string
+	accessMethod string
+}
+
+// apply updates the defaults for a SET or RESET statement.
+func (defaults *tableDefaults) apply(statement ast.Statement) {
+	switch stmt := statement.(type) {
+	case *ast.SetStatement:
+		var value string
+		if len(stmt.Values) == 1 {
+			switch v := stmt.Values[0].(type) {
+			case *ast.Identifier:
+				value = v.Value
+			case *ast.StringLiteral:
+				value = v.Token.Value
+			}
+		}
+		switch stmt.Name.Value {
+		case "default_tablespace":
+			defaults.tablespace = value
+		case "default_table_access_method":
+			defaults.accessMethod = value
+		}
+	case *ast.ResetStatement:
+		if stmt.Name == nil || stmt.Name.Value == "default_tablespace" {
+			defaults.tablespace = ""
+		}
+		if stmt.Name == nil || stmt.Name.Value == "default_table_access_method" {
+			defaults.accessMethod = ""
+		}
+	}
+}
+
+// tablespaceOf returns the tablespace of table, naming the default one.
+func tablespaceOf(table *Table) string {
+	if table.Tablespace == "" {
+		return "pg_default"
+	}
+	return table.Tablespace
+}
+
+// accessMethodOf returns the access method of table, naming the default one.
+func accessMethodOf(table *Table) string {
+	if table.AccessMethod == "" {
+		return "heap"
+	}
+	return table.AccessMethod
+}
+
+// storageParameterKey identifies a storage parameter by its qualified name.
+func storageParameterKey(storageParameter *ast.StorageParameter) string {
+	if storageParameter.Namespace != nil {
+		return storageParameter.Namespace.Value + "." + storageParameter.Name.Value
+	}
+	return storageParameter.Name.Value
+}
+
+// setStorageParameters applies ALTER TABLE ... SET ( ... ) or RESET ( ... ).
+func (table *Table) setStorageParameters(setStorageParameters *ast.SetStorageParameters) {
+	changed := make(map[string]bool)
+	for _, storageParameter := range setStorageParameters.Parameters {
+		changed[storageParameterKey(storageParameter)] = true
+	}
+	var storageParameters ast.StorageParameters
+	for _, storageParameter := range table.StorageParameters {
+		if !changed[storageParameterKey(storageParameter)] {
+			storageParameters = append(storageParameters, storageParameter)
+		}
+	}
+	if !setStorageParameters.Reset {
+		storageParameters = append(storageParameters, setStorageParameters.Parameters...)
+	}
+	table.StorageParameters = storageParameters
+}
+
+// checkTemporary records an error when a table changes between temporary
+// and permanent, which PostgreSQL cannot do in place.
+func (df *Diff) checkTemporary(sourceTable, desiredTable *Table) bool {
+	if sourceTable.Temporary == desiredTable.Temporary {
+		return true
+	}
+	df.patchErrors = append(df.patchErrors, fmt.Errorf(
+		"cannot change %s between temporary and permanent in place; the table must be recreated",
+		df.tableName(sourceTable),
+	))
+	return false
+}
+
+// diffStorage writes the changes to the persistence, storage parameters,
+// tablespace and access method of a table.
+func (df *Diff) diffStorage(sourceTable, desiredTable *Table) {
+	if sourceTable.Unlogged != desiredTable.Unlogged {
+		if desiredTable.Unlogged {
+			df.WriteString(fmt.Sprintf("ALTER TABLE %s SET UNLOGGED;\n", df.tableName(sourceTable)))
+		} else {
+			df.WriteString(fmt.Sprintf("ALTER TABLE %s SET LOGGED;\n", df.tableName(sourceTable)))
+		}
+	}
+
+	sourceParameters := make(map[string]*ast.StorageParameter)
+	for _, storageParameter := range sourceTable.StorageParameters {
+		sourceParameters[storageParameterKey(storageParameter)] = storageParameter
+	}
+	desiredParameters := make(map[string]bool)
+	var set, reset ast.StorageParameters
+	for _, storageParameter := range desiredTable.StorageParameters {
+		key := storageParameterKey(storageParameter)
+		desiredParameters[key] = true
+		sourceParameter, ok := sourceParameters[key]
+		if !ok || sourceParameter.HasValue != storageParameter.HasValue || sourceParameter.Value != storageParameter.Value {
+			set = append(set, storageParameter)
+		}
+	}
+	for _, storageParameter := range sourceTable.StorageParameters {
+		if !desiredParameters[storageParameterKey(storageParameter)] {
+			reset = append(reset, &ast.StorageParameter{Namespace: storageParameter.Namespace, Name: storageParameter.Name})
+		}
+	}
+	if len(reset) > 0 {
+		df.WriteString(fmt.Sprintf("ALTER TABLE %s RESET %s;\n", df.tableName(sourceTable), df.formatNode(reset)))
+	}
+	if len(set) > 0 {
+		df.WriteString(fmt.Sprintf("ALTER TABLE %s SET %s;\n", df.tableName(sourceTable), df.formatNode(set)))
+	}
+
+	if tablespaceOf(sourceTable) != tablespaceOf(desiredTable) {
+		df.WriteString(fmt.Sprintf("ALTER TABLE %s SET TABLESPACE %s;\n",
+			df.tableName(sourceTable),
+			df.quote(tablespaceOf(desiredTable)),
+		))
+	}
+	if accessMethodOf(sourceTable) != accessMethodOf(desiredTable) {
+		df.WriteString(fmt.Sprintf("ALTER TABLE %s SET ACCESS METHOD %s;\n",
+			df.tableName(sourceTable),
+			df.quote(accessMethodOf(desiredTable)),
+		))
+	}
+}
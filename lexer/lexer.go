@@ -1,6 +1,11 @@
 package lexer
 
 import (
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
 	"unicode"
 	"unicode/utf8"
 
@@ -22,17 +27,26 @@ type Lexer struct {
 	startPosition int
 	startLine     int
 
+	// The statement being lexed is followed to find COPY ... FROM STDIN,
+	// whose data lines are not SQL.
+	statementStart bool
+	inCopy         bool
+	fromStdin      bool
+	copyData       bool
+	previousValue  string
+
 	tokens chan token.Token
 	state  stateFn
 }
 
 func newLexer(inputName, input string) *Lexer {
 	return &Lexer{
-		inputName: inputName,
-		input:     input,
-		tokens:    make(chan token.Token),
-		line:      1,
-		startLine: 1,
+		inputName:      inputName,
+		input:          input,
+		tokens:         make(chan token.Token),
+		line:           1,
+		startLine:      1,
+		statementStart: true,
 	}
 }
 
@@ -90,21 +104,55 @@ func (l *Lexer) word() string {
 	return l.input[i:j]
 }
 
+// rest returns the input which has not been consumed yet, starting with the
+// current char.
+func (l *Lexer) rest() string {
+	if l.position >= len(l.input) {
+		return ""
+	}
+	return l.input[l.position:]
+}
+
 func (l *Lexer) emit(typ token.TokenType) {
+	l.emitValue(typ, "")
+}
+
+func (l *Lexer) emitValue(typ token.TokenType, value string) {
 	switch typ {
-	case token.Space, token.CommentLine, token.CommentBlock:
-		// ignore Space and Comment
+	case token.Space, token.CommentLine, token.CommentBlock, token.CopyData:
+		// ignore Space, Comment and the data of COPY
 	default:
 		l.tokens <- token.Token{
 			Type:    typ,
 			Literal: l.word(),
 			Line:    l.startLine,
+			Value:   value,
 		}
+		l.followStatement(typ, value)
 	}
 	l.startPosition = l.position
 	l.startLine = l.line
 }
 
+// followStatement notes whether the statement is a COPY ... FROM STDIN, so
+// that its data is skipped after the semicolon.
+func (l *Lexer) followStatement(typ token.TokenType, value string) {
+	switch {
+	case typ == token.MetaCommand:
+		// A meta-command is not part of the statement.
+		return
+	case typ == token.Semicolon:
+		l.copyData = l.inCopy && l.fromStdin
+		l.statementStart, l.inCopy, l.fromStdin = true, false, false
+	case l.statementStart:
+		l.statementStart = false
+		l.inCopy = typ == token.Copy
+	case l.inCopy && value == "stdin" && l.previousValue == "from":
+		l.fromStdin = true
+	}
+	l.previousValue = value
+}
+
 func (l *Lexer) NextToken() token.Token {
 	return <-l.tokens
 }
@@ -117,8 +165,12 @@ func lexFn(l *Lexer) stateFn {
 		return lexCommentLine
 	case l.char == '/' && l.peekChar() == '*':
 		return lexCommentBlock
-	case l.char == '"':
+	case l.char == '\\':
+		return lexMetaCommand
+	case l.char == '"' || isQuotedIdentifierStart(l.rest()):
 		return lexDoubleQuoteIdentifier
+	case isStringStart(l.rest()):
+		return lexString
 	case isIdentifierStart(l.char):
 		return lexIdentifier
 	case isNumberStart(l.char, l.peekChar()):
@@ -127,21 +179,14 @@ func lexFn(l *Lexer) stateFn {
 		return lexString
 	case l.char == ':':
 		return lexTypecast
-	case l.char == '=':
-		l.advance()
-		l.emit(token.Equal)
-	case l.char == '+':
-		l.advance()
-		l.emit(token.Plus)
-	case l.char == '*':
-		l.advance()
-		l.emit(token.Asterisk)
-	case l.char == '/':
-		l.advance()
-		l.emit(token.Slash)
+	case isOperatorChar(l.char):
+		return lexOperator
 	case l.char == ';':
 		l.advance()
 		l.emit(token.Semicolon)
+		if l.copyData {
+			return lexCopyData
+		}
 	case l.char == ',':
 		l.advance()
 		l.emit(token.Comma)
@@ -169,6 +214,44 @@ func lexFn(l *Lexer) stateFn {
 	return lexFn
 }
 
+// lexCopyData skips the lines following COPY ... FROM STDIN up to the line
+// holding only `\.`, as psql sends them to the server as data.
+func lexCopyData(l *Lexer) stateFn {
+	l.copyData = false
+	// The data starts on the line after the COPY statement.
+	for l.char != '\n' && l.char != eof {
+		l.advance()
+	}
+	for l.char != eof {
+		l.advance()
+		lineStart := l.position
+		for l.char != '\n' && l.char != eof {
+			l.advance()
+		}
+		if line := l.input[lineStart:l.position]; strings.TrimSuffix(line, "\r") == `\.` {
+			break
+		}
+	}
+	l.emit(token.CopyData)
+	return lexFn
+}
+
+// lexMetaCommand lexes a psql meta-command such as `\i file.sql` up to the
+// end of the line. The value is the name of the command, such as "i".
+func lexMetaCommand(l *Lexer) stateFn {
+	l.advance()
+	nameStart := l.position
+	for l.char != eof && !isSpace(l.char) {
+		l.advance()
+	}
+	name := l.input[nameStart:l.position]
+	for l.char != '\n' && l.char != eof {
+		l.advance()
+	}
+	l.emitValue(token.MetaCommand, name)
+	return lexFn
+}
+
 func lexEOF(l *Lexer) stateFn {
 	l.emit(token.EOF)
 	return nil
@@ -193,7 +276,16 @@ func isSpace(r rune) bool {
 }
 
 // "foobar"
+// "a""b" => a"b
+// U&"d\0061t\+000061" [ UESCAPE '\' ] => data
 func lexDoubleQuoteIdentifier(l *Lexer) stateFn {
+	unicodeEscapes := l.char != '"'
+	if unicodeEscapes {
+		l.advance()
+		l.advance()
+	}
+
+	var content strings.Builder
 	l.advance()
 Loop:
 	for {
@@ -202,29 +294,76 @@ Loop:
 			return lexIllegal
 		case '"':
 			l.advance()
-			break Loop
-
+			if l.char != '"' {
+				break Loop
+			}
+			// doubled quote.
 		}
+		content.WriteRune(l.char)
 		l.advance()
 	}
-	l.emit(token.Identifier)
+
+	value, ok := content.String(), true
+	if unicodeEscapes {
+		value, ok = decodeUnicodeString(value, l.lexUescape())
+	}
+	if !ok || value == "" {
+		// zero-length delimited identifier.
+		l.emit(token.Illegal)
+		return lexFn
+	}
+	l.emitValue(token.Identifier, truncateIdentifier(value))
 	return lexFn
 }
 
+func isQuotedIdentifierStart(s string) bool {
+	return len(s) > 2 && (s[0] == 'U' || s[0] == 'u') && s[1] == '&' && s[2] == '"'
+}
+
 // ident_start		[A-Za-z\200-\377_]
 // ident_cont		[A-Za-z\200-\377_0-9\$]
 // identifier		{ident_start}{ident_cont}*
+//
+// Unquoted identifiers are folded to lower case.
 func lexIdentifier(l *Lexer) stateFn {
 	l.advance()
 	for isIdentifierCont(l.char) {
 		l.advance()
 	}
-	l.emit(token.LookupIdent(l.word()))
+	word := l.word()
+	l.emitValue(token.LookupIdent(word), truncateIdentifier(downcaseIdentifier(word)))
 	return lexFn
 }
 
+// downcaseIdentifier folds ASCII letters to lower case as PostgreSQL does;
+// other characters are left alone.
+func downcaseIdentifier(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return r
+	}, s)
+}
+
+// NAMEDATALEN - 1
+const maxIdentifierLength = 63
+
+// truncateIdentifier truncates s to maxIdentifierLength bytes without
+// splitting a character.
+func truncateIdentifier(s string) string {
+	n := maxIdentifierLength
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
 func isIdentifierStart(r rune) bool {
-	return unicode.IsLetter(r) || r == '_' || r == '\\'
+	return unicode.IsLetter(r) || r == '_'
 }
 
 func isIdentifierCont(r rune) bool {
@@ -232,83 +371,386 @@ func isIdentifierCont(r rune) bool {
 }
 
 // 'Dianne''s horse' => "Dianne's horse"
-// Not implemented: 'foo'\n'bar' => 'foobar'
+// 'C:\' => "C:\" (standard_conforming_strings is on, as pg_dump sets it)
+// 'foo'\n'bar' => "foobar"
+// E'foo\n' (String Constants With C-Style Escapes)
+// U&'d\0061t\+000061' [ UESCAPE '\' ] (String Constants With Unicode Escapes)
+// B'1001', X'1FF' (Bit-String Constants)
+// N'foo' (national character)
 // Not implemented: $$Dianne's horse$$
 // Not implemented: $SomeTag$Dianne's horse$SomeTag$
-// Not implemented: E'foo' (String Constants With C-Style Escapes)
-// Not implemented: U&'d\0061t\+000061' (String Constants With Unicode Escapes)
 func lexString(l *Lexer) stateFn {
+	var prefix []rune
+	for l.char != '\'' {
+		prefix = append(prefix, unicode.ToUpper(l.char))
+		l.advance()
+	}
+	kind := string(prefix)
+
+	var raw strings.Builder
+	for {
+		segment, ok := lexQuoted(l, kind == "E")
+		if !ok {
+			return lexIllegal
+		}
+		raw.WriteString(segment)
+		if !l.skipQuoteContinuation() {
+			break
+		}
+	}
+
+	var (
+		value string
+		ok    bool
+	)
+	switch kind {
+	case "", "N":
+		value, ok = raw.String(), true
+	case "E":
+		value, ok = decodeEscapeString(raw.String())
+	case "U&":
+		value, ok = decodeUnicodeString(raw.String(), l.lexUescape())
+	case "B":
+		value, ok = decodeBitString(raw.String(), 2)
+	case "X":
+		value, ok = decodeBitString(raw.String(), 16)
+	}
+	if !ok {
+		l.emit(token.Illegal)
+		return lexFn
+	}
+	l.emitValue(token.String, value)
+	return lexFn
+}
+
+func isStringStart(s string) bool {
+	if len(s) > 1 && strings.ContainsRune("EeBbXxNn", rune(s[0])) {
+		s = s[1:]
+	} else if len(s) > 2 && (s[0] == 'U' || s[0] == 'u') && s[1] == '&' {
+		s = s[2:]
+	}
+	return strings.HasPrefix(s, "'")
+}
+
+// lexQuoted consumes one quoted segment starting at the opening quote and
+// returns its content with doubled quotes undoubled. When backslash is set,
+// a backslash escapes the following char, which is kept for decoding.
+func lexQuoted(l *Lexer, backslash bool) (string, bool) {
+	var content strings.Builder
 	l.advance()
-Loop:
 	for {
 		switch l.char {
 		case eof:
-			return lexIllegal
+			return "", false
 		case '\'':
-			if l.peekChar() == '\'' {
-				// doubled quote.
-				l.advance()
-				l.advance()
-				continue Loop
-			}
 			l.advance()
-			break Loop
+			if l.char != '\'' {
+				return content.String(), true
+			}
+			// doubled quote.
+			content.WriteRune('\'')
 		case '\\':
-			if l.peekChar() == '\'' {
-				// escapted quote.
+			content.WriteRune(l.char)
+			if backslash && l.peekChar() != eof {
 				l.advance()
+				content.WriteRune(l.char)
 			}
-			l.advance()
-			continue Loop
+		default:
+			content.WriteRune(l.char)
 		}
 		l.advance()
 	}
-	l.emit(token.String)
-	return lexFn
+}
+
+// skipQuoteContinuation consumes whitespace containing a newline followed by
+// a quote, which continues the preceding string constant.
+func (l *Lexer) skipQuoteContinuation() bool {
+	rest := l.rest()
+	i, newline := 0, false
+	for i < len(rest) {
+		switch {
+		case rest[i] == '\n':
+			newline = true
+			i++
+		case isSpace(rune(rest[i])) || rest[i] == '\f':
+			i++
+		case strings.HasPrefix(rest[i:], "--"):
+			for i < len(rest) && rest[i] != '\n' {
+				i++
+			}
+		default:
+			if !newline || rest[i] != '\'' {
+				return false
+			}
+			for j := 0; j < i; j++ {
+				l.advance()
+			}
+			return true
+		}
+	}
+	return false
+}
+
+var uescapePattern = regexp.MustCompile(`^\s*(?i:uescape)\s*'([^'])'`)
+
+// lexUescape consumes an optional UESCAPE 'c' clause and returns the escape
+// character of a U& string.
+func (l *Lexer) lexUescape() rune {
+	m := uescapePattern.FindStringSubmatch(l.rest())
+	if m == nil {
+		return '\\'
+	}
+	for range m[0] {
+		l.advance()
+	}
+	r, _ := utf8.DecodeRuneInString(m[1])
+	return r
+}
+
+// decodeEscapeString decodes the content of E'...'.
+func decodeEscapeString(s string) (string, bool) {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] != '\\' || i+1 == len(s) {
+			b.WriteByte(s[i])
+			continue
+		}
+		i++
+		switch c := s[i]; {
+		case c == 'b':
+			b.WriteByte('\b')
+		case c == 'f':
+			b.WriteByte('\f')
+		case c == 'n':
+			b.WriteByte('\n')
+		case c == 'r':
+			b.WriteByte('\r')
+		case c == 't':
+			b.WriteByte('\t')
+		case c >= '0' && c <= '7':
+			n := digitsIn(s[i:], 3, 8)
+			v, _ := strconv.ParseUint(s[i:i+n], 8, 8)
+			b.WriteByte(byte(v))
+			i += n - 1
+		case c == 'x' && digitsIn(s[i+1:], 2, 16) > 0:
+			n := digitsIn(s[i+1:], 2, 16)
+			v, _ := strconv.ParseUint(s[i+1:i+1+n], 16, 8)
+			b.WriteByte(byte(v))
+			i += n
+		case c == 'u' || c == 'U':
+			n := 4
+			if c == 'U' {
+				n = 8
+			}
+			if digitsIn(s[i+1:], n, 16) != n {
+				return "", false
+			}
+			v, _ := strconv.ParseUint(s[i+1:i+1+n], 16, 32)
+			b.WriteRune(rune(v))
+			i += n
+		default:
+			b.WriteByte(c)
+		}
+	}
+	return b.String(), true
+}
+
+// decodeUnicodeString decodes the content of U&'...' whose escape character
+// is escape.
+func decodeUnicodeString(s string, escape rune) (string, bool) {
+	var b strings.Builder
+	runes := []rune(s)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != escape {
+			b.WriteRune(runes[i])
+			continue
+		}
+		rest := string(runes[i+1:])
+		switch {
+		case strings.HasPrefix(rest, string(escape)):
+			b.WriteRune(escape)
+			i++
+		case strings.HasPrefix(rest, "+") && digitsIn(rest[1:], 6, 16) == 6:
+			v, _ := strconv.ParseUint(rest[1:7], 16, 32)
+			b.WriteRune(rune(v))
+			i += 7
+		case digitsIn(rest, 4, 16) == 4:
+			v, _ := strconv.ParseUint(rest[:4], 16, 32)
+			b.WriteRune(rune(v))
+			i += 4
+		default:
+			return "", false
+		}
+	}
+	return b.String(), true
+}
+
+// decodeBitString decodes the content of B'...' (base 2) or X'...' (base 16)
+// into a string of binary digits.
+func decodeBitString(s string, base int) (string, bool) {
+	if digitsIn(s, len(s), base) != len(s) {
+		return "", false
+	}
+	if base == 2 {
+		return s, true
+	}
+	var b strings.Builder
+	for _, c := range s {
+		v, _ := strconv.ParseUint(string(c), 16, 8)
+		b.WriteString(fmt.Sprintf("%04b", v))
+	}
+	return b.String(), true
+}
+
+// digitsIn counts the leading digits of s in base, up to max.
+func digitsIn(s string, max, base int) int {
+	n := 0
+	for n < len(s) && n < max {
+		if _, err := strconv.ParseUint(s[n:n+1], base, 8); err != nil {
+			break
+		}
+		n++
+	}
+	return n
 }
 
 // 42
 // 3.5
 // 4.
 // .001
-// Not implemented: 5e2
-// Not implemented: 1.925e-3
+// 5e2
+// 1.925e-3
+// 1_000_000
+// 0x1F, 0o17, 0b101
 func lexNumber(l *Lexer) stateFn {
-Loop:
-	for {
+	if l.char == '0' {
+		switch l.peekChar() {
+		case 'x', 'X':
+			return lexNonDecimalInteger(l, 16)
+		case 'o', 'O':
+			return lexNonDecimalInteger(l, 8)
+		case 'b', 'B':
+			return lexNonDecimalInteger(l, 2)
+		}
+	}
+
+	l.skipDigits(10)
+	if l.char == '.' && l.peekChar() != '.' {
 		l.advance()
-		switch {
-		case unicode.IsDigit(l.char):
-			continue
-		case l.char == '.':
-			return lexMantissa
-		default:
-			break Loop
+		l.skipDigits(10)
+	}
+	if l.char == 'e' || l.char == 'E' {
+		rest := l.rest()[1:]
+		if rest != "" && (rest[0] == '+' || rest[0] == '-') {
+			rest = rest[1:]
+		}
+		if rest != "" && isDigit(rune(rest[0]), 10) {
+			l.advance()
+			if l.char == '+' || l.char == '-' {
+				l.advance()
+			}
+			l.skipDigits(10)
 		}
 	}
-	l.emit(token.Number)
+	l.emitValue(token.Number, normalizeNumber(l.word()))
 	return lexFn
 }
 
-func lexMantissa(l *Lexer) stateFn {
-Loop:
-	for {
-		l.advance()
-		switch {
-		case unicode.IsDigit(l.char):
-			continue
-		default:
-			break Loop
-		}
+// 0x1F, 0o17, 0b101
+func lexNonDecimalInteger(l *Lexer, base int) stateFn {
+	l.advance()
+	l.advance()
+	if !isDigit(l.char, base) && !(l.char == '_' && isDigit(l.peekChar(), base)) {
+		l.emit(token.Illegal)
+		return lexFn
 	}
-	l.emit(token.Number)
+	l.skipDigits(base)
+	l.emitValue(token.Number, normalizeNumber(l.word()))
 	return lexFn
 }
 
+// skipDigits consumes digits of base, which may be separated by single
+// underscores.
+func (l *Lexer) skipDigits(base int) {
+	for isDigit(l.char, base) || (l.char == '_' && isDigit(l.peekChar(), base)) {
+		l.advance()
+	}
+}
+
+func isDigit(r rune, base int) bool {
+	switch {
+	case r >= '0' && r <= '9':
+		return int(r-'0') < base
+	case r >= 'a' && r <= 'f':
+		return base == 16
+	case r >= 'A' && r <= 'F':
+		return base == 16
+	default:
+		return false
+	}
+}
+
 func isNumberStart(r rune, peek rune) bool {
 	return unicode.IsDigit(r) || (r == '.' && unicode.IsDigit(peek))
 }
 
+// normalizeNumber converts a numeric literal into the decimal notation
+// PostgreSQL uses to print a numeric, keeping its scale:
+// 0x1F => 31, 1_000 => 1000, 5e2 => 500, 1.925e-3 => 0.001925, .50 => 0.50
+func normalizeNumber(literal string) string {
+	literal = strings.Replace(literal, "_", "", -1)
+	if len(literal) > 2 && literal[0] == '0' {
+		base := 0
+		switch literal[1] {
+		case 'x', 'X':
+			base = 16
+		case 'o', 'O':
+			base = 8
+		case 'b', 'B':
+			base = 2
+		}
+		if base != 0 {
+			n, ok := new(big.Int).SetString(literal[2:], base)
+			if !ok {
+				return literal
+			}
+			return n.String()
+		}
+	}
+
+	mantissa, exponent := literal, 0
+	if i := strings.IndexAny(literal, "eE"); i >= 0 {
+		e, err := strconv.Atoi(literal[i+1:])
+		if err != nil {
+			return literal
+		}
+		mantissa, exponent = literal[:i], e
+	}
+	integer, fraction := mantissa, ""
+	if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+		integer, fraction = mantissa[:i], mantissa[i+1:]
+	}
+
+	digits := integer + fraction
+	scale := len(fraction) - exponent
+	if scale < 0 {
+		digits += strings.Repeat("0", -scale)
+		scale = 0
+	}
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	integer, fraction = digits[:len(digits)-scale], digits[len(digits)-scale:]
+	integer = strings.TrimLeft(integer, "0")
+	if integer == "" {
+		integer = "0"
+	}
+	if fraction == "" {
+		return integer
+	}
+	return integer + "." + fraction
+}
+
 // -- This is a standard SQL comment
 func lexCommentLine(l *Lexer) stateFn {
 	l.advance()
@@ -357,13 +799,74 @@ Loop:
 	return lexFn
 }
 
+// :: or : (array slice)
 func lexTypecast(l *Lexer) stateFn {
 	l.advance()
 	if l.char != ':' {
-		l.advance()
-		return lexIllegal
+		l.emit(token.Colon)
+		return lexFn
 	}
 	l.advance()
 	l.emit(token.Typecast)
 	return lexFn
 }
+
+// + - * / < > = ~ ! @ # % ^ & | ` ? and any sequence of them.
+//
+// As in PostgreSQL, an operator stops before "--" or "/*", and a multiple
+// character operator cannot end in "+" or "-" unless it also contains one of
+// ~ ! @ # % ^ & | ` ?, so that "a=-1" is read as "a = -1".
+func lexOperator(l *Lexer) stateFn {
+	text := l.input[l.position:]
+	n := 0
+	for n < len(text) && isOperatorChar(rune(text[n])) {
+		if n > 0 && (strings.HasPrefix(text[n:], "--") || strings.HasPrefix(text[n:], "/*")) {
+			break
+		}
+		n++
+	}
+	if n > 1 && (text[n-1] == '+' || text[n-1] == '-') && !strings.ContainsAny(text[:n], "~!@#^&|`?%") {
+		for n > 1 && (text[n-1] == '+' || text[n-1] == '-') {
+			n--
+		}
+	}
+	for i := 0; i < n; i++ {
+		l.advance()
+	}
+
+	switch op := text[:n]; op {
+	case "+":
+		l.emit(token.Plus)
+	case "-":
+		l.emit(token.Minus)
+	case "*":
+		l.emit(token.Asterisk)
+	case "/":
+		l.emit(token.Slash)
+	case "%":
+		l.emit(token.Percent)
+	case "^":
+		l.emit(token.Caret)
+	case "<":
+		l.emit(token.LessThan)
+	case ">":
+		l.emit(token.GreaterThan)
+	case "=":
+		l.emit(token.Equal)
+	case "<=":
+		l.emit(token.LessEquals)
+	case ">=":
+		l.emit(token.GreaterEquals)
+	case "<>", "!=":
+		l.emit(token.NotEquals)
+	case "=>":
+		l.emit(token.EqualsGreater)
+	default:
+		l.emit(token.Op)
+	}
+	return lexFn
+}
+
+func isOperatorChar(r rune) bool {
+	return r < utf8.RuneSelf && strings.ContainsRune("~!@#^&|`?+-*/%<>=", r)
+}
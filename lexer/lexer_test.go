@@ -2,6 +2,7 @@ package lexer
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/ttakezawa/pgconverger/token"
@@ -64,7 +65,7 @@ func Test_lexer_NextToken(t *testing.T) {
 		{
 			input: `abc
 x f1 abc$2 "foobar"
-'aaaa''bbbb' 'a\'b' 'ab
+'aaaa''bbbb' E'a\'b' 'ab
 cd'
 1.5 .82
 text[]
@@ -79,7 +80,7 @@ yyy */
 				{token.Identifier, "abc$2", 2},
 				{token.Identifier, `"foobar"`, 2},
 				{token.String, "'aaaa''bbbb'", 3},
-				{token.String, "'a\\'b'", 3},
+				{token.String, "E'a\\'b'", 3},
 				{token.String, "'ab\ncd'", 3},
 				{token.Number, "1.5", 5},
 				{token.Number, ".82", 5},
@@ -89,12 +90,115 @@ yyy */
 				{token.EOF, "", 10},
 			},
 		},
+		{
+			input: `a<>b != c <= d >= e < f > g || h - i % j ^ k[1:2]`,
+			wants: []want{
+				{token.Identifier, "a", 1},
+				{token.NotEquals, "<>", 1},
+				{token.Identifier, "b", 1},
+				{token.NotEquals, "!=", 1},
+				{token.Identifier, "c", 1},
+				{token.LessEquals, "<=", 1},
+				{token.Identifier, "d", 1},
+				{token.GreaterEquals, ">=", 1},
+				{token.Identifier, "e", 1},
+				{token.LessThan, "<", 1},
+				{token.Identifier, "f", 1},
+				{token.GreaterThan, ">", 1},
+				{token.Identifier, "g", 1},
+				{token.Op, "||", 1},
+				{token.Identifier, "h", 1},
+				{token.Minus, "-", 1},
+				{token.Identifier, "i", 1},
+				{token.Percent, "%", 1},
+				{token.Identifier, "j", 1},
+				{token.Caret, "^", 1},
+				{token.Identifier, "k", 1},
+				{token.LBracket, "[", 1},
+				{token.Number, "1", 1},
+				{token.Colon, ":", 1},
+				{token.Number, "2", 1},
+				{token.RBracket, "]", 1},
+				{token.EOF, "", 1},
+			},
+		},
+		{
+			input: `a=-1 b@-1 c*-+2 d->>'k' e@>f g&&h i!~*j k=>1 l<-- m
+n/*x*/`,
+			wants: []want{
+				{token.Identifier, "a", 1},
+				{token.Equal, "=", 1},
+				{token.Minus, "-", 1},
+				{token.Number, "1", 1},
+				{token.Identifier, "b", 1},
+				{token.Op, "@-", 1},
+				{token.Number, "1", 1},
+				{token.Identifier, "c", 1},
+				{token.Asterisk, "*", 1},
+				{token.Minus, "-", 1},
+				{token.Plus, "+", 1},
+				{token.Number, "2", 1},
+				{token.Identifier, "d", 1},
+				{token.Op, "->>", 1},
+				{token.String, "'k'", 1},
+				{token.Identifier, "e", 1},
+				{token.Op, "@>", 1},
+				{token.Identifier, "f", 1},
+				{token.Identifier, "g", 1},
+				{token.Op, "&&", 1},
+				{token.Identifier, "h", 1},
+				{token.Identifier, "i", 1},
+				{token.Op, "!~*", 1},
+				{token.Identifier, "j", 1},
+				{token.Identifier, "k", 1},
+				{token.EqualsGreater, "=>", 1},
+				{token.Number, "1", 1},
+				{token.Identifier, "l", 1},
+				{token.LessThan, "<", 1},
+				{token.Identifier, "n", 2},
+				{token.EOF, "", 2},
+			},
+		},
 		{
 			input: `'a`,
 			wants: []want{
 				{token.Illegal, "'a", 1},
 			},
 		},
+		{
+			input: "COPY public.users (id, name) FROM stdin;\n1\t'it''s\n2\t\\N\n\\.\r\nSELECT 1;",
+			wants: []want{
+				{token.Copy, "COPY", 1},
+				{token.Identifier, "public", 1},
+				{token.Dot, ".", 1},
+				{token.Identifier, "users", 1},
+				{token.LParen, "(", 1},
+				{token.Identifier, "id", 1},
+				{token.Comma, ",", 1},
+				{token.Keyword, "name", 1},
+				{token.RParen, ")", 1},
+				{token.From, "FROM", 1},
+				{token.Keyword, "stdin", 1},
+				{token.Semicolon, ";", 1},
+				{token.Select, "SELECT", 5},
+				{token.Number, "1", 5},
+				{token.Semicolon, ";", 5},
+				{token.EOF, "", 5},
+			},
+		},
+		{
+			input: "COPY users TO stdout;\n1;",
+			wants: []want{
+				{token.Copy, "COPY", 1},
+				{token.Identifier, "users", 1},
+				{token.To, "TO", 1},
+				{token.Keyword, "stdout", 1},
+				{token.Semicolon, ";", 1},
+				{token.Number, "1", 2},
+				{token.Semicolon, ";", 2},
+				{token.EOF, "", 2},
+			},
+		},
 		{
 			input: `
 CREATE TABLE "users" (
@@ -123,7 +227,7 @@ ALTER TABLE ONLY "users" ALTER COLUMN "id" SET DEFAULT "nextval"('"users_id_seq"
 				{token.Not, "NOT", 3},
 				{token.Null, "NULL", 3},
 				{token.Comma, ",", 3},
-				{token.Identifier, "name", 4},
+				{token.Keyword, "name", 4},
 				{token.Character, "character", 4},
 				{token.Varying, "varying", 4},
 				{token.LParen, "(", 4},
@@ -195,3 +299,114 @@ ALTER TABLE ONLY "users" ALTER COLUMN "id" SET DEFAULT "nextval"('"users_id_seq"
 		}
 	}
 }
+
+func Test_lexer_IdentifierValue(t *testing.T) {
+	long := strings.Repeat("x", 70)
+	tests := []struct {
+		input string
+		typ   token.TokenType
+		value string
+	}{
+		{`Users`, token.Identifier, `users`},
+		{`"Users"`, token.Identifier, `Users`},
+		{`"a""b"`, token.Identifier, `a"b`},
+		{`"a b"`, token.Identifier, `a b`},
+		{`TABLE`, token.Table, `table`},
+		{`Überblick`, token.Identifier, `Überblick`},
+		{`U&"d\0061t\+000061"`, token.Identifier, `data`},
+		{`u&"d!0061t!+000061" UESCAPE '!'`, token.Identifier, `data`},
+		{long, token.Identifier, long[:63]},
+		{`"` + long + `"`, token.Identifier, long[:63]},
+		{`"` + strings.Repeat("あ", 22) + `"`, token.Identifier, strings.Repeat("あ", 21)},
+		{`""`, token.Illegal, ``},
+	}
+	for i, tt := range tests {
+		l := Lex("<input>", tt.input)
+		got := l.NextToken()
+		if got.Type != tt.typ {
+			t.Errorf("case%d Lexer.NextToken().typ = %v, want %v", i+1, got.Type, tt.typ)
+		}
+		if got.Literal != tt.input {
+			t.Errorf("case%d Lexer.NextToken().literal = %q, want %q", i+1, got.Literal, tt.input)
+		}
+		if got.Value != tt.value {
+			t.Errorf("case%d Lexer.NextToken().value = %q, want %q", i+1, got.Value, tt.value)
+		}
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Errorf("case%d Lexer.NextToken().typ = %v, want %v", i+1, next.Type, token.EOF)
+		}
+	}
+}
+
+func Test_lexer_NumberValue(t *testing.T) {
+	tests := []struct {
+		input string
+		value string
+	}{
+		{`42`, `42`},
+		{`007`, `7`},
+		{`3.50`, `3.50`},
+		{`4.`, `4`},
+		{`.001`, `0.001`},
+		{`5e2`, `500`},
+		{`1.5E+1`, `15`},
+		{`1.925e-3`, `0.001925`},
+		{`1_000_000`, `1000000`},
+		{`0x1F`, `31`},
+		{`0o17`, `15`},
+		{`0b101`, `5`},
+		{`0xFFFF_FFFF_FFFF_FFFF_FF`, `4722366482869645213695`},
+	}
+	for i, tt := range tests {
+		l := Lex("<input>", tt.input)
+		got := l.NextToken()
+		if got.Type != token.Number {
+			t.Errorf("case%d Lexer.NextToken().typ = %v, want %v", i+1, got.Type, token.Number)
+		}
+		if got.Literal != tt.input {
+			t.Errorf("case%d Lexer.NextToken().literal = %q, want %q", i+1, got.Literal, tt.input)
+		}
+		if got.Value != tt.value {
+			t.Errorf("case%d Lexer.NextToken().value = %q, want %q", i+1, got.Value, tt.value)
+		}
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Errorf("case%d Lexer.NextToken().typ = %v, want %v", i+1, next.Type, token.EOF)
+		}
+	}
+}
+
+func Test_lexer_StringValue(t *testing.T) {
+	tests := []struct {
+		input   string
+		literal string
+		value   string
+	}{
+		{`'Dianne''s horse'`, `'Dianne''s horse'`, `Dianne's horse`},
+		{`'C:\'`, `'C:\'`, `C:\`},
+		{"'foo'\n'bar'", "'foo'\n'bar'", `foobar`},
+		{"'foo' -- comment\n  'bar'", "'foo' -- comment\n  'bar'", `foobar`},
+		{`E'a\nb\tc\\d\'e'`, `E'a\nb\tc\\d\'e'`, "a\nb\tc\\d'e"},
+		{`e'\101\x42\u0043\U00000044'`, `e'\101\x42\u0043\U00000044'`, `ABCD`},
+		{`U&'d\0061t\+000061'`, `U&'d\0061t\+000061'`, `data`},
+		{`U&'d!0061t!+000061' UESCAPE '!'`, `U&'d!0061t!+000061' UESCAPE '!'`, `data`},
+		{`B'1001'`, `B'1001'`, `1001`},
+		{`X'1F'`, `X'1F'`, `00011111`},
+		{`N'foo'`, `N'foo'`, `foo`},
+	}
+	for i, tt := range tests {
+		l := Lex("<input>", tt.input)
+		got := l.NextToken()
+		if got.Type != token.String {
+			t.Errorf("case%d Lexer.NextToken().typ = %v, want %v", i+1, got.Type, token.String)
+		}
+		if got.Literal != tt.literal {
+			t.Errorf("case%d Lexer.NextToken().literal = %q, want %q", i+1, got.Literal, tt.literal)
+		}
+		if got.Value != tt.value {
+			t.Errorf("case%d Lexer.NextToken().value = %q, want %q", i+1, got.Value, tt.value)
+		}
+		if next := l.NextToken(); next.Type != token.EOF {
+			t.Errorf("case%d Lexer.NextToken().typ = %v, want %v", i+1, next.Type, token.EOF)
+		}
+	}
+}
@@ -2,20 +2,33 @@ package parser
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/ttakezawa/pgconverger/ast"
 	"github.com/ttakezawa/pgconverger/lexer"
 	"github.com/ttakezawa/pgconverger/token"
 )
 
+// https://www.postgresql.org/docs/10/sql-syntax-lexical.html#SQL-PRECEDENCE
 const (
 	_ int = iota
 	precedenceLowest
-	precedenceIs
+	precedenceOr
+	precedenceAnd
+	precedenceNot        // NOT x
+	precedenceIs         // IS, ISNULL, NOTNULL
+	precedenceComparison // < > = <= >= <>
+	precedenceLike       // BETWEEN, IN, LIKE, ILIKE, SIMILAR
+	precedenceOp         // any other operator
 	precedenceSum
 	precedenceProduct
+	precedenceExponent
+	precedenceAt      // AT TIME ZONE
+	precedenceCollate // COLLATE
+	precedencePrefix  // -x
+	precedenceSubscript
 	precedenceTypecast
-	precedencePrefix // -x
 	precedenceCall
 )
 
@@ -30,6 +43,11 @@ type Parser struct {
 	peekToken token.Token
 	errors    []error
 
+	// restricted is set while parsing b_expr, the expression syntax of
+	// column defaults, where boolean operators are not available so that
+	// a following NOT NULL is read as a constraint.
+	restricted bool
+
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
 }
@@ -44,21 +62,71 @@ func New(l *lexer.Lexer) *Parser {
 	p.registerPrefix(token.Number, p.parseNumberLiteral)
 	p.registerPrefix(token.Identifier, p.parseIdentifierAsExpression)
 	p.registerPrefix(token.Text, p.parseIdentifierAsExpression)
-	// p.registerPrefix(token.Minus, p.parsePrefixExpression)
-	// p.registerPrefix(token.Plus, p.parsePrefixExpression)
+	p.registerPrefix(token.Minus, p.parsePrefixExpression)
+	p.registerPrefix(token.Plus, p.parsePrefixExpression)
+	p.registerPrefix(token.Not, p.parsePrefixExpression)
+	p.registerPrefix(token.Op, p.parsePrefixExpression)
+	p.registerPrefix(token.Operator, p.parsePrefixExpression)
 	p.registerPrefix(token.True, p.parseBoolean)
 	p.registerPrefix(token.False, p.parseBoolean)
 	p.registerPrefix(token.Null, p.parseNull)
 	p.registerPrefix(token.LParen, p.parseGroupedExpression)
+	p.registerPrefix(token.Case, p.parseCaseExpression)
+	p.registerPrefix(token.Cast, p.parseCastExpression)
+	p.registerPrefix(token.Array, p.parseArrayExpression)
+	p.registerPrefix(token.Row, p.parseRowExpression)
+	for _, typ := range []token.TokenType{
+		token.CurrentCatalog,
+		token.CurrentDate,
+		token.CurrentRole,
+		token.CurrentSchema,
+		token.CurrentTime,
+		token.CurrentTimestamp,
+		token.CurrentUser,
+		token.Localtime,
+		token.Localtimestamp,
+		token.SessionUser,
+		token.User,
+	} {
+		p.registerPrefix(typ, p.parseKeywordFunction)
+	}
 
 	p.infixParseFns = make(map[token.TokenType]infixParseFn)
-	p.registerInfix(token.Plus, p.parseInfixExpression)
-	p.registerInfix(token.Minus, p.parseInfixExpression)
-	p.registerInfix(token.Slash, p.parseInfixExpression)
-	p.registerInfix(token.Asterisk, p.parseInfixExpression)
-	p.registerInfix(token.Typecast, p.parseInfixExpression)
-	p.registerInfix(token.Is, p.parseInfixExpression)
+	for _, typ := range []token.TokenType{
+		token.Plus,
+		token.Minus,
+		token.Slash,
+		token.Asterisk,
+		token.Percent,
+		token.Caret,
+		token.Equal,
+		token.LessThan,
+		token.GreaterThan,
+		token.LessEquals,
+		token.GreaterEquals,
+		token.NotEquals,
+		token.Op,
+		token.Operator,
+		token.And,
+		token.Or,
+	} {
+		p.registerInfix(typ, p.parseInfixExpression)
+	}
+	p.registerInfix(token.Typecast, p.parseTypeCastExpression)
+	p.registerInfix(token.Is, p.parseIsExpression)
+	p.registerInfix(token.Isnull, p.parseIsExpression)
+	p.registerInfix(token.Notnull, p.parseIsExpression)
+	p.registerInfix(token.Not, p.parseNegatedInfixExpression)
+	p.registerInfix(token.Like, p.parseLikeExpression)
+	p.registerInfix(token.Ilike, p.parseLikeExpression)
+	p.registerInfix(token.Similar, p.parseLikeExpression)
+	p.registerInfix(token.In, p.parseInExpression)
+	p.registerInfix(token.Between, p.parseBetweenExpression)
+	p.registerInfix(token.Collate, p.parseCollateExpression)
+	p.registerInfix(token.At, p.parseAtTimeZoneExpression)
+	p.registerInfix(token.LBracket, p.parseSubscriptExpression)
 	p.registerInfix(token.LParen, p.parseCallExpression)
+	p.registerInfix(token.Dot, p.parseQualifiedName)
 
 	p.advance()
 	p.advance()
@@ -81,15 +149,55 @@ func (p *Parser) Errors() []error {
 	return p.errors
 }
 
-// https://www.postgresql.org/docs/10/sql-syntax-lexical.html#SQL-PRECEDENCE
 var precedences = map[token.TokenType]int{
-	token.Is:       precedenceIs,
-	token.Plus:     precedenceSum,
-	token.Minus:    precedenceSum,
-	token.Slash:    precedenceProduct,
-	token.Asterisk: precedenceProduct,
-	token.Typecast: precedenceTypecast,
-	token.LParen:   precedenceCall,
+	token.Or:            precedenceOr,
+	token.And:           precedenceAnd,
+	token.Is:            precedenceIs,
+	token.Isnull:        precedenceIs,
+	token.Notnull:       precedenceIs,
+	token.Equal:         precedenceComparison,
+	token.LessThan:      precedenceComparison,
+	token.GreaterThan:   precedenceComparison,
+	token.LessEquals:    precedenceComparison,
+	token.GreaterEquals: precedenceComparison,
+	token.NotEquals:     precedenceComparison,
+	token.Not:           precedenceLike, // NOT LIKE, NOT IN, NOT BETWEEN
+	token.Like:          precedenceLike,
+	token.Ilike:         precedenceLike,
+	token.Similar:       precedenceLike,
+	token.In:            precedenceLike,
+	token.Between:       precedenceLike,
+	token.Op:            precedenceOp,
+	token.Operator:      precedenceOp,
+	token.Plus:          precedenceSum,
+	token.Minus:         precedenceSum,
+	token.Slash:         precedenceProduct,
+	token.Asterisk:      precedenceProduct,
+	token.Percent:       precedenceProduct,
+	token.Caret:         precedenceExponent,
+	token.At:            precedenceAt,
+	token.Collate:       precedenceCollate,
+	token.LBracket:      precedenceSubscript,
+	token.Typecast:      precedenceTypecast,
+	token.LParen:        precedenceCall,
+	token.Dot:           precedenceCall,
+}
+
+// restrictedOperators are the operators of a_expr which b_expr lacks.
+var restrictedOperators = map[token.TokenType]bool{
+	token.Or:      true,
+	token.And:     true,
+	token.Is:      true,
+	token.Isnull:  true,
+	token.Notnull: true,
+	token.Not:     true,
+	token.Like:    true,
+	token.Ilike:   true,
+	token.Similar: true,
+	token.In:      true,
+	token.Between: true,
+	token.At:      true,
+	token.Collate: true,
 }
 
 func (p *Parser) peekPrecedence() int {
@@ -160,6 +268,11 @@ func (p *Parser) parseStatementList() (list []ast.Statement) {
 		if statement := p.parseStatement(); statement != nil {
 			list = append(list, statement)
 		}
+		if p.token.Type == token.MetaCommand {
+			// A meta-command ends at the end of its line, not at a semicolon.
+			p.advance()
+			continue
+		}
 
 	skipRest:
 		for {
@@ -187,12 +300,20 @@ func (p *Parser) parseStatement() ast.Statement {
 			return nil
 		case token.Schema:
 			return p.parseCreateSchemaStatement()
-		case token.Table:
+		case token.Table, token.Temp, token.Temporary, token.Unlogged, token.Global, token.Local, token.Foreign:
 			return p.parseCreateTableStatement()
+		case token.Server:
+			return p.parseCreateServerStatement()
+		case token.User:
+			return p.parseCreateUserMappingStatement()
 		case token.Unique, token.Index:
 			return p.parseCreateIndexStatement()
 		case token.Sequence:
 			return p.parseCreateSequenceStatement()
+		case token.Collation:
+			return p.parseCreateCollationStatement()
+		case token.Policy:
+			return p.parseCreatePolicyStatement()
 		case token.Extension:
 			// Not yet implemented
 			return nil
@@ -219,11 +340,26 @@ func (p *Parser) parseStatement() ast.Statement {
 		case token.Schema:
 			// Not yet implemented
 			return nil
-		case token.Table:
+		case token.Table, token.Foreign:
 			return p.parseAlterTableStatement()
+		case token.Index:
+			return p.parseAlterIndexStatement()
 		case token.Sequence:
 			return p.parseAlterSequenceStatement()
+		case token.Server:
+			return p.parseAlterServerStatement()
+		case token.User:
+			return p.parseAlterUserMappingStatement()
+		case token.Policy:
+			return p.parseAlterPolicyStatement()
+		}
+	case token.Drop:
+		if p.peekToken.Type == token.User {
+			return p.parseDropUserMappingStatement()
 		}
+		return p.parseDropStatement()
+	case token.Import:
+		return p.parseImportForeignSchemaStatement()
 	case token.Grant:
 		// Not yet implemented
 		return nil
@@ -233,14 +369,20 @@ func (p *Parser) parseStatement() ast.Statement {
 	case token.Set:
 		return p.parseSetStatement()
 	case token.Select:
-		// Not yet implemented
-		return nil
+		return p.parseSelectStatement()
+	case token.Reset:
+		return p.parseResetStatement()
+	case token.Begin, token.Start, token.Commit, token.End, token.Rollback:
+		return p.parseTransactionStatement()
 	case token.Comment:
 		// Not yet implemented
 		return nil
-	case token.BackslashConnect:
-		// Not yet implemented
+	case token.Copy:
+		// Not yet implemented. The data of COPY ... FROM STDIN is skipped by
+		// the lexer.
 		return nil
+	case token.MetaCommand:
+		return p.parseMetaCommand()
 	default:
 		p.errorf(p.token.Line, "unknown token: %s", p.token.Literal)
 	}
@@ -264,9 +406,39 @@ func (p *Parser) parseCreateSchemaStatement() ast.Statement {
 func (p *Parser) parseCreateTableStatement() ast.Statement {
 	createTableStatement := &ast.CreateTableStatement{}
 
+	if p.peekToken.Type == token.Global || p.peekToken.Type == token.Local {
+		p.advance()
+	}
+	switch p.peekToken.Type {
+	case token.Temp, token.Temporary:
+		p.advance()
+		createTableStatement.Temporary = true
+	case token.Unlogged:
+		p.advance()
+		createTableStatement.Unlogged = true
+	}
+	if p.peekToken.Type != token.Table && (createTableStatement.Temporary || createTableStatement.Unlogged) {
+		// Temporary and unlogged sequences and views are not yet implemented.
+		return nil
+	}
+	if p.peekToken.Type == token.Foreign {
+		p.advance()
+		if p.peekToken.Type != token.Table {
+			// Foreign data wrappers are not yet implemented.
+			return nil
+		}
+		createTableStatement.Foreign = true
+	}
 	if !p.expectPeek(token.Table) {
 		return nil
 	}
+	if p.peekToken.Type == token.If {
+		p.advance()
+		if !p.expectPeek(token.Not) || !p.expectPeek(token.Exists) {
+			return nil
+		}
+		createTableStatement.IfNotExists = true
+	}
 
 	p.advance()
 	tableName := p.parseTableName()
@@ -275,15 +447,91 @@ func (p *Parser) parseCreateTableStatement() ast.Statement {
 	}
 	createTableStatement.TableName = tableName
 
-	if !p.expectPeek(token.LParen) {
-		return nil
+	if p.peekToken.Type == token.Partition {
+		// Case: CREATE TABLE "table_name" PARTITION OF "parent_table" ...
+		p.advance()
+		if !p.expectPeek(token.Of) {
+			return nil
+		}
+		p.advance()
+		createTableStatement.PartitionOf = p.parseTableName()
+		if createTableStatement.PartitionOf == nil {
+			return nil
+		}
 	}
-	p.advance()
-	columnDefinitionList := p.parseColumnDefinitionList()
-	if !p.expectPeek(token.RParen) {
+
+	if createTableStatement.PartitionOf == nil || p.peekToken.Type == token.LParen {
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
+		p.advance()
+		columnDefinitionList, tableConstraints := p.parseTableElementList()
+		if !p.expectPeek(token.RParen) {
+			return nil
+		}
+		createTableStatement.ColumnDefinitionList = columnDefinitionList
+		createTableStatement.TableConstraints = tableConstraints
+	}
+
+	if createTableStatement.PartitionOf == nil && p.peekToken.Type == token.Inherits {
+		// INHERITS ( parent_table [, ... ] )
+		p.advance()
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
+		for {
+			p.advance()
+			parent := p.parseTableName()
+			if parent == nil {
+				return nil
+			}
+			createTableStatement.Inherits = append(createTableStatement.Inherits, parent)
+			if p.peekToken.Type != token.Comma {
+				break
+			}
+			p.advance()
+		}
+		if !p.expectPeek(token.RParen) {
+			return nil
+		}
+	}
+
+	if createTableStatement.PartitionOf != nil {
+		p.advance()
+		createTableStatement.PartitionBound = p.parsePartitionBound()
+		if createTableStatement.PartitionBound == nil {
+			return nil
+		}
+	}
+
+	if p.peekToken.Type == token.Partition {
+		p.advance()
+		createTableStatement.PartitionBy = p.parsePartitionBy()
+		if createTableStatement.PartitionBy == nil {
+			return nil
+		}
+	}
+
+	if !p.parseTableOptions(createTableStatement) {
 		return nil
 	}
-	createTableStatement.ColumnDefinitionList = columnDefinitionList
+
+	if createTableStatement.Foreign {
+		// SERVER server_name [ OPTIONS ( option 'value' [, ... ] ) ]
+		if !p.expectPeek(token.Server) {
+			return nil
+		}
+		p.advance()
+		if createTableStatement.Server = p.parseIdentifier(); createTableStatement.Server == nil {
+			return nil
+		}
+		if p.peekToken.Type == token.Options {
+			p.advance()
+			if createTableStatement.Options = p.parseGenericOptions(false); createTableStatement.Options == nil {
+				return nil
+			}
+		}
+	}
 
 	switch p.peekToken.Type {
 	case token.Semicolon:
@@ -296,22 +544,216 @@ func (p *Parser) parseCreateTableStatement() ast.Statement {
 	return createTableStatement
 }
 
-// { column_definition, ... }
-func (p *Parser) parseColumnDefinitionList() (defs []*ast.ColumnDefinition) {
-	if p.peekToken.Type == token.RParen {
-		return
+// [ USING method ]
+// [ WITH ( storage_parameter [= value] [, ... ] ) | WITHOUT OIDS ]
+// [ ON COMMIT { PRESERVE ROWS | DELETE ROWS | DROP } ]
+// [ TABLESPACE tablespace_name ]
+func (p *Parser) parseTableOptions(createTableStatement *ast.CreateTableStatement) bool {
+	if p.peekToken.Type == token.Using {
+		p.advance()
+		p.advance()
+		createTableStatement.AccessMethod = p.parseIdentifier()
+		if createTableStatement.AccessMethod == nil {
+			return false
+		}
+	}
+
+	switch p.peekToken.Type {
+	case token.With:
+		p.advance()
+		if p.peekToken.Type == token.Oids {
+			p.errorf(p.peekToken.Line, "tables declared WITH OIDS are not supported")
+			return false
+		}
+		if !p.expectPeek(token.LParen) {
+			return false
+		}
+		createTableStatement.StorageParameters = p.parseStorageParameters()
+		if createTableStatement.StorageParameters == nil {
+			return false
+		}
+	case token.Without:
+		p.advance()
+		if !p.expectPeek(token.Oids) {
+			return false
+		}
+	}
+
+	if p.peekToken.Type == token.On {
+		p.advance()
+		if !p.expectPeek(token.Commit) {
+			return false
+		}
+		p.advance()
+		switch p.token.Type {
+		case token.Preserve, token.Delete:
+			action := strings.ToUpper(p.token.Literal)
+			if !p.expectPeek(token.Rows) {
+				return false
+			}
+			createTableStatement.OnCommit = action + " ROWS"
+		case token.Drop:
+			createTableStatement.OnCommit = "DROP"
+		default:
+			p.errorf(p.token.Line, "expected PRESERVE ROWS, DELETE ROWS or DROP, found %s", p.token.Literal)
+			return false
+		}
+	}
+
+	if p.peekToken.Type == token.Tablespace {
+		p.advance()
+		p.advance()
+		createTableStatement.Tablespace = p.parseIdentifier()
+		if createTableStatement.Tablespace == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// PARTITION BY { RANGE | LIST | HASH } ( { column_name | ( expression ) } [ COLLATE collation ] [ opclass ] [, ... ] )
+func (p *Parser) parsePartitionBy() *ast.PartitionBy {
+	if !p.expectPeek(token.By) {
+		return nil
+	}
+	p.advance()
+	partitionBy := &ast.PartitionBy{}
+	switch p.token.Value {
+	case "range", "list", "hash":
+		partitionBy.Strategy = strings.ToUpper(p.token.Value)
+	default:
+		p.errorf(p.token.Line, "unrecognized partitioning strategy %q", p.token.Literal)
+		return nil
+	}
+	if !p.expectPeek(token.LParen) {
+		return nil
+	}
+	partitionBy.Keys = p.parseIndexTargets()
+	if len(partitionBy.Keys) == 0 {
+		p.errorf(p.token.Line, "expected partition key, found %s", p.token.Literal)
+		return nil
+	}
+	if !p.expectPeek(token.RParen) {
+		return nil
+	}
+	return partitionBy
+}
+
+// { FOR VALUES partition_bound_spec | DEFAULT }
+func (p *Parser) parsePartitionBound() *ast.PartitionBound {
+	partitionBound := &ast.PartitionBound{}
+	switch p.token.Type {
+	case token.Default:
+		partitionBound.Default = true
+		return partitionBound
+	case token.For:
+	default:
+		p.errorf(p.token.Line, "expected FOR VALUES or DEFAULT, found %s", p.token.Literal)
+		return nil
+	}
+	if !p.expectPeek(token.Values) {
+		return nil
+	}
+	p.advance()
+	switch p.token.Type {
+	case token.In:
+		partitionBound.In = p.parsePartitionBoundList()
+		if partitionBound.In == nil {
+			return nil
+		}
+	case token.From:
+		partitionBound.From = p.parsePartitionBoundList()
+		if partitionBound.From == nil || !p.expectPeek(token.To) {
+			return nil
+		}
+		partitionBound.To = p.parsePartitionBoundList()
+		if partitionBound.To == nil {
+			return nil
+		}
+	case token.With:
+		// WITH ( MODULUS numeric_literal, REMAINDER numeric_literal )
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
+		for _, name := range []string{"modulus", "remainder"} {
+			p.advance()
+			if p.token.Value != name {
+				p.errorf(p.token.Line, "expected %s, found %s", strings.ToUpper(name), p.token.Literal)
+				return nil
+			}
+			if !p.expectPeek(token.Number) {
+				return nil
+			}
+			if name == "modulus" {
+				partitionBound.Modulus = p.parseNumberLiteral()
+				if !p.expectPeek(token.Comma) {
+					return nil
+				}
+			} else {
+				partitionBound.Remainder = p.parseNumberLiteral()
+			}
+		}
+		if !p.expectPeek(token.RParen) {
+			return nil
+		}
+	default:
+		p.errorf(p.token.Line, "expected IN, FROM or WITH, found %s", p.token.Literal)
+		return nil
 	}
-	if def := p.parseColumnDefinition(); def != nil {
-		defs = append(defs, def)
+	return partitionBound
+}
+
+// ( { partition_bound_expr | MINVALUE | MAXVALUE } [, ...] )
+func (p *Parser) parsePartitionBoundList() []ast.Expression {
+	if !p.expectPeek(token.LParen) {
+		return nil
 	}
-	for p.peekToken.Type == token.Comma {
+	var list []ast.Expression
+	for {
 		p.advance()
+		switch p.token.Type {
+		case token.Minvalue, token.Maxvalue:
+			list = append(list, &ast.KeywordFunction{Token: p.token})
+		default:
+			expr := p.parseNestedExpression()
+			if expr == nil {
+				return nil
+			}
+			list = append(list, expr)
+		}
+		if p.peekToken.Type != token.Comma {
+			break
+		}
 		p.advance()
-		if def := p.parseColumnDefinition(); def != nil {
-			defs = append(defs, def)
+	}
+	if !p.expectPeek(token.RParen) {
+		return nil
+	}
+	return list
+}
+
+// { column_definition | table_constraint } [, ... ]
+func (p *Parser) parseTableElementList() (defs []*ast.ColumnDefinition, constraints []*ast.TableConstraint) {
+	if p.peekToken.Type == token.RParen {
+		return
+	}
+	for {
+		switch p.token.Type {
+		case token.Constraint, token.Check, token.Unique, token.Primary, token.Foreign:
+			if constraint := p.parseTableConstraint(); constraint != nil {
+				constraints = append(constraints, constraint)
+			}
+		default:
+			if def := p.parseColumnDefinition(); def != nil {
+				defs = append(defs, def)
+			}
 		}
+		if p.peekToken.Type != token.Comma {
+			return
+		}
+		p.advance()
+		p.advance()
 	}
-	return
 }
 
 // "table_name" | "schema_name"."table_name"
@@ -330,7 +772,7 @@ func (p *Parser) parseTableName() *ast.TableName {
 		tableName.SchemaIdentifier = identifier
 		p.advance()
 		p.advance()
-		identifier := p.parseIdentifier()
+		identifier := p.parseColLabel()
 		if identifier == nil {
 			return nil
 		}
@@ -355,7 +797,7 @@ func (p *Parser) parseSequenceName() *ast.SequenceName {
 		sequenceName.SchemaIdentifier = identifier
 		p.advance()
 		p.advance()
-		identifier := p.parseIdentifier()
+		identifier := p.parseColLabel()
 		if identifier == nil {
 			return nil
 		}
@@ -368,42 +810,80 @@ func (p *Parser) parseIdentifierAsExpression() ast.Expression {
 	return p.parseIdentifier()
 }
 
+func (p *Parser) parseFunctionNameAsExpression() ast.Expression {
+	return p.parseTypeFunctionName()
+}
+
+// isIdentifier reports whether the current token is a ColId, the name of a
+// table, column or other object: an identifier, or an unreserved or
+// col_name keyword.
 func (p *Parser) isIdentifier() bool {
-	switch {
-	case p.token.Type == token.Identifier:
+	if !p.token.IsWord() {
+		return false
+	}
+	switch p.token.Category() {
+	case token.NotKeyword, token.UnreservedKeyword, token.ColNameKeyword:
 		return true
-	case p.token.IsKeyword() && !p.token.IsReserved():
+	default:
+		return false
+	}
+}
+
+// isTypeFunctionName reports whether the current token is a
+// type_function_name: an identifier, or an unreserved or type_func_name
+// keyword.
+func (p *Parser) isTypeFunctionName() bool {
+	if !p.token.IsWord() {
+		return false
+	}
+	switch p.token.Category() {
+	case token.NotKeyword, token.UnreservedKeyword, token.TypeFuncNameKeyword:
 		return true
 	default:
 		return false
 	}
 }
 
+// isColLabel reports whether the current token is a ColLabel, the name
+// following a dot, which can be any identifier or keyword.
+func (p *Parser) isColLabel() bool {
+	return p.token.IsWord()
+}
+
 func (p *Parser) parseIdentifier() *ast.Identifier {
-	switch {
-	case p.token.Type == token.Identifier:
-		identifier := ast.Identifier{Token: p.token}
-		switch {
-		case len(p.token.Literal) == 0:
-			identifier.Value = ""
-		case p.token.Literal[0] == '"':
-			identifier.Value = p.token.Literal[1 : len(p.token.Literal)-1]
-		default:
-			identifier.Value = p.token.Literal
-		}
-		return &identifier
-	case p.token.IsKeyword() && !p.token.IsReserved():
-		return &ast.Identifier{
-			Token: p.token,
-			Value: p.token.Literal,
-		}
-	default:
+	if !p.isIdentifier() {
+		p.errorf(p.token.Line, "expected identifier, found %s", p.token.Literal)
+		return nil
+	}
+	return p.newIdentifier()
+}
+
+func (p *Parser) parseTypeFunctionName() *ast.Identifier {
+	if !p.isTypeFunctionName() {
+		p.errorf(p.token.Line, "expected type or function name, found %s", p.token.Literal)
+		return nil
+	}
+	return p.newIdentifier()
+}
+
+func (p *Parser) parseColLabel() *ast.Identifier {
+	if !p.isColLabel() {
 		p.errorf(p.token.Line, "expected identifier, found %s", p.token.Literal)
 		return nil
 	}
+	return p.newIdentifier()
 }
 
-// column_name data_type [ COLLATE collation ] [ column_constraint [ ... ] ]
+func (p *Parser) newIdentifier() *ast.Identifier {
+	return &ast.Identifier{
+		Token: p.token,
+		Value: p.token.Value,
+	}
+}
+
+// column_name data_type [ STORAGE { PLAIN | EXTERNAL | EXTENDED | MAIN | DEFAULT } ]
+// [ COMPRESSION compression_method ] [ OPTIONS ( option 'value' [, ... ] ) ]
+// [ COLLATE collation ] [ column_constraint [ ... ] ]
 func (p *Parser) parseColumnDefinition() *ast.ColumnDefinition {
 	var def ast.ColumnDefinition
 	identifier := p.parseIdentifier()
@@ -419,46 +899,96 @@ func (p *Parser) parseColumnDefinition() *ast.ColumnDefinition {
 	}
 	def.Type = dataType
 
-	switch p.peekToken.Type {
-	case token.Comma, token.RParen:
-		// Do nothing
-	default:
+	if p.peekToken.Type == token.Storage {
 		p.advance()
-		// Parse constraint
-		columnConstraintList := p.parseColumnConstraintList()
-		if columnConstraintList == nil {
+		p.advance()
+		if def.Storage = p.parseStorageMode(); def.Storage == "" {
 			return nil
 		}
-		def.ConstraintList = columnConstraintList
 	}
-	return &def
-}
-
-func (p *Parser) parseArray(elementType ast.DataType) ast.DataType {
-	p.advance()
-	if ok := p.expectPeek(token.RBracket); !ok {
-		return nil
+	if p.peekToken.Type == token.Compression {
+		p.advance()
+		p.advance()
+		if def.Compression = p.parseIdentifier(); def.Compression == nil {
+			return nil
+		}
 	}
-	return &ast.DataTypeArray{ElementType: elementType}
-}
-
-func (p *Parser) parseDataType() ast.DataType {
-	switch p.token.Type {
-	case token.Integer:
-		dataType := &ast.DataTypeInteger{Token: p.token}
-		if p.peekToken.Type == token.LBracket {
-			return p.parseArray(dataType)
+	if p.peekToken.Type == token.Options {
+		p.advance()
+		if def.Options = p.parseGenericOptions(false); def.Options == nil {
+			return nil
+		}
+	}
+	if p.peekToken.Type == token.Collate {
+		p.advance()
+		p.advance()
+		if def.Collation = p.parseAnyName(); def.Collation == nil {
+			return nil
+		}
+	}
+
+	for {
+		switch p.peekToken.Type {
+		case token.Comma, token.RParen, token.Semicolon, token.EOF:
+			return &def
+		}
+		p.advance()
+		if p.token.Type == token.Collate && def.Collation == nil {
+			// COLLATE may also follow the constraints.
+			p.advance()
+			if def.Collation = p.parseAnyName(); def.Collation == nil {
+				return nil
+			}
+			continue
+		}
+		// Parse constraint
+		columnConstraintList := p.parseColumnConstraintList()
+		if columnConstraintList == nil {
+			return nil
+		}
+		def.ConstraintList = append(def.ConstraintList, columnConstraintList...)
+	}
+}
+
+func (p *Parser) parseArray(elementType ast.DataType) ast.DataType {
+	p.advance()
+	if ok := p.expectPeek(token.RBracket); !ok {
+		return nil
+	}
+	return &ast.DataTypeArray{ElementType: elementType}
+}
+
+func (p *Parser) parseDataType() ast.DataType {
+	switch p.token.Type {
+	case token.Integer:
+		dataType := &ast.DataTypeInteger{Token: p.token}
+		if p.peekToken.Type == token.LBracket {
+			return p.parseArray(dataType)
 		} else {
 			return dataType
 		}
 	case token.Bigint:
-		return &ast.DataTypeBigint{p.token}
+		return &ast.DataTypeBigint{Token: p.token}
 	case token.Smallint:
-		return &ast.DataTypeSmallint{p.token}
+		return &ast.DataTypeSmallint{Token: p.token}
+	case token.Smallserial:
+		return &ast.DataTypeSmallserial{}
+	case token.Serial:
+		return &ast.DataTypeSerial{}
+	case token.Bigserial:
+		return &ast.DataTypeBigserial{}
 	case token.Boolean:
 		return &ast.DataTypeBoolean{}
 	case token.Numeric:
-		return &ast.DataTypeNumeric{}
+		modifiers, ok := p.parseTypeModifiers()
+		if !ok {
+			return nil
+		}
+		dataType := &ast.DataTypeNumeric{Modifiers: modifiers}
+		if p.peekToken.Type == token.LBracket {
+			return p.parseArray(dataType)
+		}
+		return dataType
 	case token.Character:
 		var dataTypeCharacter ast.DataTypeCharacter
 		if p.peekToken.Type == token.Varying {
@@ -480,23 +1010,17 @@ func (p *Parser) parseDataType() ast.DataType {
 	case token.Date:
 		return &ast.DataTypeDate{}
 	case token.Timestamp:
-		var dataTypeTimestamp ast.DataTypeTimestamp
-		switch p.peekToken.Type {
-		case token.With:
-			// timestamp with time zone
-			p.advance()
-			if ok := p.expectPeek(token.Time) && p.expectPeek(token.Zone); !ok {
-				return nil
-			}
-			dataTypeTimestamp.WithTimeZone = true
-		case token.Without:
-			p.advance()
-			if ok := p.expectPeek(token.Time) && p.expectPeek(token.Zone); !ok {
-				return nil
-			}
-			dataTypeTimestamp.WithTimeZone = false
+		withTimeZone, ok := p.parseTimeZone()
+		if !ok {
+			return nil
+		}
+		return p.parseArrayOf(&ast.DataTypeTimestamp{WithTimeZone: withTimeZone})
+	case token.Time:
+		withTimeZone, ok := p.parseTimeZone()
+		if !ok {
+			return nil
 		}
-		return &dataTypeTimestamp
+		return p.parseArrayOf(&ast.DataTypeTime{WithTimeZone: withTimeZone})
 	case token.Text:
 		dataType := &ast.DataTypeText{}
 		if p.peekToken.Type == token.LBracket {
@@ -528,11 +1052,194 @@ func (p *Parser) parseDataType() ast.DataType {
 			return &ast.DataTypeUuid{}
 		}
 
+		if !strings.HasPrefix(p.token.Literal, `"`) {
+			if dataType, ok := p.parseDataTypeAlias(); ok {
+				return dataType
+			}
+		}
+
+		if p.isIdentifier() {
+			return p.parseUserDefinedDataType()
+		}
+
 		p.errorf(p.token.Line, "expected DataType, found %s", p.token.Literal)
 		return nil
 	}
 }
 
+// parseDataTypeAlias parses the types which are written as more than one
+// word or under another name, such as double precision or varchar(n), into
+// the type pg_dump writes. It returns false when the current token does not
+// start one.
+func (p *Parser) parseDataTypeAlias() (ast.DataType, bool) {
+	var dataType ast.DataType
+	switch p.token.Value {
+	case "interval":
+		dataType = &ast.DataTypeInterval{Fields: p.parseIntervalFields()}
+	case "double":
+		if p.peekToken.Value != "precision" {
+			return nil, false
+		}
+		p.advance()
+		dataType = &ast.DataTypeDoublePrecision{}
+	case "float8":
+		dataType = &ast.DataTypeDoublePrecision{}
+	case "real", "float4":
+		dataType = &ast.DataTypeReal{}
+	case "float":
+		// float(p) is real up to 24 bits of precision.
+		dataType = &ast.DataTypeDoublePrecision{}
+		if p.peekToken.Type == token.LParen {
+			p.advance()
+			optionLength := p.parseDataTypeOptionLength()
+			if optionLength == nil {
+				return nil, true
+			}
+			if precision, err := strconv.Atoi(optionLength.Value); err == nil && precision <= 24 {
+				dataType = &ast.DataTypeReal{}
+			}
+		}
+	case "decimal", "dec":
+		modifiers, ok := p.parseTypeModifiers()
+		if !ok {
+			return nil, true
+		}
+		dataType = &ast.DataTypeNumeric{Modifiers: modifiers}
+	case "int4":
+		dataType = &ast.DataTypeInteger{Token: p.token}
+	case "int2":
+		dataType = &ast.DataTypeSmallint{Token: p.token}
+	case "int8":
+		dataType = &ast.DataTypeBigint{Token: p.token}
+	case "serial4":
+		dataType = &ast.DataTypeSerial{}
+	case "serial2":
+		dataType = &ast.DataTypeSmallserial{}
+	case "serial8":
+		dataType = &ast.DataTypeBigserial{}
+	case "bool":
+		dataType = &ast.DataTypeBoolean{}
+	case "timestamptz":
+		dataType = &ast.DataTypeTimestamp{WithTimeZone: true}
+	case "timetz":
+		dataType = &ast.DataTypeTime{WithTimeZone: true}
+	case "varchar", "char":
+		dataTypeCharacter := &ast.DataTypeCharacter{Varying: p.token.Value == "varchar"}
+		if p.peekToken.Type == token.Varying {
+			p.advance()
+			dataTypeCharacter.Varying = true
+		}
+		if p.peekToken.Type == token.LParen {
+			p.advance()
+			if dataTypeCharacter.OptionLength = p.parseDataTypeOptionLength(); dataTypeCharacter.OptionLength == nil {
+				return nil, true
+			}
+		}
+		dataType = dataTypeCharacter
+	case "bit", "varbit":
+		dataTypeBit := &ast.DataTypeBit{Varying: p.token.Value == "varbit"}
+		if p.peekToken.Type == token.Varying {
+			p.advance()
+			dataTypeBit.Varying = true
+		}
+		if p.peekToken.Type == token.LParen {
+			p.advance()
+			if dataTypeBit.OptionLength = p.parseDataTypeOptionLength(); dataTypeBit.OptionLength == nil {
+				return nil, true
+			}
+		}
+		dataType = dataTypeBit
+	default:
+		return nil, false
+	}
+	return p.parseArrayOf(dataType), true
+}
+
+// [ WITH | WITHOUT TIME ZONE ], following time or timestamp. It reports
+// whether the type is with time zone.
+func (p *Parser) parseTimeZone() (withTimeZone bool, ok bool) {
+	switch p.peekToken.Type {
+	case token.With:
+		withTimeZone = true
+	case token.Without:
+	default:
+		return false, true
+	}
+	p.advance()
+	if ok := p.expectPeek(token.Time) && p.expectPeek(token.Zone); !ok {
+		return false, false
+	}
+	return withTimeZone, true
+}
+
+// type [ [] ... ]
+func (p *Parser) parseArrayOf(dataType ast.DataType) ast.DataType {
+	for dataType != nil && p.peekToken.Type == token.LBracket {
+		dataType = p.parseArray(dataType)
+	}
+	return dataType
+}
+
+// intervalFields are the fields which restrict an interval.
+var intervalFields = map[string]bool{
+	"year": true, "month": true, "day": true, "hour": true, "minute": true, "second": true,
+}
+
+// [ field [ TO field ] ], where field is YEAR, MONTH, DAY, HOUR, MINUTE or
+// SECOND. It returns the fields in lower case, or "" when none follow.
+func (p *Parser) parseIntervalFields() string {
+	if !p.peekToken.IsWord() || !intervalFields[p.peekToken.Value] {
+		return ""
+	}
+	p.advance()
+	fields := p.token.Value
+	if p.peekToken.Type == token.To {
+		p.advance()
+		p.advance()
+		if !intervalFields[p.token.Value] {
+			p.errorf(p.token.Line, "expected interval field, found %s", p.token.Literal)
+			return ""
+		}
+		fields += " to " + p.token.Value
+	}
+	return fields
+}
+
+// type_name [ ( modifier [, ...] ) ] [ [] ... ], for a type which is
+// referenced by its name.
+func (p *Parser) parseUserDefinedDataType() ast.DataType {
+	typeName := p.parseAnyName()
+	if typeName == nil {
+		return nil
+	}
+	modifiers, ok := p.parseTypeModifiers()
+	if !ok {
+		return nil
+	}
+	var dataType ast.DataType = &ast.DataTypeUserDefined{TypeName: typeName, Modifiers: modifiers}
+	for p.peekToken.Type == token.LBracket {
+		dataType = p.parseArray(dataType)
+		if dataType == nil {
+			return nil
+		}
+	}
+	return dataType
+}
+
+// [ ( modifier [, ...] ) ], e.g. the precision and scale of numeric(10,2).
+func (p *Parser) parseTypeModifiers() ([]ast.Expression, bool) {
+	if p.peekToken.Type != token.LParen {
+		return nil, true
+	}
+	p.advance()
+	p.advance()
+	modifiers := p.parseExpressionList()
+	if modifiers == nil || !p.expectPeek(token.RParen) {
+		return nil, false
+	}
+	return modifiers, true
+}
+
 // Parse: ( n )
 func (p *Parser) parseDataTypeOptionLength() *ast.DataTypeOptionLength {
 	if ok := p.expectPeek(token.Number); !ok {
@@ -542,7 +1249,7 @@ func (p *Parser) parseDataTypeOptionLength() *ast.DataTypeOptionLength {
 	if ok := p.expectPeek(token.RParen); !ok {
 		return nil
 	}
-	return &ast.DataTypeOptionLength{tok}
+	return &ast.DataTypeOptionLength{Token: tok}
 }
 
 func (p *Parser) parseColumnConstraintList() (constraints []ast.ColumnConstraint) {
@@ -577,7 +1284,7 @@ func (p *Parser) parseColumnConstraint() ast.ColumnConstraint {
 	case token.Default:
 		// DEFAULT expr
 		p.advance()
-		expr := p.parseExpression(precedenceLowest)
+		expr := p.parseRestrictedExpression()
 		if expr == nil {
 			return nil
 		}
@@ -585,6 +1292,7 @@ func (p *Parser) parseColumnConstraint() ast.ColumnConstraint {
 			Expr: expr,
 		}
 	default:
+		p.errorf(p.token.Line, "expected column constraint, found %s", p.token.Literal)
 		return nil
 	}
 }
@@ -595,13 +1303,28 @@ func (p *Parser) noPrefixParseFnError(t token.Token) {
 
 func (p *Parser) parseExpression(precedence int) ast.Expression {
 	prefix := p.prefixParseFns[p.token.Type]
-	if prefix == nil {
-		p.noPrefixParseFnError(p.token)
-		return nil
+	if p.isTypedLiteral() {
+		prefix = p.parseTypedLiteral
+	} else if p.isSpecialFunction() {
+		prefix = p.parseSpecialFunction
+	} else if prefix == nil {
+		switch {
+		case p.isIdentifier():
+			prefix = p.parseIdentifierAsExpression
+		case p.isTypeFunctionName() && p.peekToken.Type == token.LParen:
+			// a function named by a type_func_name keyword, such as left(s, 1).
+			prefix = p.parseFunctionNameAsExpression
+		default:
+			p.noPrefixParseFnError(p.token)
+			return nil
+		}
 	}
 	leftExp := prefix()
 
-	for precedence < p.peekPrecedence() {
+	for leftExp != nil && precedence < p.peekPrecedence() {
+		if p.restricted && restrictedOperators[p.peekToken.Type] {
+			return leftExp
+		}
 		infix := p.infixParseFns[p.peekToken.Type]
 		if infix == nil {
 			return leftExp
@@ -613,6 +1336,69 @@ func (p *Parser) parseExpression(precedence int) ast.Expression {
 	return leftExp
 }
 
+// parseRestrictedExpression parses b_expr, e.g. DEFAULT b_expr.
+func (p *Parser) parseRestrictedExpression() ast.Expression {
+	restricted := p.restricted
+	p.restricted = true
+	defer func() { p.restricted = restricted }()
+	return p.parseExpression(precedenceLowest)
+}
+
+// parseNestedExpression parses a_expr enclosed in parentheses or brackets,
+// where the full syntax is available again.
+func (p *Parser) parseNestedExpression() ast.Expression {
+	restricted := p.restricted
+	p.restricted = false
+	defer func() { p.restricted = restricted }()
+	return p.parseExpression(precedenceLowest)
+}
+
+// parseExpressionList parses expr [, ...] and leaves the last expression as
+// the current token.
+func (p *Parser) parseExpressionList() []ast.Expression {
+	var list []ast.Expression
+	for {
+		expr := p.parseNestedExpression()
+		if expr == nil {
+			return nil
+		}
+		list = append(list, expr)
+		if p.peekToken.Type != token.Comma {
+			return list
+		}
+		p.advance()
+		p.advance()
+	}
+}
+
+func (p *Parser) parsePrefixExpression() ast.Expression {
+	expression := &ast.PrefixExpression{
+		Operator: p.token,
+	}
+	precedence := precedencePrefix
+	switch p.token.Type {
+	case token.Not:
+		precedence = precedenceNot
+	case token.Op:
+		precedence = precedenceOp
+	case token.Operator:
+		precedence = precedenceOp
+		schema, operator, ok := p.parseQualifiedOperator()
+		if !ok {
+			return nil
+		}
+		expression.OperatorSchema = schema
+		expression.Operator = operator
+	}
+	p.advance()
+	right := p.parseExpression(precedence)
+	if right == nil {
+		return nil
+	}
+	expression.Right = right
+	return expression
+}
+
 func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	expression := &ast.InfixExpression{
 		Operator: p.token,
@@ -620,8 +1406,23 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	}
 
 	precedence := p.currentPrecedence()
+	if p.token.Type == token.Operator {
+		schema, operator, ok := p.parseQualifiedOperator()
+		if !ok {
+			return nil
+		}
+		expression.OperatorSchema = schema
+		expression.Operator = operator
+	}
 	p.advance()
-	right := p.parseExpression(precedence)
+	var right ast.Expression
+	switch {
+	case (p.token.Type == token.Any || p.token.Type == token.Some || p.token.Type == token.All) &&
+		p.peekToken.Type == token.LParen:
+		right = p.parseSubLinkExpression()
+	default:
+		right = p.parseExpression(precedence)
+	}
 	if right == nil {
 		return nil
 	}
@@ -630,388 +1431,2212 @@ func (p *Parser) parseInfixExpression(left ast.Expression) ast.Expression {
 	return expression
 }
 
-func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
-	expr := &ast.CallExpression{
-		Token:     p.token,
-		Function:  function,
-		Arguments: p.parseCallArguments(),
-	}
-	return expr
-}
-
-func (p *Parser) parseCallArguments() []ast.Expression {
-	var args []ast.Expression
-
-	if p.peekToken.Type == token.RParen {
-		p.advance()
-		return args
+// OPERATOR ( [ schema . ] op )
+func (p *Parser) parseQualifiedOperator() (*ast.Identifier, token.Token, bool) {
+	var schema *ast.Identifier
+	if !p.expectPeek(token.LParen) {
+		return nil, p.token, false
 	}
-
 	p.advance()
-	args = append(args, p.parseExpression(precedenceLowest))
-
-	for p.peekToken.Type == token.Comma {
+	if p.peekToken.Type == token.Dot {
+		schema = p.parseIdentifier()
+		if schema == nil {
+			return nil, p.token, false
+		}
 		p.advance()
 		p.advance()
-		args = append(args, p.parseExpression(precedenceLowest))
 	}
-
+	operator := p.token
+	if !isOperatorToken(operator.Type) {
+		p.errorf(operator.Line, "expected operator, found %s", operator.Literal)
+		return nil, operator, false
+	}
 	if !p.expectPeek(token.RParen) {
-		return nil
+		return nil, operator, false
 	}
-
-	return args
-}
-
-func (p *Parser) parseStringLiteral() ast.Expression {
-	return &ast.StringLiteral{Token: p.token}
-}
-
-func (p *Parser) parseNumberLiteral() ast.Expression {
-	return &ast.NumberLiteral{Token: p.token}
+	return schema, operator, true
 }
 
-func (p *Parser) parseBoolean() ast.Expression {
-	return &ast.BooleanLiteral{Token: p.token}
+func isOperatorToken(typ token.TokenType) bool {
+	switch typ {
+	case token.Op, token.Plus, token.Minus, token.Asterisk, token.Slash, token.Percent, token.Caret,
+		token.LessThan, token.GreaterThan, token.Equal, token.LessEquals, token.GreaterEquals, token.NotEquals:
+		return true
+	default:
+		return false
+	}
 }
 
-func (p *Parser) parseNull() ast.Expression {
-	return &ast.NullLiteral{Token: p.token}
+// { ANY | SOME | ALL } ( expr )
+func (p *Parser) parseSubLinkExpression() ast.Expression {
+	expression := &ast.SubLinkExpression{Quantifier: p.token}
+	p.advance()
+	p.advance()
+	expr := p.parseNestedExpression()
+	if expr == nil || !p.expectPeek(token.RParen) {
+		return nil
+	}
+	expression.Expression = expr
+	return expression
 }
 
-func (p *Parser) parseGroupedExpression() ast.Expression {
+func (p *Parser) parseTypeCastExpression(left ast.Expression) ast.Expression {
 	p.advance()
-	expr := p.parseExpression(precedenceLowest)
-	if !p.expectPeek(token.RParen) {
+	dataType := p.parseCastDataType()
+	if dataType == nil {
 		return nil
 	}
-	return &ast.GroupedExpression{Expression: expr}
+	return &ast.TypeCastExpression{Expression: left, Type: dataType}
 }
 
-func (p *Parser) parseCreateIndexStatement() ast.Statement {
-	createIndexStatement := &ast.CreateIndexStatement{}
-
-	if p.peekToken.Type == token.Unique {
-		createIndexStatement.UniqueIndex = true
-		p.advance()
+// parseCastDataType parses the type of a cast, keeping a type referenced by
+// its name as written.
+func (p *Parser) parseCastDataType() ast.DataType {
+	switch {
+	case p.token.Value == "interval" && !strings.HasPrefix(p.token.Literal, `"`):
+		return p.parseDataType()
+	case p.token.Type == token.Identifier, p.token.Type == token.Text:
+		return p.parseUserDefinedDataType()
+	default:
+		return p.parseDataType()
 	}
+}
 
-	if !p.expectPeek(token.Index) {
+// CAST ( expr AS type )
+func (p *Parser) parseCastExpression() ast.Expression {
+	if !p.expectPeek(token.LParen) {
+		return nil
+	}
+	p.advance()
+	expr := p.parseNestedExpression()
+	if expr == nil || !p.expectPeek(token.As) {
+		return nil
+	}
+	p.advance()
+	dataType := p.parseDataType()
+	if dataType == nil || !p.expectPeek(token.RParen) {
 		return nil
 	}
+	return &ast.CastExpression{Expression: expr, Type: dataType}
+}
 
-	if p.peekToken.Type == token.Concurrently {
-		createIndexStatement.Concurrently = true
-		p.advance()
+// IS [ NOT ] { NULL | TRUE | FALSE | UNKNOWN | DISTINCT FROM expr }, ISNULL, NOTNULL
+func (p *Parser) parseIsExpression(left ast.Expression) ast.Expression {
+	expression := &ast.IsExpression{Expression: left}
+	switch p.token.Type {
+	case token.Isnull:
+		expression.Test = token.Token{Type: token.Null, Literal: "NULL", Line: p.token.Line}
+		return expression
+	case token.Notnull:
+		expression.Not = true
+		expression.Test = token.Token{Type: token.Null, Literal: "NULL", Line: p.token.Line}
+		return expression
 	}
 
-	if p.peekToken.Type == token.If {
-		createIndexStatement.Concurrently = true
+	if p.peekToken.Type == token.Not {
+		expression.Not = true
 		p.advance()
-		if !p.expectPeek(token.Not) {
+	}
+	p.advance()
+	switch p.token.Type {
+	case token.Null, token.True, token.False, token.Unknown:
+		expression.Test = p.token
+	case token.Distinct:
+		expression.Test = p.token
+		if !p.expectPeek(token.From) {
 			return nil
 		}
-		if !p.expectPeek(token.Exists) {
+		p.advance()
+		right := p.parseExpression(precedenceIs)
+		if right == nil {
 			return nil
 		}
-	}
-
-	p.advance()
-	identifier := p.parseIdentifier()
-	if identifier == nil {
+		expression.DistinctFrom = right
+	default:
+		p.errorf(p.token.Line, "expected NULL, TRUE, FALSE, UNKNOWN or DISTINCT, found %s", p.token.Literal)
 		return nil
 	}
-	createIndexStatement.Name = identifier
+	return expression
+}
 
-	if !p.expectPeek(token.On) {
+// NOT { LIKE | ILIKE | SIMILAR TO | IN | BETWEEN }
+func (p *Parser) parseNegatedInfixExpression(left ast.Expression) ast.Expression {
+	p.advance()
+	var expression ast.Expression
+	switch p.token.Type {
+	case token.Like, token.Ilike, token.Similar:
+		expression = p.parseLikeExpression(left)
+		if expression != nil {
+			expression.(*ast.LikeExpression).Not = true
+		}
+	case token.In:
+		expression = p.parseInExpression(left)
+		if expression != nil {
+			expression.(*ast.InExpression).Not = true
+		}
+	case token.Between:
+		expression = p.parseBetweenExpression(left)
+		if expression != nil {
+			expression.(*ast.BetweenExpression).Not = true
+		}
+	default:
+		p.errorf(p.token.Line, "expected LIKE, ILIKE, SIMILAR, IN or BETWEEN, found %s", p.token.Literal)
 		return nil
 	}
+	return expression
+}
 
+func (p *Parser) parseLikeExpression(left ast.Expression) ast.Expression {
+	expression := &ast.LikeExpression{Expression: left, Operator: p.token}
+	if p.token.Type == token.Similar && !p.expectPeek(token.To) {
+		return nil
+	}
 	p.advance()
-	tableName := p.parseTableName()
-	if tableName == nil {
+	pattern := p.parseExpression(precedenceLike)
+	if pattern == nil {
 		return nil
 	}
-	createIndexStatement.TableName = tableName
-
-	if p.peekToken.Type == token.Using {
+	expression.Pattern = pattern
+	if p.peekToken.Type == token.Escape {
 		p.advance()
 		p.advance()
-		identifier := p.parseIdentifier()
-		if identifier == nil {
+		escape := p.parseExpression(precedenceLike)
+		if escape == nil {
 			return nil
 		}
-		createIndexStatement.UsingMethod = identifier
+		expression.Escape = escape
 	}
+	return expression
+}
 
-	p.advance()
-	indexTargets := p.parseIndexTargets()
-	if !p.expectPeek(token.RParen) {
+func (p *Parser) parseInExpression(left ast.Expression) ast.Expression {
+	if !p.expectPeek(token.LParen) {
 		return nil
 	}
-	createIndexStatement.IndexTargets = indexTargets
 	p.advance()
-
-	return createIndexStatement
+	list := p.parseExpressionList()
+	if list == nil || !p.expectPeek(token.RParen) {
+		return nil
+	}
+	return &ast.InExpression{Expression: left, List: list}
 }
 
-// CREATE SEQUENCE "users_id_seq"
-//     START WITH 1
-//     INCREMENT BY 1
-//     NO MINVALUE
-//     NO MAXVALUE
-//     CACHE 1;
-func (p *Parser) parseCreateSequenceStatement() ast.Statement {
-	createSequenceStatement := &ast.CreateSequenceStatement{}
-
-	if !p.expectPeek(token.Sequence) {
+func (p *Parser) parseBetweenExpression(left ast.Expression) ast.Expression {
+	expression := &ast.BetweenExpression{Expression: left}
+	switch p.peekToken.Type {
+	case token.Symmetric:
+		expression.Symmetric = true
+		p.advance()
+	case token.Asymmetric:
+		p.advance()
+	}
+	p.advance()
+	low := p.parseExpression(precedenceLike)
+	if low == nil || !p.expectPeek(token.And) {
 		return nil
 	}
-
 	p.advance()
-	identifier := p.parseIdentifier()
-	if identifier == nil {
+	high := p.parseExpression(precedenceLike)
+	if high == nil {
 		return nil
 	}
-	createSequenceStatement.Name = identifier
+	expression.Low = low
+	expression.High = high
+	return expression
+}
 
-	for p.peekToken.Type != token.Semicolon && p.peekToken.Type != token.EOF {
-		p.advance()
-		p.parseCreateSequenceOption(createSequenceStatement)
+func (p *Parser) parseCollateExpression(left ast.Expression) ast.Expression {
+	p.advance()
+	collation := p.parseAnyName()
+	if collation == nil {
+		return nil
 	}
+	return &ast.CollateExpression{Expression: left, Collation: collation}
+}
 
-	return createSequenceStatement
+// AT TIME ZONE zone
+func (p *Parser) parseAtTimeZoneExpression(left ast.Expression) ast.Expression {
+	if !p.expectPeek(token.Time) || !p.expectPeek(token.Zone) {
+		return nil
+	}
+	p.advance()
+	zone := p.parseExpression(precedenceAt)
+	if zone == nil {
+		return nil
+	}
+	return &ast.AtTimeZoneExpression{Expression: left, Zone: zone}
 }
 
-func (p *Parser) parseCreateSequenceOption(createSequenceStatement *ast.CreateSequenceStatement) {
-	switch p.token.Type {
-	case token.Start:
-		if p.peekToken.Type == token.With {
-			p.advance()
+// CASE [ arg ] WHEN condition THEN result [ ... ] [ ELSE result ] END
+func (p *Parser) parseCaseExpression() ast.Expression {
+	expression := &ast.CaseExpression{}
+	p.advance()
+	if p.token.Type != token.When {
+		argument := p.parseNestedExpression()
+		if argument == nil {
+			return nil
 		}
+		expression.Argument = argument
 		p.advance()
-		createSequenceStatement.StartWith = p.parseNumberLiteral()
-	case token.Increment:
-		if !p.expectPeek(token.By) {
-			return
+	}
+	for p.token.Type == token.When {
+		p.advance()
+		condition := p.parseNestedExpression()
+		if condition == nil || !p.expectPeek(token.Then) {
+			return nil
 		}
 		p.advance()
-		createSequenceStatement.IncrementBy = p.parseNumberLiteral()
-	case token.No:
+		result := p.parseNestedExpression()
+		if result == nil {
+			return nil
+		}
+		expression.Whens = append(expression.Whens, &ast.CaseWhen{Condition: condition, Result: result})
 		p.advance()
-		switch p.token.Type {
-		case token.Maxvalue:
-			createSequenceStatement.NoMaxvalue = true
-		case token.Minvalue:
-			createSequenceStatement.NoMinvalue = true
-		default:
-			p.errorf(p.token.Line, "expected MAXVALUE or MINVALUE, found %s", p.token.Literal)
-			return
+	}
+	if len(expression.Whens) == 0 {
+		p.errorf(p.token.Line, "expected %s, found %s", token.When, p.token.Literal)
+		return nil
+	}
+	if p.token.Type == token.Else {
+		p.advance()
+		result := p.parseNestedExpression()
+		if result == nil {
+			return nil
 		}
-	case token.Cache:
+		expression.Else = result
 		p.advance()
-		createSequenceStatement.Cache = p.parseNumberLiteral()
 	}
+	if p.token.Type != token.End {
+		p.errorf(p.token.Line, "expected %s, found %s", token.End, p.token.Literal)
+		return nil
+	}
+	return expression
 }
 
-// ( target [ASC|DESC], ... )
-func (p *Parser) parseIndexTargets() []*ast.IndexTarget {
-	var indexTargets []*ast.IndexTarget
-	p.advance()
-	if p.token.Type == token.RParen {
-		return indexTargets
+// ARRAY[ elem [, ...] ]
+func (p *Parser) parseArrayExpression() ast.Expression {
+	if !p.expectPeek(token.LBracket) {
+		return nil
 	}
-	for {
-		if p.isIdentifier() {
-			identifier := p.parseIdentifier()
-			indexTarget := &ast.IndexTarget{
-				Node: identifier,
-			}
-
-			// parse operator class
-			switch p.peekToken.Type {
-			case token.TextPatternOps, token.VarcharPatternOps:
-				peekToken := p.peekToken
-				indexTarget.OperatorClass = &peekToken
-				p.advance()
-			}
+	expression := p.parseArrayElements()
+	if expression == nil {
+		return nil
+	}
+	expression.Keyword = true
+	return expression
+}
 
-			// parse sort option
-			switch p.peekToken.Type {
-			case token.Asc:
-				p.advance()
-			case token.Desc:
-				indexTarget.IsDesc = true
-				p.advance()
+// [ elem [, ...] ], where an element may itself be a bracketed sub-array.
+func (p *Parser) parseArrayElements() *ast.ArrayExpression {
+	expression := &ast.ArrayExpression{}
+	if p.peekToken.Type == token.RBracket {
+		p.advance()
+		return expression
+	}
+	for {
+		p.advance()
+		var element ast.Expression
+		if p.token.Type == token.LBracket {
+			if sub := p.parseArrayElements(); sub != nil {
+				element = sub
 			}
-			indexTargets = append(indexTargets, indexTarget)
 		} else {
-			expr := p.parseExpression(precedenceLowest)
-			indexTargets = append(indexTargets, &ast.IndexTarget{Node: expr})
+			element = p.parseNestedExpression()
 		}
-		if p.peekToken.Type == token.RParen {
-			return indexTargets
+		if element == nil {
+			return nil
 		}
-		if !p.expectPeek(token.Comma) {
-			return indexTargets
+		expression.Elements = append(expression.Elements, element)
+		if p.peekToken.Type != token.Comma {
+			break
 		}
 		p.advance()
 	}
-}
-
-func (p *Parser) parseAlterSequenceStatement() ast.Statement {
-	alterSequenceStatement := &ast.AlterSequenceStatement{}
-
-	if !p.expectPeek(token.Sequence) {
+	if !p.expectPeek(token.RBracket) {
 		return nil
 	}
+	return expression
+}
 
+// expr[ index ] or expr[ lower : upper ]
+func (p *Parser) parseSubscriptExpression(left ast.Expression) ast.Expression {
+	expression := &ast.SubscriptExpression{Expression: left}
 	p.advance()
-	sequenceName := p.parseSequenceName()
-	if sequenceName == nil {
+	if p.token.Type != token.Colon {
+		lower := p.parseNestedExpression()
+		if lower == nil {
+			return nil
+		}
+		expression.Lower = lower
+		p.advance()
+	}
+	if p.token.Type == token.Colon {
+		expression.Slice = true
+		p.advance()
+		if p.token.Type != token.RBracket {
+			upper := p.parseNestedExpression()
+			if upper == nil {
+				return nil
+			}
+			expression.Upper = upper
+			p.advance()
+		}
+	}
+	if p.token.Type != token.RBracket {
+		p.errorf(p.token.Line, "expected %s, found %s", token.RBracket, p.token.Literal)
 		return nil
 	}
-	alterSequenceStatement.Name = sequenceName
+	return expression
+}
 
-	if !p.expectPeek(token.Owned) {
+// ROW ( [ expr [, ...] ] )
+func (p *Parser) parseRowExpression() ast.Expression {
+	expression := &ast.RowExpression{Keyword: true}
+	if !p.expectPeek(token.LParen) {
 		return nil
 	}
-	if !p.expectPeek(token.By) {
+	if p.peekToken.Type == token.RParen {
+		p.advance()
+		return expression
+	}
+	p.advance()
+	fields := p.parseExpressionList()
+	if fields == nil || !p.expectPeek(token.RParen) {
 		return nil
 	}
+	expression.Fields = fields
+	return expression
+}
+
+// CURRENT_TIMESTAMP [ ( precision ) ]
+func (p *Parser) parseKeywordFunction() ast.Expression {
+	expression := &ast.KeywordFunction{Token: p.token}
+	switch p.token.Type {
+	case token.CurrentTime, token.CurrentTimestamp, token.Localtime, token.Localtimestamp:
+		if p.peekToken.Type == token.LParen {
+			p.advance()
+			if !p.expectPeek(token.Number) {
+				return nil
+			}
+			expression.Precision = p.parseNumberLiteral()
+			if !p.expectPeek(token.RParen) {
+				return nil
+			}
+		}
+	}
+	return expression
+}
+
+// specialFunctions maps the functions with a syntax of their own to the
+// keywords which may separate their arguments besides a comma.
+var specialFunctions = map[string][]token.TokenType{
+	"coalesce":  nil,
+	"greatest":  nil,
+	"least":     nil,
+	"nullif":    nil,
+	"extract":   {token.From},
+	"position":  {token.In},
+	"substring": {token.From, token.For},
+	"trim":      {token.From},
+}
+
+// isSpecialFunction reports whether the current token starts one of
+// specialFunctions, as opposed to a function or column of that name
+// written as a quoted identifier.
+func (p *Parser) isSpecialFunction() bool {
+	if p.peekToken.Type != token.LParen || !p.token.IsWord() || strings.HasPrefix(p.token.Literal, `"`) {
+		return false
+	}
+	_, ok := specialFunctions[p.token.Value]
+	return ok
+}
 
+// COALESCE ( expr [, ...] ), EXTRACT ( field FROM expr ),
+// POSITION ( b_expr IN b_expr ), SUBSTRING ( expr [ FROM expr ] [ FOR expr ] ),
+// TRIM ( [ BOTH | LEADING | TRAILING ] [ expr ] FROM expr [, ...] ), ...
+func (p *Parser) parseSpecialFunction() ast.Expression {
+	expression := &ast.SpecialFunction{Token: p.token}
+	separators := specialFunctions[p.token.Value]
 	p.advance()
-	ownedByID1 := p.parseIdentifier()
-	if ownedByID1 == nil {
-		return nil
+	switch expression.Token.Value {
+	case "extract":
+		p.advance()
+		if !p.token.IsWord() && p.token.Type != token.String {
+			p.errorf(p.token.Line, "expected field, found %s", p.token.Literal)
+			return nil
+		}
+		expression.Modifier = p.token.Value
+		if p.peekToken.Type != token.From {
+			p.errorf(p.peekToken.Line, "expected %s, found %s", token.From, p.peekToken.Literal)
+			return nil
+		}
+	case "trim":
+		switch p.peekToken.Value {
+		case "both", "leading", "trailing":
+			p.advance()
+			expression.Modifier = strings.ToUpper(p.token.Value)
+		}
 	}
 
-	if !p.expectPeek(token.Dot) {
+	p.advance()
+	keyword := ""
+	if p.token.Type == token.From && expression.Token.Value != "substring" {
+		keyword = "FROM"
+		p.advance()
+	}
+	for {
+		var argument ast.Expression
+		if expression.Token.Value == "position" {
+			argument = p.parseRestrictedExpression()
+		} else {
+			argument = p.parseNestedExpression()
+		}
+		if argument == nil {
+			return nil
+		}
+		expression.Arguments = append(expression.Arguments, argument)
+		expression.Keywords = append(expression.Keywords, keyword)
+
+		keyword = ""
+		if p.peekToken.Type != token.Comma {
+			for _, separator := range separators {
+				if p.peekToken.Type == separator {
+					keyword = strings.ToUpper(p.peekToken.Literal)
+				}
+			}
+			if keyword == "" {
+				break
+			}
+		}
+		p.advance()
+		p.advance()
+	}
+	if expression.Token.Value == "position" && (len(expression.Arguments) != 2 || expression.Keywords[1] != "IN") {
+		p.errorf(p.peekToken.Line, "expected %s, found %s", token.In, p.peekToken.Literal)
 		return nil
 	}
+	if !p.expectPeek(token.RParen) {
+		return nil
+	}
+	return expression
+}
 
+// name.name[.name]
+func (p *Parser) parseQualifiedName(left ast.Expression) ast.Expression {
+	var qualifiedName ast.QualifiedName
+	switch v := left.(type) {
+	case *ast.Identifier:
+		qualifiedName.Identifiers = []*ast.Identifier{v}
+	case *ast.QualifiedName:
+		qualifiedName.Identifiers = v.Identifiers
+	default:
+		p.errorf(p.token.Line, "unexpected %s", p.token.Literal)
+		return nil
+	}
 	p.advance()
-	ownedByID2 := p.parseIdentifier()
-	if ownedByID2 == nil {
+	identifier := p.parseColLabel()
+	if identifier == nil {
 		return nil
 	}
+	qualifiedName.Identifiers = append(qualifiedName.Identifiers, identifier)
+	return &qualifiedName
+}
 
-	if p.peekToken.Type == token.Dot {
+// any_name: name [ . name ... ], such as a collation or a type name.
+func (p *Parser) parseAnyName() ast.Expression {
+	identifier := p.parseIdentifier()
+	if identifier == nil {
+		return nil
+	}
+	if p.peekToken.Type != token.Dot {
+		return identifier
+	}
+	qualifiedName := &ast.QualifiedName{Identifiers: []*ast.Identifier{identifier}}
+	for p.peekToken.Type == token.Dot {
 		p.advance()
 		p.advance()
-		ownedByColumn := p.parseIdentifier()
-		if ownedByColumn == nil {
+		identifier := p.parseColLabel()
+		if identifier == nil {
 			return nil
 		}
-		alterSequenceStatement.OwnedByColumn = ownedByColumn
-		alterSequenceStatement.OwnedByTable = &ast.TableName{
-			SchemaIdentifier: ownedByID1,
-			TableIdentifier:  ownedByID2,
-		}
-	} else {
-		alterSequenceStatement.OwnedByColumn = ownedByID2
-		alterSequenceStatement.OwnedByTable = &ast.TableName{
-			TableIdentifier: ownedByID1,
-		}
+		qualifiedName.Identifiers = append(qualifiedName.Identifiers, identifier)
 	}
+	return qualifiedName
+}
 
-	return alterSequenceStatement
+func (p *Parser) parseCallExpression(function ast.Expression) ast.Expression {
+	expr := &ast.CallExpression{
+		Token:    p.token,
+		Function: function,
+	}
+	args, ok := p.parseCallArguments()
+	if !ok {
+		return nil
+	}
+	expr.Arguments = args
+	return expr
+}
+
+func (p *Parser) parseCallArguments() ([]ast.Expression, bool) {
+	var args []ast.Expression
+
+	if p.peekToken.Type == token.RParen {
+		p.advance()
+		return args, true
+	}
+
+	p.advance()
+	args = p.parseExpressionList()
+	if args == nil {
+		return nil, false
+	}
+
+	if !p.expectPeek(token.RParen) {
+		return nil, false
+	}
+
+	return args, true
+}
+
+// isTypedLiteral reports whether the current token starts type 'string',
+// such as date '2020-01-01' or timestamp with time zone '2020-01-01 00:00'.
+func (p *Parser) isTypedLiteral() bool {
+	switch {
+	case p.token.Type == token.Timestamp && (p.peekToken.Type == token.With || p.peekToken.Type == token.Without):
+		return true
+	case p.token.Type == token.Character && p.peekToken.Type == token.Varying:
+		return true
+	}
+	return p.peekToken.Type == token.String && p.token.IsWord() && p.token.Category() != token.ReservedKeyword
+}
+
+// type 'string' [ field [ TO field ] ], a constant of the type. It is held
+// as the cast PostgreSQL turns it into, such as '1 day'::interval, with the
+// fields of an interval moved onto the type.
+func (p *Parser) parseTypedLiteral() ast.Expression {
+	dataType := p.parseCastDataType()
+	if dataType == nil || !p.expectPeek(token.String) {
+		return nil
+	}
+	literal := &ast.StringLiteral{Token: p.token}
+	if interval, ok := dataType.(*ast.DataTypeInterval); ok && interval.Fields == "" {
+		interval.Fields = p.parseIntervalFields()
+	}
+	return &ast.TypeCastExpression{Expression: literal, Type: dataType}
+}
+
+func (p *Parser) parseStringLiteral() ast.Expression {
+	return &ast.StringLiteral{Token: p.token}
+}
+
+func (p *Parser) parseNumberLiteral() ast.Expression {
+	return &ast.NumberLiteral{Token: p.token}
+}
+
+func (p *Parser) parseBoolean() ast.Expression {
+	return &ast.BooleanLiteral{Token: p.token}
+}
+
+func (p *Parser) parseNull() ast.Expression {
+	return &ast.NullLiteral{Token: p.token}
+}
+
+// ( expr ) or a row constructor ( expr, expr [, ...] )
+func (p *Parser) parseGroupedExpression() ast.Expression {
+	p.advance()
+	list := p.parseExpressionList()
+	if list == nil || !p.expectPeek(token.RParen) {
+		return nil
+	}
+	if len(list) > 1 {
+		return &ast.RowExpression{Fields: list}
+	}
+	return &ast.GroupedExpression{Expression: list[0]}
+}
+
+func (p *Parser) parseCreateIndexStatement() ast.Statement {
+	createIndexStatement := &ast.CreateIndexStatement{}
+
+	if p.peekToken.Type == token.Unique {
+		createIndexStatement.UniqueIndex = true
+		p.advance()
+	}
+
+	if !p.expectPeek(token.Index) {
+		return nil
+	}
+
+	if p.peekToken.Type == token.Concurrently {
+		createIndexStatement.Concurrently = true
+		p.advance()
+	}
+
+	if p.peekToken.Type == token.If {
+		createIndexStatement.IfNotExists = true
+		p.advance()
+		if !p.expectPeek(token.Not) {
+			return nil
+		}
+		if !p.expectPeek(token.Exists) {
+			return nil
+		}
+	}
+
+	p.advance()
+	identifier := p.parseIdentifier()
+	if identifier == nil {
+		return nil
+	}
+	createIndexStatement.Name = identifier
+
+	if !p.expectPeek(token.On) {
+		return nil
+	}
+
+	if p.peekToken.Type == token.Only {
+		createIndexStatement.Only = true
+		p.advance()
+	}
+
+	p.advance()
+	tableName := p.parseTableName()
+	if tableName == nil {
+		return nil
+	}
+	createIndexStatement.TableName = tableName
+
+	if p.peekToken.Type == token.Using {
+		p.advance()
+		p.advance()
+		identifier := p.parseIdentifier()
+		if identifier == nil {
+			return nil
+		}
+		createIndexStatement.UsingMethod = identifier
+	}
+
+	p.advance()
+	indexTargets := p.parseIndexTargets()
+	if !p.expectPeek(token.RParen) {
+		return nil
+	}
+	createIndexStatement.IndexTargets = indexTargets
+
+	if p.peekToken.Type == token.Include {
+		p.advance()
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
+		columnList := p.parseColumnList()
+		if columnList == nil {
+			return nil
+		}
+		createIndexStatement.Include = columnList.ColumnNames
+	}
+
+	if p.peekToken.Type == token.Nulls {
+		p.advance()
+		if p.peekToken.Type == token.Not {
+			p.advance()
+			createIndexStatement.NullsNotDistinct = true
+		}
+		if !p.expectPeek(token.Distinct) {
+			return nil
+		}
+	}
+
+	if p.peekToken.Type == token.With {
+		p.advance()
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
+		storageParameters := p.parseStorageParameters()
+		if storageParameters == nil {
+			return nil
+		}
+		createIndexStatement.StorageParameters = storageParameters
+	}
+
+	if p.peekToken.Type == token.Tablespace {
+		p.advance()
+		p.advance()
+		identifier := p.parseIdentifier()
+		if identifier == nil {
+			return nil
+		}
+		createIndexStatement.Tablespace = identifier
+	}
+
+	if p.peekToken.Type == token.Where {
+		p.advance()
+		p.advance()
+		where := p.parseExpression(precedenceLowest)
+		if where == nil {
+			return nil
+		}
+		createIndexStatement.Where = where
+	}
+
+	switch p.peekToken.Type {
+	case token.Semicolon:
+		p.advance()
+	case token.EOF:
+	default:
+		p.errorf(p.peekToken.Line, "expected %s, found %s", token.Semicolon, p.peekToken.Literal)
+	}
+
+	return createIndexStatement
+}
+
+// ( storage_parameter [= value] [, ... ] )
+func (p *Parser) parseStorageParameters() ast.StorageParameters {
+	var storageParameters ast.StorageParameters
+	for {
+		p.advance()
+		storageParameter := p.parseStorageParameter()
+		if storageParameter == nil {
+			return nil
+		}
+		storageParameters = append(storageParameters, storageParameter)
+		if p.peekToken.Type != token.Comma {
+			break
+		}
+		p.advance()
+	}
+	if !p.expectPeek(token.RParen) {
+		return nil
+	}
+	return storageParameters
+}
+
+// [ namespace . ] name [ = value ]
+func (p *Parser) parseStorageParameter() *ast.StorageParameter {
+	storageParameter := &ast.StorageParameter{}
+	name := p.parseColLabel()
+	if name == nil {
+		return nil
+	}
+	if p.peekToken.Type == token.Dot {
+		p.advance()
+		p.advance()
+		storageParameter.Namespace = name
+		if name = p.parseColLabel(); name == nil {
+			return nil
+		}
+	}
+	storageParameter.Name = name
+	if p.peekToken.Type != token.Equal {
+		return storageParameter
+	}
+	p.advance()
+	p.advance()
+	storageParameter.HasValue = true
+	switch {
+	case p.token.Type == token.String, p.token.IsWord():
+		storageParameter.Value = p.token.Value
+	case p.token.Type == token.Number:
+		storageParameter.Value = p.token.Literal
+	case p.token.Type == token.Minus && p.peekToken.Type == token.Number:
+		p.advance()
+		storageParameter.Value = "-" + p.token.Literal
+	default:
+		p.errorf(p.token.Line, "expected storage parameter value, found %s", p.token.Literal)
+		return nil
+	}
+	return storageParameter
+}
+
+// CREATE COLLATION [ IF NOT EXISTS ] name ( option = value [, ... ] )
+// CREATE COLLATION [ IF NOT EXISTS ] name FROM existing_collation
+func (p *Parser) parseCreateCollationStatement() ast.Statement {
+	createCollationStatement := &ast.CreateCollationStatement{}
+	p.advance()
+	if p.peekToken.Type == token.If {
+		p.advance()
+		if !p.expectPeek(token.Not) || !p.expectPeek(token.Exists) {
+			return nil
+		}
+		createCollationStatement.IfNotExists = true
+	}
+	p.advance()
+	if createCollationStatement.Name = p.parseTableName(); createCollationStatement.Name == nil {
+		return nil
+	}
+	p.advance()
+	switch p.token.Type {
+	case token.From:
+		p.advance()
+		if createCollationStatement.From = p.parseAnyName(); createCollationStatement.From == nil {
+			return nil
+		}
+	case token.LParen:
+		if createCollationStatement.Options = p.parseStorageParameters(); createCollationStatement.Options == nil {
+			return nil
+		}
+	default:
+		p.errorf(p.token.Line, "expected ( or FROM, found %s", p.token.Literal)
+		return nil
+	}
+	return createCollationStatement
+}
+
+// CREATE POLICY name ON table_name
+//     [ AS { PERMISSIVE | RESTRICTIVE } ]
+//     [ FOR { ALL | SELECT | INSERT | UPDATE | DELETE } ]
+//     [ TO role_specification [, ...] ]
+//     [ USING ( using_expression ) ]
+//     [ WITH CHECK ( check_expression ) ]
+func (p *Parser) parseCreatePolicyStatement() ast.Statement {
+	createPolicyStatement := &ast.CreatePolicyStatement{}
+	p.advance()
+	p.advance()
+	if createPolicyStatement.Name = p.parseIdentifier(); createPolicyStatement.Name == nil {
+		return nil
+	}
+	if !p.expectPeek(token.On) {
+		return nil
+	}
+	p.advance()
+	if createPolicyStatement.Table = p.parseTableName(); createPolicyStatement.Table == nil {
+		return nil
+	}
+	if p.peekToken.Type == token.As {
+		p.advance()
+		p.advance()
+		switch p.token.Value {
+		case "permissive":
+		case "restrictive":
+			createPolicyStatement.Restrictive = true
+		default:
+			p.errorf(p.token.Line, "expected PERMISSIVE or RESTRICTIVE, found %s", p.token.Literal)
+			return nil
+		}
+	}
+	if p.peekToken.Type == token.For {
+		p.advance()
+		p.advance()
+		switch p.token.Type {
+		case token.All:
+		case token.Select, token.Insert, token.Update, token.Delete:
+			createPolicyStatement.Command = strings.ToUpper(p.token.Literal)
+		default:
+			p.errorf(p.token.Line, "expected ALL, SELECT, INSERT, UPDATE or DELETE, found %s", p.token.Literal)
+			return nil
+		}
+	}
+	if !p.parsePolicyClauses(&createPolicyStatement.Roles, &createPolicyStatement.Using, &createPolicyStatement.WithCheck) {
+		return nil
+	}
+	return createPolicyStatement
+}
+
+// ALTER POLICY name ON table_name RENAME TO new_name
+// ALTER POLICY name ON table_name
+//     [ TO role_specification [, ...] ]
+//     [ USING ( using_expression ) ]
+//     [ WITH CHECK ( check_expression ) ]
+func (p *Parser) parseAlterPolicyStatement() ast.Statement {
+	alterPolicyStatement := &ast.AlterPolicyStatement{}
+	p.advance()
+	p.advance()
+	if alterPolicyStatement.Name = p.parseIdentifier(); alterPolicyStatement.Name == nil {
+		return nil
+	}
+	if !p.expectPeek(token.On) {
+		return nil
+	}
+	p.advance()
+	if alterPolicyStatement.Table = p.parseTableName(); alterPolicyStatement.Table == nil {
+		return nil
+	}
+	if p.peekToken.Type == token.Rename {
+		p.advance()
+		if !p.expectPeek(token.To) {
+			return nil
+		}
+		p.advance()
+		if alterPolicyStatement.NewName = p.parseIdentifier(); alterPolicyStatement.NewName == nil {
+			return nil
+		}
+		return alterPolicyStatement
+	}
+	if !p.parsePolicyClauses(&alterPolicyStatement.Roles, &alterPolicyStatement.Using, &alterPolicyStatement.WithCheck) {
+		return nil
+	}
+	return alterPolicyStatement
+}
+
+// [ TO role_specification [, ...] ] [ USING ( using_expression ) ] [ WITH CHECK ( check_expression ) ]
+func (p *Parser) parsePolicyClauses(roles *[]*ast.RoleSpecification, using, withCheck *ast.Expression) bool {
+	if p.peekToken.Type == token.To {
+		p.advance()
+		for {
+			p.advance()
+			role := p.parseRoleSpecification()
+			if role == nil {
+				return false
+			}
+			*roles = append(*roles, role)
+			if p.peekToken.Type != token.Comma {
+				break
+			}
+			p.advance()
+		}
+	}
+	if p.peekToken.Type == token.Using {
+		p.advance()
+		if !p.expectPeek(token.LParen) {
+			return false
+		}
+		p.advance()
+		if *using = p.parseNestedExpression(); *using == nil || !p.expectPeek(token.RParen) {
+			return false
+		}
+	}
+	if p.peekToken.Type == token.With {
+		p.advance()
+		if !p.expectPeek(token.Check) || !p.expectPeek(token.LParen) {
+			return false
+		}
+		p.advance()
+		if *withCheck = p.parseNestedExpression(); *withCheck == nil || !p.expectPeek(token.RParen) {
+			return false
+		}
+	}
+	return true
+}
+
+// role_name | PUBLIC | CURRENT_ROLE | CURRENT_USER | SESSION_USER
+func (p *Parser) parseRoleSpecification() *ast.RoleSpecification {
+	switch p.token.Type {
+	case token.CurrentRole, token.CurrentUser, token.SessionUser:
+		return &ast.RoleSpecification{Keyword: strings.ToUpper(p.token.Literal)}
+	case token.Identifier:
+		// PUBLIC is not a keyword, but unquoted it names every role.
+		if p.token.Value == "public" && !strings.HasPrefix(p.token.Literal, `"`) {
+			return &ast.RoleSpecification{Keyword: "PUBLIC"}
+		}
+	}
+	name := p.parseIdentifier()
+	if name == nil {
+		return nil
+	}
+	return &ast.RoleSpecification{Name: name}
+}
+
+// OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] )
+//
+// The current token is OPTIONS. ADD, SET and DROP are only accepted when
+// alterable is set, as CREATE statements only add options.
+func (p *Parser) parseGenericOptions(alterable bool) ast.GenericOptions {
+	if !p.expectPeek(token.LParen) {
+		return nil
+	}
+	var genericOptions ast.GenericOptions
+	for {
+		p.advance()
+		genericOption := &ast.GenericOption{}
+		if alterable {
+			switch p.token.Type {
+			case token.Add, token.Set, token.Drop:
+				genericOption.Action = strings.ToUpper(p.token.Literal)
+				p.advance()
+			}
+		}
+		if genericOption.Name = p.parseColLabel(); genericOption.Name == nil {
+			return nil
+		}
+		if genericOption.Action != "DROP" {
+			if !p.expectPeek(token.String) {
+				return nil
+			}
+			genericOption.Value = p.token.Value
+		}
+		genericOptions = append(genericOptions, genericOption)
+		if p.peekToken.Type != token.Comma {
+			break
+		}
+		p.advance()
+	}
+	if !p.expectPeek(token.RParen) {
+		return nil
+	}
+	return genericOptions
+}
+
+// CREATE SERVER [ IF NOT EXISTS ] server_name [ TYPE 'server_type' ] [ VERSION 'server_version' ]
+//     FOREIGN DATA WRAPPER fdw_name
+//     [ OPTIONS ( option 'value' [, ... ] ) ]
+func (p *Parser) parseCreateServerStatement() ast.Statement {
+	createServerStatement := &ast.CreateServerStatement{}
+	p.advance()
+	if p.peekToken.Type == token.If {
+		p.advance()
+		if !p.expectPeek(token.Not) || !p.expectPeek(token.Exists) {
+			return nil
+		}
+		createServerStatement.IfNotExists = true
+	}
+	p.advance()
+	if createServerStatement.Name = p.parseIdentifier(); createServerStatement.Name == nil {
+		return nil
+	}
+	if p.peekToken.Type == token.Type {
+		p.advance()
+		if !p.expectPeek(token.String) {
+			return nil
+		}
+		createServerStatement.Type = p.token.Value
+	}
+	if p.peekToken.Type == token.Version {
+		p.advance()
+		if !p.expectPeek(token.String) {
+			return nil
+		}
+		createServerStatement.Version = p.token.Value
+	}
+	if !p.expectPeek(token.Foreign) || !p.expectPeek(token.Data) || !p.expectPeek(token.Wrapper) {
+		return nil
+	}
+	p.advance()
+	if createServerStatement.Wrapper = p.parseIdentifier(); createServerStatement.Wrapper == nil {
+		return nil
+	}
+	if p.peekToken.Type == token.Options {
+		p.advance()
+		if createServerStatement.Options = p.parseGenericOptions(false); createServerStatement.Options == nil {
+			return nil
+		}
+	}
+	return createServerStatement
+}
+
+// ALTER SERVER name [ VERSION 'new_version' ] [ OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] ) ]
+//
+// OWNER TO and RENAME TO are not yet implemented.
+func (p *Parser) parseAlterServerStatement() ast.Statement {
+	alterServerStatement := &ast.AlterServerStatement{}
+	p.advance()
+	p.advance()
+	if alterServerStatement.Name = p.parseIdentifier(); alterServerStatement.Name == nil {
+		return nil
+	}
+	switch p.peekToken.Type {
+	case token.Version, token.Options:
+	default:
+		return nil
+	}
+	if p.peekToken.Type == token.Version {
+		p.advance()
+		if !p.expectPeek(token.String) {
+			return nil
+		}
+		alterServerStatement.Version = p.token.Value
+	}
+	if p.peekToken.Type == token.Options {
+		p.advance()
+		if alterServerStatement.Options = p.parseGenericOptions(true); alterServerStatement.Options == nil {
+			return nil
+		}
+	}
+	return alterServerStatement
+}
+
+// CREATE USER MAPPING [ IF NOT EXISTS ] FOR { user_name | USER | CURRENT_ROLE | CURRENT_USER | PUBLIC | SESSION_USER }
+//     SERVER server_name
+//     [ OPTIONS ( option 'value' [ , ... ] ) ]
+//
+// CREATE USER, which creates a role, is not yet implemented.
+func (p *Parser) parseCreateUserMappingStatement() ast.Statement {
+	p.advance()
+	if p.peekToken.Type != token.Mapping {
+		return nil
+	}
+	p.advance()
+	createUserMappingStatement := &ast.CreateUserMappingStatement{}
+	if p.peekToken.Type == token.If {
+		p.advance()
+		if !p.expectPeek(token.Not) || !p.expectPeek(token.Exists) {
+			return nil
+		}
+		createUserMappingStatement.IfNotExists = true
+	}
+	createUserMappingStatement.User, createUserMappingStatement.Server = p.parseUserMapping()
+	if createUserMappingStatement.Server == nil {
+		return nil
+	}
+	if p.peekToken.Type == token.Options {
+		p.advance()
+		if createUserMappingStatement.Options = p.parseGenericOptions(false); createUserMappingStatement.Options == nil {
+			return nil
+		}
+	}
+	return createUserMappingStatement
+}
+
+// ALTER USER MAPPING FOR { user_name | USER | CURRENT_ROLE | CURRENT_USER | PUBLIC | SESSION_USER }
+//     SERVER server_name
+//     OPTIONS ( [ ADD | SET | DROP ] option [ 'value' ] [, ... ] )
+//
+// ALTER USER, which alters a role, is not yet implemented.
+func (p *Parser) parseAlterUserMappingStatement() ast.Statement {
+	p.advance()
+	if p.peekToken.Type != token.Mapping {
+		return nil
+	}
+	p.advance()
+	alterUserMappingStatement := &ast.AlterUserMappingStatement{}
+	alterUserMappingStatement.User, alterUserMappingStatement.Server = p.parseUserMapping()
+	if alterUserMappingStatement.Server == nil || !p.expectPeek(token.Options) {
+		return nil
+	}
+	if alterUserMappingStatement.Options = p.parseGenericOptions(true); alterUserMappingStatement.Options == nil {
+		return nil
+	}
+	return alterUserMappingStatement
+}
+
+// DROP USER MAPPING [ IF EXISTS ] FOR { user_name | USER | CURRENT_ROLE | CURRENT_USER | PUBLIC | SESSION_USER }
+//     SERVER server_name
+//
+// DROP USER, which drops a role, is not yet implemented.
+func (p *Parser) parseDropUserMappingStatement() ast.Statement {
+	p.advance()
+	if p.peekToken.Type != token.Mapping {
+		return nil
+	}
+	p.advance()
+	dropUserMappingStatement := &ast.DropUserMappingStatement{IfExists: p.parseIfExists()}
+	dropUserMappingStatement.User, dropUserMappingStatement.Server = p.parseUserMapping()
+	if dropUserMappingStatement.Server == nil {
+		return nil
+	}
+	return dropUserMappingStatement
+}
+
+// FOR { user_name | USER | CURRENT_ROLE | CURRENT_USER | PUBLIC | SESSION_USER } SERVER server_name
+//
+// The returned server is nil on error.
+func (p *Parser) parseUserMapping() (*ast.RoleSpecification, *ast.Identifier) {
+	if !p.expectPeek(token.For) {
+		return nil, nil
+	}
+	p.advance()
+	var user *ast.RoleSpecification
+	if p.token.Type == token.User {
+		user = &ast.RoleSpecification{Keyword: "CURRENT_USER"}
+	} else if user = p.parseRoleSpecification(); user == nil {
+		return nil, nil
+	}
+	if !p.expectPeek(token.Server) {
+		return nil, nil
+	}
+	p.advance()
+	return user, p.parseIdentifier()
+}
+
+// IMPORT FOREIGN SCHEMA remote_schema
+//     [ { LIMIT TO | EXCEPT } ( table_name [, ...] ) ]
+//     FROM SERVER server_name
+//     INTO local_schema
+//     [ OPTIONS ( option 'value' [, ... ] ) ]
+func (p *Parser) parseImportForeignSchemaStatement() ast.Statement {
+	importForeignSchemaStatement := &ast.ImportForeignSchemaStatement{}
+	if !p.expectPeek(token.Foreign) || !p.expectPeek(token.Schema) {
+		return nil
+	}
+	p.advance()
+	if importForeignSchemaStatement.RemoteSchema = p.parseIdentifier(); importForeignSchemaStatement.RemoteSchema == nil {
+		return nil
+	}
+	switch p.peekToken.Type {
+	case token.Limit:
+		p.advance()
+		if !p.expectPeek(token.To) {
+			return nil
+		}
+		importForeignSchemaStatement.Restriction = "LIMIT TO"
+	case token.Except:
+		p.advance()
+		importForeignSchemaStatement.Restriction = "EXCEPT"
+	}
+	if importForeignSchemaStatement.Restriction != "" {
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
+		for {
+			p.advance()
+			table := p.parseIdentifier()
+			if table == nil {
+				return nil
+			}
+			importForeignSchemaStatement.Tables = append(importForeignSchemaStatement.Tables, table)
+			if p.peekToken.Type != token.Comma {
+				break
+			}
+			p.advance()
+		}
+		if !p.expectPeek(token.RParen) {
+			return nil
+		}
+	}
+	if !p.expectPeek(token.From) || !p.expectPeek(token.Server) {
+		return nil
+	}
+	p.advance()
+	if importForeignSchemaStatement.Server = p.parseIdentifier(); importForeignSchemaStatement.Server == nil {
+		return nil
+	}
+	if !p.expectPeek(token.Into) {
+		return nil
+	}
+	p.advance()
+	if importForeignSchemaStatement.LocalSchema = p.parseIdentifier(); importForeignSchemaStatement.LocalSchema == nil {
+		return nil
+	}
+	if p.peekToken.Type == token.Options {
+		p.advance()
+		if importForeignSchemaStatement.Options = p.parseGenericOptions(false); importForeignSchemaStatement.Options == nil {
+			return nil
+		}
+	}
+	return importForeignSchemaStatement
+}
+
+// CREATE SEQUENCE "users_id_seq"
+//     START WITH 1
+//     INCREMENT BY 1
+//     NO MINVALUE
+//     NO MAXVALUE
+//     CACHE 1;
+func (p *Parser) parseCreateSequenceStatement() ast.Statement {
+	createSequenceStatement := &ast.CreateSequenceStatement{}
+
+	if !p.expectPeek(token.Sequence) {
+		return nil
+	}
+
+	p.advance()
+	identifier := p.parseIdentifier()
+	if identifier == nil {
+		return nil
+	}
+	createSequenceStatement.Name = identifier
+
+	for p.peekToken.Type != token.Semicolon && p.peekToken.Type != token.EOF {
+		p.advance()
+		p.parseCreateSequenceOption(createSequenceStatement)
+	}
+
+	return createSequenceStatement
+}
+
+func (p *Parser) parseCreateSequenceOption(createSequenceStatement *ast.CreateSequenceStatement) {
+	switch p.token.Type {
+	case token.Start:
+		if p.peekToken.Type == token.With {
+			p.advance()
+		}
+		p.advance()
+		createSequenceStatement.StartWith = p.parseNumberLiteral()
+	case token.Increment:
+		if !p.expectPeek(token.By) {
+			return
+		}
+		p.advance()
+		createSequenceStatement.IncrementBy = p.parseNumberLiteral()
+	case token.No:
+		p.advance()
+		switch p.token.Type {
+		case token.Maxvalue:
+			createSequenceStatement.NoMaxvalue = true
+		case token.Minvalue:
+			createSequenceStatement.NoMinvalue = true
+		default:
+			p.errorf(p.token.Line, "expected MAXVALUE or MINVALUE, found %s", p.token.Literal)
+			return
+		}
+	case token.Cache:
+		p.advance()
+		createSequenceStatement.Cache = p.parseNumberLiteral()
+	}
+}
+
+// ( target [ COLLATE collation ] [ opclass ] [ ASC | DESC ] [ NULLS { FIRST | LAST } ], ... )
+func (p *Parser) parseIndexTargets() []*ast.IndexTarget {
+	var indexTargets []*ast.IndexTarget
+	p.advance()
+	if p.token.Type == token.RParen {
+		return indexTargets
+	}
+	for {
+		indexTarget := &ast.IndexTarget{}
+		if p.isIdentifier() && p.peekToken.Type != token.LParen && p.peekToken.Type != token.Dot {
+			indexTarget.Node = p.parseIdentifier()
+		} else {
+			// stop before COLLATE, which belongs to the index target.
+			indexTarget.Node = p.parseExpression(precedenceCollate)
+		}
+		if indexTarget.Node == nil {
+			return nil
+		}
+
+		if p.peekToken.Type == token.Collate {
+			p.advance()
+			p.advance()
+			if indexTarget.Collation = p.parseAnyName(); indexTarget.Collation == nil {
+				return nil
+			}
+		}
+
+		// parse operator class: opclass [ ( opclass_parameter = value [, ... ] ) ]
+		switch p.peekToken.Type {
+		case token.Asc, token.Desc, token.Nulls:
+		default:
+			if p.peekToken.IsWord() {
+				p.advance()
+				if indexTarget.OperatorClass = p.parseAnyName(); indexTarget.OperatorClass == nil {
+					return nil
+				}
+				if p.peekToken.Type == token.LParen {
+					p.advance()
+					if indexTarget.OperatorClassParameters = p.parseStorageParameters(); indexTarget.OperatorClassParameters == nil {
+						return nil
+					}
+				}
+			}
+		}
+
+		// parse sort option
+		switch p.peekToken.Type {
+		case token.Asc:
+			p.advance()
+		case token.Desc:
+			indexTarget.IsDesc = true
+			p.advance()
+		}
+
+		if p.peekToken.Type == token.Nulls {
+			p.advance()
+			p.advance()
+			switch p.token.Type {
+			case token.First:
+				indexTarget.NullsFirst = true
+			case token.Last:
+				indexTarget.NullsLast = true
+			default:
+				p.errorf(p.token.Line, "expected FIRST or LAST, found %s", p.token.Literal)
+			}
+		}
+		indexTargets = append(indexTargets, indexTarget)
+
+		if p.peekToken.Type == token.RParen {
+			return indexTargets
+		}
+		if !p.expectPeek(token.Comma) {
+			return indexTargets
+		}
+		p.advance()
+	}
+}
+
+func (p *Parser) parseAlterIndexStatement() ast.Statement {
+	alterIndexStatement := &ast.AlterIndexStatement{}
+	p.advance()
+	p.advance()
+	if alterIndexStatement.Name = p.parseTableName(); alterIndexStatement.Name == nil {
+		return nil
+	}
+	if !p.expectPeek(token.Attach) {
+		return nil
+	}
+	if !p.expectPeek(token.Partition) {
+		return nil
+	}
+	p.advance()
+	if alterIndexStatement.AttachPartition = p.parseTableName(); alterIndexStatement.AttachPartition == nil {
+		return nil
+	}
+
+	switch p.peekToken.Type {
+	case token.Semicolon:
+		p.advance()
+	case token.EOF:
+	default:
+		p.errorf(p.peekToken.Line, "expected %s, found %s", token.Semicolon, p.peekToken.Literal)
+	}
+
+	return alterIndexStatement
+}
+
+func (p *Parser) parseAlterSequenceStatement() ast.Statement {
+	alterSequenceStatement := &ast.AlterSequenceStatement{}
+
+	if !p.expectPeek(token.Sequence) {
+		return nil
+	}
+
+	p.advance()
+	sequenceName := p.parseSequenceName()
+	if sequenceName == nil {
+		return nil
+	}
+	alterSequenceStatement.Name = sequenceName
+
+	if !p.expectPeek(token.Owned) {
+		return nil
+	}
+	if !p.expectPeek(token.By) {
+		return nil
+	}
+
+	p.advance()
+	ownedByID1 := p.parseIdentifier()
+	if ownedByID1 == nil {
+		return nil
+	}
+
+	if !p.expectPeek(token.Dot) {
+		return nil
+	}
+
+	p.advance()
+	ownedByID2 := p.parseIdentifier()
+	if ownedByID2 == nil {
+		return nil
+	}
+
+	if p.peekToken.Type == token.Dot {
+		p.advance()
+		p.advance()
+		ownedByColumn := p.parseIdentifier()
+		if ownedByColumn == nil {
+			return nil
+		}
+		alterSequenceStatement.OwnedByColumn = ownedByColumn
+		alterSequenceStatement.OwnedByTable = &ast.TableName{
+			SchemaIdentifier: ownedByID1,
+			TableIdentifier:  ownedByID2,
+		}
+	} else {
+		alterSequenceStatement.OwnedByColumn = ownedByID2
+		alterSequenceStatement.OwnedByTable = &ast.TableName{
+			TableIdentifier: ownedByID1,
+		}
+	}
+
+	return alterSequenceStatement
+}
+
+// ALTER TABLE ONLY users ADD CONSTRAINT users_name_key UNIQUE (name);
+// ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+// DROP { TABLE | VIEW | INDEX [ CONCURRENTLY ] | SEQUENCE | FUNCTION | TYPE | SCHEMA | COLLATION |
+//     FOREIGN TABLE | SERVER | FOREIGN DATA WRAPPER }
+//     [ IF EXISTS ] name [, ...] [ CASCADE | RESTRICT ]
+// DROP POLICY [ IF EXISTS ] name ON table_name [ CASCADE | RESTRICT ]
+func (p *Parser) parseDropStatement() ast.Statement {
+	dropStatement := &ast.DropStatement{}
+	p.advance()
+	switch p.token.Type {
+	case token.Table, token.View, token.Index, token.Sequence, token.Function, token.Type, token.Schema, token.Collation, token.Policy, token.Server:
+		dropStatement.ObjectType = strings.ToUpper(p.token.Literal)
+	case token.Foreign:
+		p.advance()
+		switch p.token.Type {
+		case token.Table:
+			dropStatement.ObjectType = "FOREIGN TABLE"
+		case token.Data:
+			if !p.expectPeek(token.Wrapper) {
+				return nil
+			}
+			dropStatement.ObjectType = "FOREIGN DATA WRAPPER"
+		default:
+			p.errorf(p.token.Line, "unknown token: DROP FOREIGN %s", p.token.Literal)
+			return nil
+		}
+	default:
+		p.errorf(p.token.Line, "unknown token: DROP %s", p.token.Literal)
+		return nil
+	}
+	objectType := p.token.Type
+	if objectType == token.Index && p.peekToken.Type == token.Concurrently {
+		p.advance()
+		dropStatement.Concurrently = true
+	}
+	dropStatement.IfExists = p.parseIfExists()
+
+	for {
+		p.advance()
+		var name *ast.TableName
+		if objectType == token.Schema || objectType == token.Policy || objectType == token.Server || objectType == token.Wrapper {
+			identifier := p.parseIdentifier()
+			if identifier == nil {
+				return nil
+			}
+			name = &ast.TableName{TableIdentifier: identifier}
+		} else {
+			name = p.parseTableName()
+			if name == nil {
+				return nil
+			}
+		}
+		dropStatement.Names = append(dropStatement.Names, name)
+		if objectType == token.Policy {
+			// A policy is dropped one at a time, from the table it is on.
+			if !p.expectPeek(token.On) {
+				return nil
+			}
+			p.advance()
+			if dropStatement.Table = p.parseTableName(); dropStatement.Table == nil {
+				return nil
+			}
+			break
+		}
+		if objectType == token.Function && p.peekToken.Type == token.LParen {
+			p.advance()
+			if !p.skipParenthesized() {
+				return nil
+			}
+		}
+		if p.peekToken.Type != token.Comma {
+			break
+		}
+		p.advance()
+	}
+	dropStatement.Cascade = p.parseDropBehavior()
+	return dropStatement
+}
+
+// skipParenthesized skips from the current "(" to its matching ")".
+func (p *Parser) skipParenthesized() bool {
+	depth := 0
+	for {
+		switch p.token.Type {
+		case token.LParen:
+			depth++
+		case token.RParen:
+			depth--
+			if depth == 0 {
+				return true
+			}
+		case token.Semicolon, token.EOF:
+			p.errorf(p.token.Line, "expected %s, found %s", token.RParen, p.token.Literal)
+			return false
+		}
+		p.advance()
+	}
+}
+
+// ALTER TABLE [ IF EXISTS ] [ ONLY ] name [ * ] action [, ... ]
+// ALTER TABLE [ IF EXISTS ] [ ONLY ] name [ * ] RENAME [ COLUMN ] column_name TO new_column_name
+// ALTER TABLE [ IF EXISTS ] [ ONLY ] name [ * ] RENAME CONSTRAINT constraint_name TO new_constraint_name
+// ALTER TABLE [ IF EXISTS ] name RENAME TO new_name
+// ALTER TABLE [ IF EXISTS ] name SET SCHEMA new_schema
+func (p *Parser) parseAlterTableStatement() ast.Statement {
+	alterTableStatement := &ast.AlterTableStatement{}
+
+	if p.peekToken.Type == token.Foreign {
+		p.advance()
+		alterTableStatement.Foreign = true
+	}
+	if !p.expectPeek(token.Table) {
+		return nil
+	}
+
+	if p.peekToken.Type == token.If {
+		p.advance()
+		if !p.expectPeek(token.Exists) {
+			return nil
+		}
+		alterTableStatement.IfExists = true
+	}
+
+	if p.peekToken.Type == token.Only {
+		alterTableStatement.Only = true
+		p.advance()
+	}
+
+	p.advance()
+	tableName := p.parseTableName()
+	if tableName == nil {
+		return nil
+	}
+	alterTableStatement.Name = tableName
+	if p.peekToken.Type == token.Asterisk {
+		p.advance()
+	}
+
+	for {
+		p.advance()
+		action := p.parseAlterTableAction()
+		if action == nil {
+			return nil
+		}
+		alterTableStatement.Actions = append(alterTableStatement.Actions, action)
+		if p.peekToken.Type != token.Comma {
+			break
+		}
+		p.advance()
+	}
+
+	switch p.peekToken.Type {
+	case token.Semicolon:
+		p.advance()
+	case token.EOF:
+	default:
+		p.errorf(p.peekToken.Line, "expected %s, found %s", token.Semicolon, p.peekToken.Literal)
+	}
+
+	return alterTableStatement
+}
+
+func (p *Parser) parseAlterTableAction() ast.Node {
+	switch p.token.Type {
+	case token.Add:
+		switch p.peekToken.Type {
+		case token.Constraint, token.Check, token.Unique, token.Primary, token.Foreign:
+			p.advance()
+			return p.parseTableConstraint()
+		}
+		return p.parseAddColumn()
+	case token.Drop:
+		if p.peekToken.Type == token.Constraint {
+			p.advance()
+			return p.parseDropConstraint()
+		}
+		return p.parseDropColumn()
+	case token.Alter:
+		return p.parseAlterColumn()
+	case token.Rename:
+		return p.parseRename()
+	case token.Set:
+		return p.parseAlterTableSet()
+	case token.Reset:
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
+		parameters := p.parseStorageParameters()
+		if parameters == nil {
+			return nil
+		}
+		return &ast.SetStorageParameters{Reset: true, Parameters: parameters}
+	case token.Enable, token.Disable:
+		if p.peekToken.Type == token.Row {
+			return p.parseRowLevelSecurity(&ast.RowLevelSecurity{Enable: p.token.Type == token.Enable})
+		}
+		return p.parseAlterTrigger()
+	case token.Force:
+		return p.parseRowLevelSecurity(&ast.RowLevelSecurity{Force: true, Enable: true})
+	case token.Replica:
+		return p.parseReplicaIdentity()
+	case token.Options:
+		options := p.parseGenericOptions(true)
+		if options == nil {
+			return nil
+		}
+		return &ast.SetOptions{Options: options}
+	case token.Cluster:
+		if !p.expectPeek(token.On) {
+			return nil
+		}
+		p.advance()
+		clusterOn := &ast.ClusterOn{Index: p.parseIdentifier()}
+		if clusterOn.Index == nil {
+			return nil
+		}
+		return clusterOn
+	case token.No:
+		if p.peekToken.Type == token.Force {
+			p.advance()
+			return p.parseRowLevelSecurity(&ast.RowLevelSecurity{Force: true})
+		}
+		fallthrough
+	case token.Inherit:
+		inherit := &ast.Inherit{No: p.token.Type == token.No}
+		if inherit.No && !p.expectPeek(token.Inherit) {
+			return nil
+		}
+		p.advance()
+		inherit.Parent = p.parseTableName()
+		if inherit.Parent == nil {
+			return nil
+		}
+		return inherit
+	case token.Attach:
+		if !p.expectPeek(token.Partition) {
+			return nil
+		}
+		p.advance()
+		attachPartition := &ast.AttachPartition{Name: p.parseTableName()}
+		if attachPartition.Name == nil {
+			return nil
+		}
+		p.advance()
+		attachPartition.Bound = p.parsePartitionBound()
+		if attachPartition.Bound == nil {
+			return nil
+		}
+		return attachPartition
+	case token.Detach:
+		if !p.expectPeek(token.Partition) {
+			return nil
+		}
+		p.advance()
+		detachPartition := &ast.DetachPartition{Name: p.parseTableName()}
+		if detachPartition.Name == nil {
+			return nil
+		}
+		switch p.peekToken.Type {
+		case token.Concurrently:
+			p.advance()
+			detachPartition.Concurrently = true
+		case token.Finalize:
+			p.advance()
+			detachPartition.Finalize = true
+		}
+		return detachPartition
+	case token.Owner:
+		if !p.expectPeek(token.To) {
+			return nil
+		}
+		p.advance()
+		ownerTo := &ast.OwnerTo{}
+		switch p.token.Type {
+		case token.CurrentRole, token.CurrentUser, token.SessionUser:
+			ownerTo.Keyword = strings.ToUpper(p.token.Literal)
+		default:
+			ownerTo.Owner = p.parseIdentifier()
+			if ownerTo.Owner == nil {
+				return nil
+			}
+		}
+		return ownerTo
+	default:
+		p.errorf(p.token.Line, "unknown ALTER TABLE action: %s", p.token.Literal)
+		return nil
+	}
+}
+
+// REPLICA IDENTITY { DEFAULT | USING INDEX index_name | FULL | NOTHING }
+func (p *Parser) parseReplicaIdentity() ast.Node {
+	if !p.expectPeek(token.Identity) {
+		return nil
+	}
+	p.advance()
+	replicaIdentity := &ast.ReplicaIdentity{}
+	switch p.token.Type {
+	case token.Default, token.Full, token.Nothing:
+		replicaIdentity.Identity = strings.ToUpper(p.token.Literal)
+	case token.Using:
+		if !p.expectPeek(token.Index) {
+			return nil
+		}
+		p.advance()
+		if replicaIdentity.Index = p.parseIdentifier(); replicaIdentity.Index == nil {
+			return nil
+		}
+	default:
+		p.errorf(p.token.Line, "expected DEFAULT, USING INDEX, FULL or NOTHING, found %s", p.token.Literal)
+		return nil
+	}
+	return replicaIdentity
+}
+
+// { ENABLE | DISABLE | FORCE | NO FORCE } ROW LEVEL SECURITY
+func (p *Parser) parseRowLevelSecurity(rowLevelSecurity *ast.RowLevelSecurity) ast.Node {
+	if !p.expectPeek(token.Row) || !p.expectPeek(token.Level) || !p.expectPeek(token.Security) {
+		return nil
+	}
+	return rowLevelSecurity
+}
+
+// SET SCHEMA new_schema |
+// SET { LOGGED | UNLOGGED } |
+// SET ( storage_parameter [= value] [, ... ] ) |
+// SET TABLESPACE new_tablespace |
+// SET ACCESS METHOD new_access_method |
+// SET WITHOUT CLUSTER
+func (p *Parser) parseAlterTableSet() ast.Node {
+	p.advance()
+	switch p.token.Type {
+	case token.Schema:
+		p.advance()
+		schema := p.parseIdentifier()
+		if schema == nil {
+			return nil
+		}
+		return &ast.SetSchema{Schema: schema}
+	case token.Logged, token.Unlogged:
+		return &ast.SetLogged{Logged: p.token.Type == token.Logged}
+	case token.LParen:
+		parameters := p.parseStorageParameters()
+		if parameters == nil {
+			return nil
+		}
+		return &ast.SetStorageParameters{Parameters: parameters}
+	case token.Tablespace:
+		p.advance()
+		tablespace := p.parseIdentifier()
+		if tablespace == nil {
+			return nil
+		}
+		return &ast.SetTablespace{Tablespace: tablespace}
+	case token.Access:
+		if !p.expectPeek(token.Method) {
+			return nil
+		}
+		p.advance()
+		accessMethod := p.parseIdentifier()
+		if accessMethod == nil {
+			return nil
+		}
+		return &ast.SetAccessMethod{AccessMethod: accessMethod}
+	case token.Without:
+		if !p.expectPeek(token.Cluster) {
+			return nil
+		}
+		return &ast.SetWithoutCluster{}
+	default:
+		p.errorf(p.token.Line, "unknown ALTER TABLE action: SET %s", p.token.Literal)
+		return nil
+	}
+}
+
+// ADD [ COLUMN ] [ IF NOT EXISTS ] column_name data_type [ column_constraint [ ... ] ]
+func (p *Parser) parseAddColumn() ast.Node {
+	addColumn := &ast.AddColumn{}
+	if p.peekToken.Type == token.Column {
+		p.advance()
+	}
+	if p.peekToken.Type == token.If {
+		p.advance()
+		if !p.expectPeek(token.Not) || !p.expectPeek(token.Exists) {
+			return nil
+		}
+		addColumn.IfNotExists = true
+	}
+	p.advance()
+	columnDefinition := p.parseColumnDefinition()
+	if columnDefinition == nil {
+		return nil
+	}
+	addColumn.ColumnDefinition = columnDefinition
+	return addColumn
+}
+
+// DROP [ COLUMN ] [ IF EXISTS ] column_name [ RESTRICT | CASCADE ]
+func (p *Parser) parseDropColumn() ast.Node {
+	dropColumn := &ast.DropColumn{}
+	if p.peekToken.Type == token.Column {
+		p.advance()
+	}
+	dropColumn.IfExists = p.parseIfExists()
+	p.advance()
+	column := p.parseIdentifier()
+	if column == nil {
+		return nil
+	}
+	dropColumn.Column = column
+	dropColumn.Cascade = p.parseDropBehavior()
+	return dropColumn
+}
+
+// DROP CONSTRAINT [ IF EXISTS ] constraint_name [ RESTRICT | CASCADE ]
+func (p *Parser) parseDropConstraint() ast.Node {
+	dropConstraint := &ast.DropConstraint{}
+	dropConstraint.IfExists = p.parseIfExists()
+	p.advance()
+	name := p.parseIdentifier()
+	if name == nil {
+		return nil
+	}
+	dropConstraint.Name = name
+	dropConstraint.Cascade = p.parseDropBehavior()
+	return dropConstraint
+}
+
+// parseIfExists consumes an optional IF EXISTS following the current token.
+func (p *Parser) parseIfExists() bool {
+	if p.peekToken.Type != token.If {
+		return false
+	}
+	p.advance()
+	return p.expectPeek(token.Exists)
+}
+
+// parseDropBehavior consumes an optional RESTRICT or CASCADE and reports
+// whether it was CASCADE.
+func (p *Parser) parseDropBehavior() bool {
+	switch p.peekToken.Type {
+	case token.Restrict:
+		p.advance()
+	case token.Cascade:
+		p.advance()
+		return true
+	}
+	return false
+}
+
+// ALTER [ COLUMN ] column_name [ SET DATA ] TYPE data_type [ USING expression ]
+// ALTER [ COLUMN ] column_name SET DEFAULT expression
+// ALTER [ COLUMN ] column_name DROP DEFAULT
+// ALTER [ COLUMN ] column_name { SET | DROP } NOT NULL
+// ALTER [ COLUMN ] column_name SET STATISTICS integer
+// ALTER [ COLUMN ] column_name SET STORAGE { PLAIN | EXTERNAL | EXTENDED | MAIN | DEFAULT }
+// ALTER [ COLUMN ] column_name SET COMPRESSION compression_method
+func (p *Parser) parseAlterColumn() ast.Node {
+	if p.peekToken.Type == token.Column {
+		p.advance()
+	}
+	p.advance()
+
+	column := p.parseIdentifier()
+	if column == nil {
+		return nil
+	}
+	p.advance()
+	switch p.token.Type {
+	case token.Set:
+		p.advance()
+		switch p.token.Type {
+		case token.Default:
+			p.advance()
+			expr := p.parseExpression(precedenceLowest)
+			if expr == nil {
+				return nil
+			}
+			return &ast.AlterColumnSetDefault{
+				Column: column,
+				Expr:   expr,
+			}
+		case token.Not:
+			if !p.expectPeek(token.Null) {
+				return nil
+			}
+			return &ast.AlterColumnNotNull{Column: column, NotNull: true}
+		case token.Data:
+			if !p.expectPeek(token.Type) {
+				return nil
+			}
+			return p.parseAlterColumnType(column)
+		case token.Statistics:
+			p.advance()
+			sign := 1
+			if p.token.Type == token.Minus {
+				sign = -1
+				p.advance()
+			}
+			statistics, err := strconv.Atoi(p.token.Literal)
+			if p.token.Type != token.Number || err != nil {
+				p.errorf(p.token.Line, "expected integer, found %s", p.token.Literal)
+				return nil
+			}
+			return &ast.AlterColumnSetStatistics{Column: column, Statistics: sign * statistics}
+		case token.Storage:
+			p.advance()
+			storage := p.parseStorageMode()
+			if storage == "" {
+				return nil
+			}
+			return &ast.AlterColumnSetStorage{Column: column, Storage: storage}
+		case token.Compression:
+			p.advance()
+			alterColumnSetCompression := &ast.AlterColumnSetCompression{Column: column}
+			if p.token.Type != token.Default {
+				if alterColumnSetCompression.Compression = p.parseIdentifier(); alterColumnSetCompression.Compression == nil {
+					return nil
+				}
+			}
+			return alterColumnSetCompression
+		}
+	case token.Drop:
+		p.advance()
+		switch p.token.Type {
+		case token.Default:
+			return &ast.AlterColumnDropDefault{Column: column}
+		case token.Not:
+			if !p.expectPeek(token.Null) {
+				return nil
+			}
+			return &ast.AlterColumnNotNull{Column: column, NotNull: false}
+		}
+	case token.Type:
+		return p.parseAlterColumnType(column)
+	case token.Options:
+		options := p.parseGenericOptions(true)
+		if options == nil {
+			return nil
+		}
+		return &ast.AlterColumnOptions{Column: column, Options: options}
+	}
+	p.errorf(p.token.Line, "unknown ALTER COLUMN action: %s", p.token.Literal)
+	return nil
 }
 
-// ALTER TABLE ONLY users ADD CONSTRAINT users_name_key UNIQUE (name);
-// ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
-func (p *Parser) parseAlterTableStatement() ast.Statement {
-	alterTableStatement := &ast.AlterTableStatement{}
+// { PLAIN | EXTERNAL | EXTENDED | MAIN | DEFAULT }
+func (p *Parser) parseStorageMode() string {
+	switch p.token.Value {
+	case "plain", "external", "extended", "main", "default":
+		return strings.ToUpper(p.token.Value)
+	}
+	p.errorf(p.token.Line, "expected PLAIN, EXTERNAL, EXTENDED, MAIN or DEFAULT, found %s", p.token.Literal)
+	return ""
+}
 
-	if !p.expectPeek(token.Table) {
+func (p *Parser) parseAlterColumnType(column *ast.Identifier) ast.Node {
+	p.advance()
+	dataType := p.parseDataType()
+	if dataType == nil {
 		return nil
 	}
-
-	if p.peekToken.Type == token.Only {
-		alterTableStatement.Only = true
+	alterColumnType := &ast.AlterColumnType{Column: column, Type: dataType}
+	if p.peekToken.Type == token.Collate {
+		p.advance()
 		p.advance()
+		if alterColumnType.Collation = p.parseAnyName(); alterColumnType.Collation == nil {
+			return nil
+		}
 	}
-
-	p.advance()
-	tableName := p.parseTableName()
-	if tableName == nil {
-		return nil
+	if p.peekToken.Type == token.Using {
+		p.advance()
+		p.advance()
+		using := p.parseExpression(precedenceLowest)
+		if using == nil {
+			return nil
+		}
+		alterColumnType.Using = using
 	}
-	alterTableStatement.Name = tableName
+	return alterColumnType
+}
 
+// RENAME [ COLUMN ] column_name TO new_column_name
+// RENAME CONSTRAINT constraint_name TO new_constraint_name
+// RENAME TO new_name
+func (p *Parser) parseRename() ast.Node {
 	switch p.peekToken.Type {
-	case token.Add:
+	case token.To:
 		p.advance()
-		if p.peekToken.Type == token.Constraint {
-			p.advance()
-			tableConstraint := p.parseTableConstraint()
-			if tableConstraint == nil {
-				return nil
-			}
-			alterTableStatement.Actions = append(alterTableStatement.Actions, tableConstraint)
-			return alterTableStatement
-		} else {
-			p.errorf(p.peekToken.Line, "expected %s, found %s", token.Constraint, p.peekToken.Literal)
+		p.advance()
+		newName := p.parseIdentifier()
+		if newName == nil {
 			return nil
 		}
-	case token.Alter:
+		return &ast.RenameTable{NewName: newName}
+	case token.Constraint:
 		p.advance()
-		if p.peekToken.Type == token.Column {
-			p.advance()
+		p.advance()
+		name := p.parseIdentifier()
+		if name == nil || !p.expectPeek(token.To) {
+			return nil
 		}
 		p.advance()
-
-		column := p.parseIdentifier()
-		if column == nil {
+		newName := p.parseIdentifier()
+		if newName == nil {
 			return nil
 		}
+		return &ast.RenameConstraint{Name: name, NewName: newName}
+	case token.Column:
 		p.advance()
-		if p.token.Type == token.Set && p.peekToken.Type == token.Default {
-			p.advance()
+	}
+	p.advance()
+	column := p.parseIdentifier()
+	if column == nil || !p.expectPeek(token.To) {
+		return nil
+	}
+	p.advance()
+	newName := p.parseIdentifier()
+	if newName == nil {
+		return nil
+	}
+	return &ast.RenameColumn{Column: column, NewName: newName}
+}
+
+// { ENABLE [ REPLICA | ALWAYS ] | DISABLE } TRIGGER [ trigger_name | ALL | USER ]
+func (p *Parser) parseAlterTrigger() ast.Node {
+	alterTrigger := &ast.AlterTrigger{Mode: "DISABLE"}
+	if p.token.Type == token.Enable {
+		alterTrigger.Mode = "ENABLE"
+		switch p.peekToken.Type {
+		case token.Replica, token.Always:
 			p.advance()
-			expr := p.parseExpression(precedenceLowest)
-			if expr == nil {
-				return nil
-			}
-			alterColumnSetDefault := &ast.AlterColumnSetDefault{
-				Column: column,
-				Expr:   expr,
-			}
-			alterTableStatement.Actions = append(alterTableStatement.Actions, alterColumnSetDefault)
-			return alterTableStatement
+			alterTrigger.Mode += " " + strings.ToUpper(p.token.Literal)
 		}
 	}
-
-	return alterTableStatement
+	if !p.expectPeek(token.Trigger) {
+		return nil
+	}
+	p.advance()
+	switch p.token.Type {
+	case token.All, token.User:
+		alterTrigger.Scope = strings.ToUpper(p.token.Literal)
+	default:
+		name := p.parseIdentifier()
+		if name == nil {
+			return nil
+		}
+		alterTrigger.Name = name
+	}
+	return alterTrigger
 }
 
 // [ CONSTRAINT constraint_name ]
 // { CHECK ( expression ) [ NO INHERIT ] |
-//   UNIQUE ( column_name [, ... ] ) index_parameters |
+//   UNIQUE [ NULLS [ NOT ] DISTINCT ] ( column_name [, ... ] ) index_parameters |
 //   PRIMARY KEY ( column_name [, ... ] ) index_parameters |
-//   EXCLUDE [ USING index_method ] ( exclude_element WITH operator [, ... ] ) index_parameters [ WHERE ( predicate ) ] |
 //   FOREIGN KEY ( column_name [, ... ] ) REFERENCES reftable [ ( refcolumn [, ... ] ) ]
 //     [ MATCH FULL | MATCH PARTIAL | MATCH SIMPLE ] [ ON DELETE action ] [ ON UPDATE action ] }
-// [ DEFERRABLE | NOT DEFERRABLE ] [ INITIALLY DEFERRED | INITIALLY IMMEDIATE ]
-func (p *Parser) parseTableConstraint() ast.Node {
+// [ DEFERRABLE | NOT DEFERRABLE ] [ INITIALLY DEFERRED | INITIALLY IMMEDIATE ] [ NOT VALID ]
+//
+// EXCLUDE constraints are not supported.
+func (p *Parser) parseTableConstraint() *ast.TableConstraint {
 	tableConstraint := &ast.TableConstraint{}
 	if p.token.Type == token.Constraint {
 		p.advance()
 		identifier := p.parseIdentifier()
+		if identifier == nil {
+			return nil
+		}
 		tableConstraint.Name = identifier
+		p.advance()
 	}
-	if p.peekToken.Type == token.Unique {
-		tableConstraint.Unique = true
+	switch p.token.Type {
+	case token.Check:
+		if !p.expectPeek(token.LParen) {
+			return nil
+		}
 		p.advance()
+		check := p.parseNestedExpression()
+		if check == nil || !p.expectPeek(token.RParen) {
+			return nil
+		}
+		tableConstraint.Check = check
+		if p.peekToken.Type == token.No {
+			p.advance()
+			if !p.expectPeek(token.Inherit) {
+				return nil
+			}
+			tableConstraint.NoInherit = true
+		}
+	case token.Unique:
+		tableConstraint.Unique = true
+		if p.peekToken.Type == token.Nulls {
+			p.advance()
+			if p.peekToken.Type == token.Not {
+				p.advance()
+				tableConstraint.NullsNotDistinct = true
+			}
+			if !p.expectPeek(token.Distinct) {
+				return nil
+			}
+		}
 		p.advance()
 		columnList := p.parseColumnList()
 		if columnList == nil {
 			return nil
 		}
 		tableConstraint.ColumnList = columnList
-	}
-	if p.peekToken.Type == token.Primary {
-		p.advance()
+		tableConstraint.IndexParameters = p.parseIndexParameters()
+	case token.Primary:
 		if !p.expectPeek(token.Key) {
 			return nil
 		}
@@ -1022,8 +3647,189 @@ func (p *Parser) parseTableConstraint() ast.Node {
 			return nil
 		}
 		tableConstraint.ColumnList = columnList
+		tableConstraint.IndexParameters = p.parseIndexParameters()
+	case token.Foreign:
+		if !p.expectPeek(token.Key) {
+			return nil
+		}
+		tableConstraint.ForeignKey = true
+		p.advance()
+		columnList := p.parseColumnList()
+		if columnList == nil {
+			return nil
+		}
+		tableConstraint.ColumnList = columnList
+		if !p.expectPeek(token.References) {
+			return nil
+		}
+		references := p.parseReferences()
+		if references == nil {
+			return nil
+		}
+		tableConstraint.References = references
+	default:
+		p.errorf(p.token.Line, "expected table constraint, found %s", p.token.Literal)
+		return nil
+	}
+
+	for {
+		switch {
+		case p.peekToken.Type == token.Deferrable:
+			p.advance()
+			tableConstraint.Deferrable = true
+		case p.peekToken.Type == token.Not:
+			p.advance()
+			switch p.peekToken.Type {
+			case token.Deferrable:
+				tableConstraint.Deferrable = false
+			case token.Valid:
+				tableConstraint.NotValid = true
+			default:
+				p.errorf(p.peekToken.Line, "expected DEFERRABLE or VALID, found %s", p.peekToken.Literal)
+				return nil
+			}
+			p.advance()
+		case p.peekToken.Type == token.Initially:
+			p.advance()
+			p.advance()
+			switch p.token.Type {
+			case token.Deferred:
+				tableConstraint.Deferrable = true
+				tableConstraint.Deferred = true
+			case token.Immediate:
+				tableConstraint.Deferred = false
+			default:
+				p.errorf(p.token.Line, "expected DEFERRED or IMMEDIATE, found %s", p.token.Literal)
+				return nil
+			}
+		default:
+			return tableConstraint
+		}
+	}
+}
+
+// [ INCLUDE ( column_name [, ... ] ) ]
+// [ WITH ( storage_parameter [= value] [, ... ] ) ]
+// [ USING INDEX TABLESPACE tablespace_name ]
+func (p *Parser) parseIndexParameters() *ast.IndexParameters {
+	var indexParameters ast.IndexParameters
+	if p.peekToken.Type == token.Include {
+		p.advance()
+		p.advance()
+		if columnList := p.parseColumnList(); columnList != nil {
+			indexParameters.Include = columnList.ColumnNames
+		}
+	}
+	if p.peekToken.Type == token.With {
+		p.advance()
+		if p.expectPeek(token.LParen) {
+			indexParameters.StorageParameters = p.parseStorageParameters()
+		}
+	}
+	if p.peekToken.Type == token.Using {
+		p.advance()
+		if p.expectPeek(token.Index) && p.expectPeek(token.Tablespace) {
+			p.advance()
+			indexParameters.Tablespace = p.parseIdentifier()
+		}
+	}
+	if indexParameters.Include == nil && indexParameters.StorageParameters == nil && indexParameters.Tablespace == nil {
+		return nil
+	}
+	return &indexParameters
+}
+
+// REFERENCES reftable [ ( refcolumn [, ... ] ) ]
+// [ MATCH FULL | MATCH PARTIAL | MATCH SIMPLE ] [ ON DELETE action ] [ ON UPDATE action ]
+func (p *Parser) parseReferences() *ast.References {
+	var references ast.References
+	p.advance()
+	table := p.parseTableName()
+	if table == nil {
+		return nil
+	}
+	references.Table = table
+	if p.peekToken.Type == token.LParen {
+		p.advance()
+		columnList := p.parseColumnList()
+		if columnList == nil {
+			return nil
+		}
+		references.ColumnList = columnList
+	}
+	for {
+		switch p.peekToken.Type {
+		case token.Match:
+			p.advance()
+			p.advance()
+			switch p.token.Type {
+			case token.Full, token.Partial, token.Simple:
+				references.Match = strings.ToUpper(p.token.Literal)
+			default:
+				p.errorf(p.token.Line, "expected FULL, PARTIAL or SIMPLE, found %s", p.token.Literal)
+				return nil
+			}
+		case token.On:
+			p.advance()
+			p.advance()
+			event := p.token.Type
+			if event != token.Delete && event != token.Update {
+				p.errorf(p.token.Line, "expected DELETE or UPDATE, found %s", p.token.Literal)
+				return nil
+			}
+			p.advance()
+			action := p.parseReferentialAction()
+			if action == "" {
+				return nil
+			}
+			if event == token.Delete {
+				references.OnDelete = action
+			} else {
+				references.OnUpdate = action
+			}
+		default:
+			return &references
+		}
+	}
+}
+
+// NO ACTION | RESTRICT | CASCADE | SET NULL [ ( column_name [, ... ] ) ] | SET DEFAULT [ ( column_name [, ... ] ) ]
+func (p *Parser) parseReferentialAction() string {
+	switch p.token.Type {
+	case token.No:
+		if !p.expectPeek(token.Action) {
+			return ""
+		}
+		return "NO ACTION"
+	case token.Restrict:
+		return "RESTRICT"
+	case token.Cascade:
+		return "CASCADE"
+	case token.Set:
+		p.advance()
+		var action string
+		switch p.token.Type {
+		case token.Null:
+			action = "SET NULL"
+		case token.Default:
+			action = "SET DEFAULT"
+		default:
+			p.errorf(p.token.Line, "expected NULL or DEFAULT, found %s", p.token.Literal)
+			return ""
+		}
+		if p.peekToken.Type == token.LParen {
+			p.advance()
+			columnList := p.parseColumnList()
+			if columnList == nil {
+				return ""
+			}
+			action += " " + ast.FormatNode(columnList)
+		}
+		return action
+	default:
+		p.errorf(p.token.Line, "expected referential action, found %s", p.token.Literal)
+		return ""
 	}
-	return tableConstraint
 }
 
 func (p *Parser) parseColumnList() *ast.ColumnList {
@@ -1032,7 +3838,7 @@ func (p *Parser) parseColumnList() *ast.ColumnList {
 	if !ok {
 		return nil
 	}
-	for p.token.Type != token.RParen {
+	for p.token.Type != token.RParen && p.token.Type != token.EOF {
 		column := p.parseIdentifier()
 		if column != nil {
 			columnList.ColumnNames = append(columnList.ColumnNames, column)
@@ -1045,31 +3851,88 @@ func (p *Parser) parseColumnList() *ast.ColumnList {
 	return columnList
 }
 
+// parseMetaCommand splits the arguments of a psql meta-command at white
+// space. A single-quoted argument may contain white space and '' for a quote.
+func (p *Parser) parseMetaCommand() ast.Statement {
+	metaCommand := &ast.MetaCommand{Token: p.token, Command: p.token.Value}
+	rest := strings.TrimPrefix(p.token.Literal, `\`+p.token.Value)
+	for {
+		rest = strings.TrimLeft(rest, " \t\r")
+		if rest == "" {
+			break
+		}
+		var argument strings.Builder
+		if rest[0] == '\'' {
+			i := 1
+			for ; i < len(rest); i++ {
+				if rest[i] == '\'' {
+					if i+1 < len(rest) && rest[i+1] == '\'' {
+						i++
+					} else {
+						break
+					}
+				}
+				argument.WriteByte(rest[i])
+			}
+			if i >= len(rest) {
+				p.errorf(p.token.Line, "unterminated quoted string in \\%s", metaCommand.Command)
+				return nil
+			}
+			rest = rest[i+1:]
+		} else {
+			end := strings.IndexAny(rest, " \t\r")
+			if end < 0 {
+				end = len(rest)
+			}
+			argument.WriteString(rest[:end])
+			rest = rest[end:]
+		}
+		metaCommand.Arguments = append(metaCommand.Arguments, argument.String())
+	}
+	return metaCommand
+}
+
 // SET name = { value | 'value' | DEFAULT }
+// SET [ SESSION | LOCAL ] configuration_parameter { TO | = } { value [, ...] | DEFAULT }
+//
+// Other forms such as SET TIME ZONE or SET ROLE are skipped.
 func (p *Parser) parseSetStatement() ast.Statement {
 	setStatement := &ast.SetStatement{}
 
-	if !p.expectPeek(token.Identifier) {
-		return nil
+	switch p.peekToken.Type {
+	case token.Session:
+		p.advance()
+	case token.Local:
+		p.advance()
+		setStatement.Local = true
 	}
-	setStatement.Name = p.parseIdentifier()
 
-	if setStatement.Name.Value != "search_path" {
-		// `SET search_path` is only implemented.
+	p.advance()
+	if !p.isIdentifier() {
+		return nil
+	}
+	setStatement.Name = p.parseConfigurationParameter()
+	if setStatement.Name == nil {
 		return nil
 	}
 
-	if !p.expectPeek(token.Equal) {
+	switch p.peekToken.Type {
+	case token.To, token.Equal:
+		p.advance()
+	default:
 		return nil
 	}
 
 	p.advance()
+	if p.token.Type == token.Default {
+		return setStatement
+	}
 	for {
-		expr := p.parseExpression(precedenceLowest)
-		if expr == nil {
+		value := p.parseSetValue()
+		if value == nil {
 			return nil
 		}
-		setStatement.Values = append(setStatement.Values, expr)
+		setStatement.Values = append(setStatement.Values, value)
 
 		if p.peekToken.Type != token.Comma {
 			break
@@ -1080,3 +3943,99 @@ func (p *Parser) parseSetStatement() ast.Statement {
 
 	return setStatement
 }
+
+// parseConfigurationParameter parses a possibly dotted parameter name such as
+// search_path or myext.setting into a single identifier.
+func (p *Parser) parseConfigurationParameter() *ast.Identifier {
+	name := p.parseIdentifier()
+	if name == nil {
+		return nil
+	}
+	for p.peekToken.Type == token.Dot {
+		p.advance()
+		p.advance()
+		part := p.parseColLabel()
+		if part == nil {
+			return nil
+		}
+		name.Value += "." + part.Value
+	}
+	return name
+}
+
+// A value of SET is a string, a number or a word, which may be a keyword such
+// as ON.
+func (p *Parser) parseSetValue() ast.Expression {
+	switch p.token.Type {
+	case token.String, token.Number, token.Minus, token.Plus:
+		return p.parseExpression(precedenceLowest)
+	}
+	if !p.isColLabel() {
+		p.errorf(p.token.Line, "unexpected %s in SET", p.token.Literal)
+		return nil
+	}
+	return p.parseColLabel()
+}
+
+// RESET { configuration_parameter | ALL }
+func (p *Parser) parseResetStatement() ast.Statement {
+	resetStatement := &ast.ResetStatement{}
+	p.advance()
+	if p.token.Type == token.All {
+		return resetStatement
+	}
+	if !p.isIdentifier() {
+		// Such as RESET SESSION AUTHORIZATION.
+		return nil
+	}
+	resetStatement.Name = p.parseConfigurationParameter()
+	if resetStatement.Name == nil {
+		return nil
+	}
+	return resetStatement
+}
+
+// SELECT expression [, ...]
+//
+// A SELECT with anything more than a target list is skipped.
+func (p *Parser) parseSelectStatement() ast.Statement {
+	selectStatement := &ast.SelectStatement{}
+	p.advance()
+	targets := p.parseExpressionList()
+	if targets == nil {
+		return nil
+	}
+	selectStatement.Targets = targets
+	switch p.peekToken.Type {
+	case token.Semicolon, token.EOF:
+		return selectStatement
+	}
+	return nil
+}
+
+// { BEGIN | START TRANSACTION | COMMIT | END | ROLLBACK } [ WORK | TRANSACTION ]
+func (p *Parser) parseTransactionStatement() ast.Statement {
+	transactionStatement := &ast.TransactionStatement{}
+	switch p.token.Type {
+	case token.Begin:
+		transactionStatement.Command = "BEGIN"
+	case token.Start:
+		if !p.expectPeek(token.Transaction) {
+			return nil
+		}
+		transactionStatement.Command = "BEGIN"
+	case token.Commit, token.End:
+		transactionStatement.Command = "COMMIT"
+	case token.Rollback:
+		if p.peekToken.Type == token.To {
+			// ROLLBACK TO SAVEPOINT does not end the transaction.
+			return nil
+		}
+		transactionStatement.Command = "ROLLBACK"
+	}
+	switch p.peekToken.Type {
+	case token.Work, token.Transaction:
+		p.advance()
+	}
+	return transactionStatement
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ttakezawa/pgconverger/ast"
 	"github.com/ttakezawa/pgconverger/lexer"
 )
 
@@ -49,7 +50,10 @@ func TestCreateTableStatement(t *testing.T) {
     expr1 bigint DEFAULT 1+2*3/4::"text",
     expr2 bigint DEFAULT '0002'::text,
     flag1 bigint DEFAULT TRUE,
-    flag2 bigint DEFAULT falSe
+    flag2 bigint DEFAULT falSe,
+    seq1 smallserial,
+    seq2 serial,
+    seq3 bigserial
 );`,
 			`CREATE TABLE "users" (
     "id" bigint NOT NULL,
@@ -76,7 +80,116 @@ func TestCreateTableStatement(t *testing.T) {
     "expr1" bigint DEFAULT 1+2*3/4::"text",
     "expr2" bigint DEFAULT '0002'::"text",
     "flag1" bigint DEFAULT TRUE,
-    "flag2" bigint DEFAULT FALSE
+    "flag2" bigint DEFAULT FALSE,
+    "seq1" smallserial,
+    "seq2" serial,
+    "seq3" bigserial
+);
+`,
+		},
+		{
+			`CREATE TABLE t (
+    n integer DEFAULT -1 NOT NULL,
+    m integer DEFAULT (1 + 2) * 3 NULL
+);`,
+			`CREATE TABLE "t" (
+    "n" integer DEFAULT -1 NOT NULL,
+    "m" integer DEFAULT (1+2)*3
+);
+`,
+		},
+		{
+			`CREATE TABLE t (
+    expires_at timestamp with time zone DEFAULT (now() + interval '1 day'),
+    period interval day to second DEFAULT '1 day'
+);`,
+			`CREATE TABLE "t" (
+    "expires_at" timestamp with time zone DEFAULT ("now"()+'1 day'::interval),
+    "period" interval day to second DEFAULT '1 day'
+);
+`,
+		},
+		{
+			`CREATE TABLE t (
+    type text,
+    value integer,
+    position integer,
+    name text,
+    "user" text,
+    "order" int
+);`,
+			`CREATE TABLE "t" (
+    "type" text,
+    "value" integer,
+    "position" integer,
+    "name" text,
+    "user" text,
+    "order" integer
+);
+`,
+		},
+		{
+			`CREATE TABLE t (
+    a double precision,
+    b real,
+    c float,
+    d float(10),
+    e time,
+    f time with time zone,
+    g timetz,
+    h bit(1),
+    i bit varying(8),
+    j varchar(255),
+    k int4,
+    l bool,
+    m timestamptz[],
+    n float8,
+    o decimal(10, 2),
+    p char(2)
+);`,
+			`CREATE TABLE "t" (
+    "a" double precision,
+    "b" real,
+    "c" double precision,
+    "d" real,
+    "e" time without time zone,
+    "f" time with time zone,
+    "g" time with time zone,
+    "h" bit(1),
+    "i" bit varying(8),
+    "j" character varying(255),
+    "k" integer,
+    "l" boolean,
+    "m" timestamp with time zone[],
+    "n" double precision,
+    "o" numeric(10,2),
+    "p" character(2)
+);
+`,
+		},
+		{
+			`CREATE TABLE public.orders (
+    id integer NOT NULL,
+    total integer,
+    user_id integer,
+    CONSTRAINT orders_total_check CHECK ((total >= 0)) NO INHERIT,
+    PRIMARY KEY (id),
+    CONSTRAINT orders_user_id_fkey FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);`,
+			`CREATE TABLE "public"."orders" (
+    "id" integer NOT NULL,
+    "total" integer,
+    "user_id" integer,
+    CONSTRAINT "orders_total_check" CHECK (("total">=0)) NO INHERIT,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "orders_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE
+);
+`,
+		},
+		{
+			`CREATE TABLE t (a text DEFAULT 'x' COLLATE "C" NOT NULL);`,
+			`CREATE TABLE "t" (
+    "a" text COLLATE "C" DEFAULT 'x' NOT NULL
 );
 `,
 		},
@@ -87,6 +200,75 @@ func TestCreateTableStatement(t *testing.T) {
 			`CREATE TABLE "public"."users" (
     "id" bigint NOT NULL DEFAULT 'nextval(''users_id_seq''::regclass)'
 );
+`,
+		},
+		{
+			`CREATE TABLE events (id bigint, created_at date, kind text) PARTITION BY RANGE (created_at, (lower(kind)) text_pattern_ops);`,
+			`CREATE TABLE "events" (
+    "id" bigint,
+    "created_at" date,
+    "kind" text
+) PARTITION BY RANGE ("created_at", ("lower"("kind")) "text_pattern_ops");
+`,
+		},
+		{
+			`CREATE UNLOGGED TABLE IF NOT EXISTS hits (id bigint) USING heap WITH (fillfactor=70, autovacuum_enabled) TABLESPACE fast;`,
+			`CREATE UNLOGGED TABLE IF NOT EXISTS "hits" (
+    "id" bigint
+) USING "heap" WITH ("fillfactor"='70', "autovacuum_enabled") TABLESPACE "fast";
+`,
+		},
+		{
+			`CREATE GLOBAL TEMPORARY TABLE scratch (id bigint) WITHOUT OIDS ON COMMIT DELETE ROWS;
+CREATE TEMP SEQUENCE scratch_seq;
+CREATE TEMP TABLE scratch2 (id bigint) ON COMMIT DROP;`,
+			`CREATE TEMPORARY TABLE "scratch" (
+    "id" bigint
+) ON COMMIT DELETE ROWS;
+
+CREATE TEMPORARY TABLE "scratch2" (
+    "id" bigint
+) ON COMMIT DROP;
+`,
+		},
+		{
+			`CREATE TABLE documents (body text STORAGE external COMPRESSION lz4 NOT NULL);`,
+			`CREATE TABLE "documents" (
+    "body" text STORAGE EXTERNAL COMPRESSION "lz4" NOT NULL
+);
+`,
+		},
+		{
+			`CREATE TABLE users (name text COLLATE "C" NOT NULL, nickname character varying(40) COLLATE public.case_insensitive);`,
+			`CREATE TABLE "users" (
+    "name" text COLLATE "C" NOT NULL,
+    "nickname" character varying(40) COLLATE "public"."case_insensitive"
+);
+`,
+		},
+		{
+			`CREATE TABLE cities_capitals (state text) INHERITS (cities, public.places);`,
+			`CREATE TABLE "cities_capitals" (
+    "state" text
+) INHERITS ("cities", "public"."places");
+`,
+		},
+		{
+			`CREATE TABLE events_2020 PARTITION OF events FOR VALUES FROM ('2020-01-01', MINVALUE) TO ('2021-01-01', maxvalue) PARTITION BY list (kind);`,
+			`CREATE TABLE "events_2020" PARTITION OF "events" FOR VALUES FROM ('2020-01-01', MINVALUE) TO ('2021-01-01', MAXVALUE) PARTITION BY LIST ("kind");
+`,
+		},
+		{
+			`CREATE TABLE events_a PARTITION OF events FOR VALUES IN ('a', 'b');`,
+			`CREATE TABLE "events_a" PARTITION OF "events" FOR VALUES IN ('a', 'b');
+`,
+		},
+		{
+			`CREATE TABLE public.events_h0 PARTITION OF public.events FOR VALUES WITH (modulus 4, remainder 0);
+CREATE TABLE events_other PARTITION OF events DEFAULT;`,
+			`CREATE TABLE "public"."events_h0" PARTITION OF "public"."events" FOR VALUES WITH (MODULUS 4, REMAINDER 0);
+
+CREATE TABLE "events_other" PARTITION OF "events" DEFAULT;
 `,
 		},
 	}
@@ -138,11 +320,49 @@ func TestCreateIndexStatement(t *testing.T) {
 		},
 		{
 			`CREATE INDEX users_name_key ON users USING btree (name text_pattern_ops);`,
-			`CREATE INDEX "users_name_key" ON "users" USING "btree" ("name" text_pattern_ops);`,
+			`CREATE INDEX "users_name_key" ON "users" USING "btree" ("name" "text_pattern_ops");`,
 		},
 		{
 			`CREATE INDEX users_name_key ON users USING btree (name varchar_pattern_ops);`,
-			`CREATE INDEX "users_name_key" ON "users" USING "btree" ("name" varchar_pattern_ops);`,
+			`CREATE INDEX "users_name_key" ON "users" USING "btree" ("name" "varchar_pattern_ops");`,
+		},
+		{
+			`CREATE INDEX users_lower_name_key ON users USING btree (lower(name));`,
+			`CREATE INDEX "users_lower_name_key" ON "users" USING "btree" ("lower"("name"));`,
+		},
+		{
+			`CREATE UNIQUE INDEX users_email_key ON users (email) WHERE deleted_at IS NULL;`,
+			`CREATE UNIQUE INDEX "users_email_key" ON "users" ("email") WHERE "deleted_at" IS NULL;`,
+		},
+		{
+			`CREATE INDEX recent_events ON events (created_at) WHERE created_at > now() - interval '1 day';`,
+			`CREATE INDEX "recent_events" ON "events" ("created_at") WHERE "created_at">"now"()-'1 day'::interval;`,
+		},
+		{
+			`CREATE INDEX IF NOT EXISTS users_name_key ON users USING btree (name COLLATE "C" DESC NULLS LAST, id NULLS FIRST) INCLUDE (email, phone);`,
+			`CREATE INDEX IF NOT EXISTS "users_name_key" ON "users" USING "btree" ("name" COLLATE "C" DESC NULLS LAST, "id" NULLS FIRST) INCLUDE ("email", "phone");`,
+		},
+		{
+			`CREATE INDEX users_lower_name_key ON users (lower(name) COLLATE pg_catalog."default" text_pattern_ops);`,
+			`CREATE INDEX "users_lower_name_key" ON "users" ("lower"("name") COLLATE "pg_catalog"."default" "text_pattern_ops");`,
+		},
+		{
+			`CREATE INDEX users_name_trgm ON public.users USING gist (name public.gist_trgm_ops (siglen='32'), email);`,
+			`CREATE INDEX "users_name_trgm" ON "public"."users" USING "gist" ("name" "public"."gist_trgm_ops" ("siglen"='32'), "email");`,
+		},
+		{
+			`CREATE INDEX users_tags_key ON users USING gin (tags jsonb_path_ops) WITH (fastupdate = off, gin_pending_list_limit = 128) TABLESPACE fast;`,
+			`CREATE INDEX "users_tags_key" ON "users" USING "gin" ("tags" "jsonb_path_ops") WITH ("fastupdate"='off', "gin_pending_list_limit"='128') TABLESPACE "fast";`,
+		},
+		{
+			`CREATE UNIQUE INDEX users_code_key ON users (code) NULLS NOT DISTINCT WITH (fillfactor='70', deduplicate_items) WHERE (code <> '');`,
+			`CREATE UNIQUE INDEX "users_code_key" ON "users" ("code") NULLS NOT DISTINCT WITH ("fillfactor"='70', "deduplicate_items") WHERE ("code"<>'');`,
+		},
+		{
+			`CREATE INDEX ev_ts_idx ON ONLY public.ev USING btree (ts);
+ALTER INDEX public.ev_ts_idx ATTACH PARTITION public.ev_2024_ts_idx;`,
+			`CREATE INDEX "ev_ts_idx" ON ONLY "public"."ev" USING "btree" ("ts");
+ALTER INDEX "public"."ev_ts_idx" ATTACH PARTITION "public"."ev_2024_ts_idx";`,
 		},
 	}
 
@@ -171,6 +391,38 @@ func TestSetStatement(t *testing.T) {
 			`SET search_path = "myschema";`,
 			`SET search_path = "myschema";`,
 		},
+		{
+			`SET LOCAL search_path TO app, public;`,
+			`SET LOCAL search_path = "app", "public";`,
+		},
+		{
+			`SET SESSION search_path TO DEFAULT;`,
+			`SET search_path = DEFAULT;`,
+		},
+		{
+			`SET client_min_messages = warning; SET statement_timeout = 0; SET default_tablespace = '';`,
+			"SET client_min_messages = \"warning\";\nSET statement_timeout = 0;\nSET default_tablespace = '';",
+		},
+		{
+			`SET TIME ZONE 'UTC'; SET ROLE admin;`,
+			``,
+		},
+		{
+			`RESET search_path; RESET ALL;`,
+			"RESET search_path;\nRESET ALL;",
+		},
+		{
+			`SELECT pg_catalog.set_config('search_path', '', false);`,
+			`SELECT "pg_catalog"."set_config"('search_path', '', FALSE);`,
+		},
+		{
+			`SELECT 1 FROM users;`,
+			``,
+		},
+		{
+			`BEGIN; START TRANSACTION; COMMIT WORK; END; ROLLBACK;`,
+			"BEGIN;\nBEGIN;\nCOMMIT;\nCOMMIT;\nROLLBACK;",
+		},
 	}
 
 	for i, tt := range tests {
@@ -218,6 +470,137 @@ func TestCreateSequenceStatement(t *testing.T) {
 	}
 }
 
+func TestCreateCollationStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`CREATE COLLATION case_insensitive (provider = icu, locale = 'und-u-ks-level2', deterministic = false);`,
+			`CREATE COLLATION "case_insensitive" ("provider"='icu', "locale"='und-u-ks-level2', "deterministic"='false');
+`,
+		},
+		{
+			`CREATE COLLATION IF NOT EXISTS public.german FROM "de_DE";`,
+			`CREATE COLLATION IF NOT EXISTS "public"."german" FROM "de_DE";
+`,
+		},
+	}
+
+	for i, tt := range tests {
+		p := New(lexer.Lex("<input>", tt.input))
+		dataDefinition := p.ParseDataDefinition()
+		var builder strings.Builder
+		dataDefinition.WriteStringTo(&builder)
+		if builder.String() != tt.expected {
+			t.Errorf("case%d:\n\tgot  =      %q,\n\twant =      %q", i+1, builder.String(), tt.expected)
+		}
+		checkParserErrors(t, p)
+	}
+}
+
+func TestPolicyStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`CREATE POLICY tenant_isolation ON public.accounts AS RESTRICTIVE FOR UPDATE TO app_user, PUBLIC, "public", current_user USING (tenant_id = current_setting('app.tenant')::integer) WITH CHECK (tenant_id > 0);`,
+			`CREATE POLICY "tenant_isolation" ON "public"."accounts" AS RESTRICTIVE FOR UPDATE TO "app_user", PUBLIC, "public", CURRENT_USER USING ("tenant_id"="current_setting"('app.tenant')::integer) WITH CHECK ("tenant_id">0);
+`,
+		},
+		{
+			`CREATE POLICY everyone ON accounts AS PERMISSIVE FOR ALL USING (true);`,
+			`CREATE POLICY "everyone" ON "accounts" USING (TRUE);
+`,
+		},
+		{
+			`ALTER POLICY everyone ON accounts RENAME TO anyone;`,
+			`ALTER POLICY "everyone" ON "accounts" RENAME TO "anyone";
+`,
+		},
+		{
+			`ALTER POLICY everyone ON accounts TO public WITH CHECK (owner = current_user);`,
+			`ALTER POLICY "everyone" ON "accounts" TO PUBLIC WITH CHECK ("owner"=CURRENT_USER);
+`,
+		},
+	}
+
+	for i, tt := range tests {
+		p := New(lexer.Lex("<input>", tt.input))
+		dataDefinition := p.ParseDataDefinition()
+		var builder strings.Builder
+		dataDefinition.WriteStringTo(&builder)
+		if builder.String() != tt.expected {
+			t.Errorf("case%d:\n\tgot  =      %q,\n\twant =      %q", i+1, builder.String(), tt.expected)
+		}
+		checkParserErrors(t, p)
+	}
+}
+
+func TestForeignDataStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			`CREATE SERVER IF NOT EXISTS reporting TYPE 'pg' VERSION '16' FOREIGN DATA WRAPPER postgres_fdw OPTIONS (host 'db', dbname 'reports');`,
+			`CREATE SERVER IF NOT EXISTS "reporting" TYPE 'pg' VERSION '16' FOREIGN DATA WRAPPER "postgres_fdw" OPTIONS ("host" 'db', "dbname" 'reports');
+`,
+		},
+		{
+			`ALTER SERVER reporting VERSION '17' OPTIONS (SET host 'db2', DROP dbname, ADD port '5432');`,
+			`ALTER SERVER "reporting" VERSION '17' OPTIONS (SET "host" 'db2', DROP "dbname", ADD "port" '5432');
+`,
+		},
+		{
+			`CREATE USER MAPPING FOR USER SERVER reporting OPTIONS (user 'app', password 'it''s');`,
+			`CREATE USER MAPPING FOR CURRENT_USER SERVER "reporting" OPTIONS ("user" 'app', "password" 'it''s');
+`,
+		},
+		{
+			`ALTER USER MAPPING FOR public SERVER reporting OPTIONS (DROP password);`,
+			`ALTER USER MAPPING FOR PUBLIC SERVER "reporting" OPTIONS (DROP "password");
+`,
+		},
+		{
+			`DROP USER MAPPING IF EXISTS FOR app SERVER reporting;`,
+			`DROP USER MAPPING IF EXISTS FOR "app" SERVER "reporting";
+`,
+		},
+		{
+			`IMPORT FOREIGN SCHEMA public LIMIT TO (orders, items) FROM SERVER reporting INTO remote OPTIONS (import_default 'true');`,
+			`IMPORT FOREIGN SCHEMA "public" LIMIT TO ("orders", "items") FROM SERVER "reporting" INTO "remote" OPTIONS ("import_default" 'true');
+`,
+		},
+		{
+			`CREATE FOREIGN TABLE remote.orders (id integer OPTIONS (column_name 'order_id') NOT NULL, note text) SERVER reporting OPTIONS (schema_name 'public', table_name 'orders');`,
+			`CREATE FOREIGN TABLE "remote"."orders" (
+    "id" integer OPTIONS ("column_name" 'order_id') NOT NULL,
+    "note" text
+) SERVER "reporting" OPTIONS ("schema_name" 'public', "table_name" 'orders');
+`,
+		},
+		{
+			`ALTER FOREIGN TABLE remote.orders OPTIONS (SET table_name 'orders2'), ALTER COLUMN id OPTIONS (DROP column_name);`,
+			`ALTER FOREIGN TABLE "remote"."orders"
+    OPTIONS (SET "table_name" 'orders2'),
+    ALTER COLUMN "id" OPTIONS (DROP "column_name");`,
+		},
+	}
+
+	for i, tt := range tests {
+		p := New(lexer.Lex("<input>", tt.input))
+		dataDefinition := p.ParseDataDefinition()
+		var builder strings.Builder
+		dataDefinition.WriteStringTo(&builder)
+		if builder.String() != tt.expected {
+			t.Errorf("case%d:\n\tgot  =      %q,\n\twant =      %q", i+1, builder.String(), tt.expected)
+		}
+		checkParserErrors(t, p)
+	}
+}
+
 func TestAlterSequenceStatement(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -269,12 +652,134 @@ func TestAlterTableStatement(t *testing.T) {
     ADD CONSTRAINT users_name_key UNIQUE (name);`,
 			`ALTER TABLE ONLY "users"
     ADD CONSTRAINT "users_name_key" UNIQUE ("name");`,
+		},
+		{
+			`ALTER TABLE ONLY users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id) INCLUDE (name) WITH (fillfactor=70) USING INDEX TABLESPACE fast;`,
+			`ALTER TABLE ONLY "users"
+    ADD CONSTRAINT "users_pkey" PRIMARY KEY ("id") INCLUDE ("name") WITH ("fillfactor"='70') USING INDEX TABLESPACE "fast";`,
+		},
+		{
+			`ALTER TABLE ONLY users
+    ADD CONSTRAINT users_age_check CHECK (age >= 0) NO INHERIT NOT VALID;`,
+			`ALTER TABLE ONLY "users"
+    ADD CONSTRAINT "users_age_check" CHECK ("age">=0) NO INHERIT NOT VALID;`,
+		},
+		{
+			`ALTER TABLE ONLY public.posts
+    ADD CONSTRAINT posts_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) MATCH FULL ON DELETE CASCADE ON UPDATE SET NULL DEFERRABLE INITIALLY DEFERRED;`,
+			`ALTER TABLE ONLY "public"."posts"
+    ADD CONSTRAINT "posts_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "public"."users" ("id") MATCH FULL ON UPDATE SET NULL ON DELETE CASCADE DEFERRABLE INITIALLY DEFERRED;`,
+		},
+		{
+			`ALTER TABLE IF EXISTS ONLY users ADD COLUMN IF NOT EXISTS age integer NOT NULL, DROP COLUMN name CASCADE,
+    ALTER COLUMN email SET DATA TYPE text USING email::text, ALTER email DROP DEFAULT, ALTER COLUMN email SET NOT NULL;`,
+			`ALTER TABLE IF EXISTS ONLY "users"
+    ADD COLUMN IF NOT EXISTS "age" integer NOT NULL,
+    DROP COLUMN "name" CASCADE,
+    ALTER COLUMN "email" TYPE text USING "email"::"text",
+    ALTER COLUMN "email" DROP DEFAULT,
+    ALTER COLUMN "email" SET NOT NULL;`,
+		},
+		{
+			`ALTER TABLE users DROP CONSTRAINT IF EXISTS users_pkey, ADD PRIMARY KEY (id), DISABLE TRIGGER ALL, ENABLE REPLICA TRIGGER audit, OWNER TO CURRENT_USER;`,
+			`ALTER TABLE "users"
+    DROP CONSTRAINT IF EXISTS "users_pkey",
+    ADD PRIMARY KEY ("id"),
+    DISABLE TRIGGER ALL,
+    ENABLE REPLICA TRIGGER "audit",
+    OWNER TO CURRENT_USER;`,
+		},
+		{
+			`ALTER TABLE users RENAME name TO full_name;`,
+			`ALTER TABLE "users"
+    RENAME COLUMN "name" TO "full_name";`,
+		},
+		{
+			`ALTER TABLE users RENAME CONSTRAINT users_pkey TO members_pkey;`,
+			`ALTER TABLE "users"
+    RENAME CONSTRAINT "users_pkey" TO "members_pkey";`,
+		},
+		{
+			`ALTER TABLE public.users RENAME TO members;`,
+			`ALTER TABLE "public"."users"
+    RENAME TO "members";`,
+		},
+		{
+			`ALTER TABLE public.users SET SCHEMA app;`,
+			`ALTER TABLE "public"."users"
+    SET SCHEMA "app";`,
 		},
 		{
 			`ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);`,
 			`ALTER TABLE ONLY "public"."users"
     ALTER COLUMN "id" SET DEFAULT "nextval"('public.users_id_seq'::"regclass");`,
 		},
+		{
+			`ALTER TABLE ONLY public.events ATTACH PARTITION public.events_2020 FOR VALUES FROM ('2020-01-01') TO ('2021-01-01');`,
+			`ALTER TABLE ONLY "public"."events"
+    ATTACH PARTITION "public"."events_2020" FOR VALUES FROM ('2020-01-01') TO ('2021-01-01');`,
+		},
+		{
+			`ALTER TABLE hits SET LOGGED, SET (fillfactor = 70), RESET (autovacuum_enabled), SET TABLESPACE pg_default, SET ACCESS METHOD heap;`,
+			`ALTER TABLE "hits"
+    SET LOGGED,
+    SET ("fillfactor"='70'),
+    RESET ("autovacuum_enabled"),
+    SET TABLESPACE "pg_default",
+    SET ACCESS METHOD "heap";`,
+		},
+		{
+			`ALTER TABLE ONLY public.documents ALTER COLUMN body SET STATISTICS 1000, ALTER body SET STATISTICS -1, ALTER COLUMN body SET STORAGE main, ALTER COLUMN body SET COMPRESSION pglz, ALTER COLUMN body SET COMPRESSION default;`,
+			`ALTER TABLE ONLY "public"."documents"
+    ALTER COLUMN "body" SET STATISTICS 1000,
+    ALTER COLUMN "body" SET STATISTICS -1,
+    ALTER COLUMN "body" SET STORAGE MAIN,
+    ALTER COLUMN "body" SET COMPRESSION "pglz",
+    ALTER COLUMN "body" SET COMPRESSION DEFAULT;`,
+		},
+		{
+			`ALTER TABLE users ALTER COLUMN name TYPE text COLLATE "C", ALTER nickname SET DATA TYPE text COLLATE pg_catalog."default" USING nickname::text;`,
+			`ALTER TABLE "users"
+    ALTER COLUMN "name" TYPE text COLLATE "C",
+    ALTER COLUMN "nickname" TYPE text COLLATE "pg_catalog"."default" USING "nickname"::"text";`,
+		},
+		{
+			`ALTER TABLE t ALTER COLUMN b SET DATA TYPE varchar(10), ALTER COLUMN a SET NOT NULL, ALTER COLUMN c TYPE numeric(10, 2)[];`,
+			`ALTER TABLE "t"
+    ALTER COLUMN "b" TYPE character varying(10),
+    ALTER COLUMN "a" SET NOT NULL,
+    ALTER COLUMN "c" TYPE numeric(10,2)[];`,
+		},
+		{
+			`ALTER TABLE ONLY accounts ENABLE ROW LEVEL SECURITY, FORCE ROW LEVEL SECURITY, NO FORCE ROW LEVEL SECURITY, DISABLE ROW LEVEL SECURITY, DISABLE TRIGGER ALL;`,
+			`ALTER TABLE ONLY "accounts"
+    ENABLE ROW LEVEL SECURITY,
+    FORCE ROW LEVEL SECURITY,
+    NO FORCE ROW LEVEL SECURITY,
+    DISABLE ROW LEVEL SECURITY,
+    DISABLE TRIGGER ALL;`,
+		},
+		{
+			`ALTER TABLE ONLY public.users REPLICA IDENTITY USING INDEX users_email_key, REPLICA IDENTITY full, CLUSTER ON users_pkey, SET WITHOUT CLUSTER;`,
+			`ALTER TABLE ONLY "public"."users"
+    REPLICA IDENTITY USING INDEX "users_email_key",
+    REPLICA IDENTITY FULL,
+    CLUSTER ON "users_pkey",
+    SET WITHOUT CLUSTER;`,
+		},
+		{
+			`ALTER TABLE capitals NO INHERIT cities, INHERIT public.places;`,
+			`ALTER TABLE "capitals"
+    NO INHERIT "cities",
+    INHERIT "public"."places";`,
+		},
+		{
+			`ALTER TABLE events ATTACH PARTITION events_other DEFAULT, DETACH PARTITION events_2020 CONCURRENTLY;`,
+			`ALTER TABLE "events"
+    ATTACH PARTITION "events_other" DEFAULT,
+    DETACH PARTITION "events_2020" CONCURRENTLY;`,
+		},
 	}
 
 	for i, tt := range tests {
@@ -288,3 +793,222 @@ func TestAlterTableStatement(t *testing.T) {
 		checkParserErrors(t, p)
 	}
 }
+
+func TestDropStatement(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`DROP TABLE IF EXISTS public.users, posts CASCADE;`, `DROP TABLE IF EXISTS "public"."users", "posts" CASCADE;`},
+		{`DROP INDEX CONCURRENTLY users_email_key RESTRICT;`, `DROP INDEX CONCURRENTLY "users_email_key";`},
+		{`DROP FUNCTION IF EXISTS public.touch(integer, text), noop();`, `DROP FUNCTION IF EXISTS "public"."touch", "noop";`},
+		{`DROP SCHEMA app CASCADE;`, `DROP SCHEMA "app" CASCADE;`},
+		{`DROP COLLATION IF EXISTS public.german;`, `DROP COLLATION IF EXISTS "public"."german";`},
+		{`DROP POLICY IF EXISTS everyone ON public.accounts CASCADE;`, `DROP POLICY IF EXISTS "everyone" ON "public"."accounts" CASCADE;`},
+		{`DROP FOREIGN TABLE IF EXISTS remote.orders;`, `DROP FOREIGN TABLE IF EXISTS "remote"."orders";`},
+		{`DROP SERVER reporting CASCADE; DROP FOREIGN DATA WRAPPER postgres_fdw;`, "DROP SERVER \"reporting\" CASCADE;\nDROP FOREIGN DATA WRAPPER \"postgres_fdw\";"},
+		{`DROP VIEW v; DROP SEQUENCE s; DROP TYPE mood;`, "DROP VIEW \"v\";\nDROP SEQUENCE \"s\";\nDROP TYPE \"mood\";"},
+	}
+
+	for i, tt := range tests {
+		p := New(lexer.Lex("<input>", tt.input))
+		dataDefinition := p.ParseDataDefinition()
+		var builder strings.Builder
+		dataDefinition.WriteStringTo(&builder)
+		if builder.String() != tt.expected {
+			t.Errorf("case%d:\n\tgot  =      %q,\n\twant =      %q", i+1, builder.String(), tt.expected)
+		}
+		checkParserErrors(t, p)
+	}
+}
+
+func TestMetaCommand(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"\\connect app\nDROP VIEW v;", "\\connect app\nDROP VIEW \"v\";"},
+		{"\\restrict Xk2f9\r\nDROP VIEW v;\n\\unrestrict Xk2f9", "\\restrict Xk2f9\nDROP VIEW \"v\";\n\\unrestrict Xk2f9"},
+		{"\\ir   'my tables.sql'  \nDROP VIEW v;", "\\ir 'my tables.sql'\nDROP VIEW \"v\";"},
+		{"\\i 'it''s.sql'", "\\i 'it''s.sql'"},
+	}
+
+	for i, tt := range tests {
+		p := New(lexer.Lex("<input>", tt.input))
+		dataDefinition := p.ParseDataDefinition()
+		var builder strings.Builder
+		dataDefinition.WriteStringTo(&builder)
+		if builder.String() != tt.expected {
+			t.Errorf("case%d:\n\tgot  =      %q,\n\twant =      %q", i+1, builder.String(), tt.expected)
+		}
+		checkParserErrors(t, p)
+	}
+}
+
+func TestExpression(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`-1`, `-1`},
+		{`Users.Name`, `"users"."name"`},
+		{`t.order + t.type`, `"t"."order"+"t"."type"`},
+		{`left(value, 1) || right(value, 1)`, `"left"("value", 1)||"right"("value", 1)`},
+		{`"Users"."a""b"`, `"Users"."a""b"`},
+		{`U&"d\0061t\+000061"`, `"data"`},
+		{`- x::int`, `-"x"::integer`},
+		{`a = 1 AND NOT b OR c <> 2`, `"a"=1 AND NOT "b" OR "c"<>2`},
+		{`a != b`, `"a"<>"b"`},
+		{`a <= 1 and b >= 2 and c < 3 and d > 4`, `"a"<=1 AND "b">=2 AND "c"<3 AND "d">4`},
+		{`2 ^ 3 % 4`, `2^3%4`},
+		{`first_name || ' ' || last_name`, `"first_name"||' '||"last_name"`},
+		{`name LIKE 'a%'`, `"name" LIKE 'a%'`},
+		{`name not ilike 'a%' escape '!'`, `"name" NOT ILIKE 'a%' ESCAPE '!'`},
+		{`name SIMILAR TO '(a|b)%'`, `"name" SIMILAR TO '(a|b)%'`},
+		{`status IN ('a', 'b')`, `"status" IN ('a', 'b')`},
+		{`status NOT IN (1)`, `"status" NOT IN (1)`},
+		{`n BETWEEN 1 AND 10 AND m NOT BETWEEN SYMMETRIC 1 AND 2`, `"n" BETWEEN 1 AND 10 AND "m" NOT BETWEEN SYMMETRIC 1 AND 2`},
+		{`deleted_at IS NOT NULL`, `"deleted_at" IS NOT NULL`},
+		{`deleted_at ISNULL`, `"deleted_at" IS NULL`},
+		{`flag IS NOT TRUE`, `"flag" IS NOT TRUE`},
+		{`a IS DISTINCT FROM b`, `"a" IS DISTINCT FROM "b"`},
+		{`a IS NOT DISTINCT FROM b + 1`, `"a" IS NOT DISTINCT FROM "b"+1`},
+		{`CASE WHEN a > 0 THEN 'p' WHEN a < 0 THEN 'n' ELSE 'z' END`, `CASE WHEN "a">0 THEN 'p' WHEN "a"<0 THEN 'n' ELSE 'z' END`},
+		{`CASE kind WHEN 1 THEN true END`, `CASE "kind" WHEN 1 THEN TRUE END`},
+		{`CAST(x AS character varying(10))`, `CAST("x" AS character varying(10))`},
+		{`now() + interval '1 day'`, `"now"()+'1 day'::interval`},
+		{`date '2020-01-01' = '2020-01-01'::date`, `'2020-01-01'::date='2020-01-01'::date`},
+		{`timestamp with time zone '2020-01-01 00:00+00'`, `'2020-01-01 00:00+00'::timestamp with time zone`},
+		{`INTERVAL '1-2' YEAR TO MONTH`, `'1-2'::interval year to month`},
+		{`'1'::interval day < interval '1' second`, `'1'::interval day<'1'::interval second`},
+		{`text 'a' || mood 'happy'`, `'a'::"text"||'happy'::"mood"`},
+		{`'{}'::text[]`, `'{}'::"text"[]`},
+		{`'x'::pg_catalog.regclass`, `'x'::"pg_catalog"."regclass"`},
+		{`ARRAY['a'::text, 'b'::text]`, `ARRAY['a'::"text", 'b'::"text"]`},
+		{`ARRAY[[1, 2], [3, 4]]`, `ARRAY[[1, 2], [3, 4]]`},
+		{`status = ANY (ARRAY['a', 'b'])`, `"status"=ANY (ARRAY['a', 'b'])`},
+		{`tags[1]`, `"tags"[1]`},
+		{`tags[1:2]`, `"tags"[1:2]`},
+		{`(a, b)`, `("a", "b")`},
+		{`ROW(1, 'x')`, `ROW(1, 'x')`},
+		{`CURRENT_TIMESTAMP`, `CURRENT_TIMESTAMP`},
+		{`current_timestamp(3)`, `CURRENT_TIMESTAMP(3)`},
+		{`now()`, `"now"()`},
+		{`COALESCE(a, 0) + nullif(b, '')`, `COALESCE("a", 0)+NULLIF("b", '')`},
+		{`greatest(a, b) - least(a, b)`, `GREATEST("a", "b")-LEAST("a", "b")`},
+		{`"coalesce"(a, b)`, `"coalesce"("a", "b")`},
+		{`extract(year from created_at)`, `EXTRACT(year FROM "created_at")`},
+		{`substring(name from 1 for 3) || substring(name, 2)`, `SUBSTRING("name" FROM 1 FOR 3)||SUBSTRING("name", 2)`},
+		{`position('@' in email) > 0`, `POSITION('@' IN "email")>0`},
+		{`trim(both ' ' from name) = trim(leading from name)`, `TRIM(BOTH ' ' FROM "name")=TRIM(LEADING FROM "name")`},
+		{`name COLLATE "C" < 'b'`, `"name" COLLATE "C"<'b'`},
+		{`pg_catalog.lower(t.name)`, `"pg_catalog"."lower"("t"."name")`},
+		{`name COLLATE "C"`, `"name" COLLATE "C"`},
+		{`created_at AT TIME ZONE 'UTC'`, `"created_at" AT TIME ZONE 'UTC'`},
+		{`data->>'key' = 'v'`, `"data"->>'key'='v'`},
+		{`E'it\'s'`, `'it''s'`},
+		{`X'F' | B'0001'`, `B'1111'|B'0001'`},
+		{`tags @> ARRAY['a']`, `"tags"@>ARRAY['a']`},
+		{`~flags & 1`, `~"flags"&1`},
+		{`a - -1`, `"a"- -1`},
+		{`a OPERATOR(pg_catalog.+) b`, `"a" OPERATOR("pg_catalog".+) "b"`},
+		{`OPERATOR(pg_catalog.-) 1`, `OPERATOR("pg_catalog".-) 1`},
+	}
+
+	for i, tt := range tests {
+		p := New(lexer.Lex("<input>", tt.input))
+		expr := p.parseExpression(precedenceLowest)
+		checkParserErrors(t, p)
+		if got := ast.FormatNode(expr); got != tt.expected {
+			t.Errorf("case%d:\n\tgot  =      %q,\n\twant =      %q", i+1, got, tt.expected)
+		}
+	}
+}
+
+func TestUnexpectedStatementEnd(t *testing.T) {
+	tests := []string{
+		`CREATE TABLE t (a int, f double precisio, z int);`,
+		`CREATE TABLE t (a int, b int UNIQUE, z int);`,
+		`CREATE INDEX i ON t ((extract(from a)));`,
+		`CREATE INDEX i ON t ((position(a)));`,
+		`CREATE INDEX i ON t (a) WHERE a > 0 b;`,
+		`CREATE INDEX i ON t (a) TABLESPACE s x;`,
+		`ALTER INDEX i ATTACH PARTITION j k;`,
+		`ALTER TABLE t ALTER COLUMN a SET NOT NULL b;`,
+		`ALTER TABLE t ALTER COLUMN a TYPE text USING a::text b, ALTER COLUMN c SET NOT NULL;`,
+	}
+
+	for i, input := range tests {
+		p := New(lexer.Lex("<input>", input))
+		p.ParseDataDefinition()
+		if len(p.Errors()) == 0 {
+			t.Errorf("case%d: expected an error for %q", i+1, input)
+		}
+	}
+}
+
+func TestReservedKeywordAsIdentifier(t *testing.T) {
+	tests := []string{
+		`CREATE TABLE t (user text);`,
+		`CREATE TABLE t (order integer);`,
+		`CREATE TABLE left (id integer);`,
+		`CREATE INDEX i ON t (select);`,
+	}
+
+	for i, input := range tests {
+		p := New(lexer.Lex("<input>", input))
+		p.ParseDataDefinition()
+		if len(p.Errors()) == 0 {
+			t.Errorf("case%d: expected an error for %q", i+1, input)
+		}
+	}
+}
+
+func TestExpressionPrecedence(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`a OR b AND c`, `(a OR (b AND c))`},
+		{`NOT a AND b`, `((NOT a) AND b)`},
+		{`a = b IS NULL`, `((a = b) IS NULL)`},
+		{`a < b + c * d ^ e`, `(a < (b + (c * (d ^ e))))`},
+		{`-a::int`, `(- (a :: integer))`},
+		{`a || b LIKE c`, `((a || b) LIKE c)`},
+		{`a + b BETWEEN c AND d OR e`, `(((a + b) BETWEEN c AND d) OR e)`},
+		{`a || b @> c = d`, `(((a || b) @> c) = d)`},
+		{`a @> b + c`, `(a @> (b + c))`},
+	}
+
+	for i, tt := range tests {
+		p := New(lexer.Lex("<input>", tt.input))
+		expr := p.parseExpression(precedenceLowest)
+		checkParserErrors(t, p)
+		if got := treeString(expr); got != tt.expected {
+			t.Errorf("case%d:\n\tgot  =      %q,\n\twant =      %q", i+1, got, tt.expected)
+		}
+	}
+}
+
+// treeString renders an expression with explicit parentheses so that tests
+// can observe how operators were grouped.
+func treeString(expr ast.Expression) string {
+	switch v := expr.(type) {
+	case *ast.Identifier:
+		return v.Value
+	case *ast.InfixExpression:
+		return "(" + treeString(v.Left) + " " + strings.ToUpper(v.Operator.Literal) + " " + treeString(v.Right) + ")"
+	case *ast.PrefixExpression:
+		return "(" + strings.ToUpper(v.Operator.Literal) + " " + treeString(v.Right) + ")"
+	case *ast.TypeCastExpression:
+		return "(" + treeString(v.Expression) + " :: " + ast.FormatNode(v.Type) + ")"
+	case *ast.IsExpression:
+		return "(" + treeString(v.Expression) + " IS " + strings.ToUpper(v.Test.Literal) + ")"
+	case *ast.LikeExpression:
+		return "(" + treeString(v.Expression) + " LIKE " + treeString(v.Pattern) + ")"
+	case *ast.BetweenExpression:
+		return "(" + treeString(v.Expression) + " BETWEEN " + treeString(v.Low) + " AND " + treeString(v.High) + ")"
+	default:
+		return ast.FormatNode(expr)
+	}
+}
@@ -0,0 +1,477 @@
+package token
+
+// kwlist maps each keyword of PostgreSQL 16 (src/include/parser/kwlist.h) to
+// its category.
+var kwlist = map[string]KeywordCategory{
+	"ABORT":             UnreservedKeyword,
+	"ABSENT":            UnreservedKeyword,
+	"ABSOLUTE":          UnreservedKeyword,
+	"ACCESS":            UnreservedKeyword,
+	"ACTION":            UnreservedKeyword,
+	"ADD":               UnreservedKeyword,
+	"ADMIN":             UnreservedKeyword,
+	"AFTER":             UnreservedKeyword,
+	"AGGREGATE":         UnreservedKeyword,
+	"ALL":               ReservedKeyword,
+	"ALSO":              UnreservedKeyword,
+	"ALTER":             UnreservedKeyword,
+	"ALWAYS":            UnreservedKeyword,
+	"ANALYSE":           ReservedKeyword,
+	"ANALYZE":           ReservedKeyword,
+	"AND":               ReservedKeyword,
+	"ANY":               ReservedKeyword,
+	"ARRAY":             ReservedKeyword,
+	"AS":                ReservedKeyword,
+	"ASC":               ReservedKeyword,
+	"ASENSITIVE":        UnreservedKeyword,
+	"ASSERTION":         UnreservedKeyword,
+	"ASSIGNMENT":        UnreservedKeyword,
+	"ASYMMETRIC":        ReservedKeyword,
+	"AT":                UnreservedKeyword,
+	"ATOMIC":            UnreservedKeyword,
+	"ATTACH":            UnreservedKeyword,
+	"ATTRIBUTE":         UnreservedKeyword,
+	"AUTHORIZATION":     TypeFuncNameKeyword,
+	"BACKWARD":          UnreservedKeyword,
+	"BEFORE":            UnreservedKeyword,
+	"BEGIN":             UnreservedKeyword,
+	"BETWEEN":           ColNameKeyword,
+	"BIGINT":            ColNameKeyword,
+	"BINARY":            TypeFuncNameKeyword,
+	"BIT":               ColNameKeyword,
+	"BOOLEAN":           ColNameKeyword,
+	"BOTH":              ReservedKeyword,
+	"BREADTH":           UnreservedKeyword,
+	"BY":                UnreservedKeyword,
+	"CACHE":             UnreservedKeyword,
+	"CALL":              UnreservedKeyword,
+	"CALLED":            UnreservedKeyword,
+	"CASCADE":           UnreservedKeyword,
+	"CASCADED":          UnreservedKeyword,
+	"CASE":              ReservedKeyword,
+	"CAST":              ReservedKeyword,
+	"CATALOG":           UnreservedKeyword,
+	"CHAIN":             UnreservedKeyword,
+	"CHAR":              ColNameKeyword,
+	"CHARACTER":         ColNameKeyword,
+	"CHARACTERISTICS":   UnreservedKeyword,
+	"CHECK":             ReservedKeyword,
+	"CHECKPOINT":        UnreservedKeyword,
+	"CLASS":             UnreservedKeyword,
+	"CLOSE":             UnreservedKeyword,
+	"CLUSTER":           UnreservedKeyword,
+	"COALESCE":          ColNameKeyword,
+	"COLLATE":           ReservedKeyword,
+	"COLLATION":         TypeFuncNameKeyword,
+	"COLUMN":            ReservedKeyword,
+	"COLUMNS":           UnreservedKeyword,
+	"COMMENT":           UnreservedKeyword,
+	"COMMENTS":          UnreservedKeyword,
+	"COMMIT":            UnreservedKeyword,
+	"COMMITTED":         UnreservedKeyword,
+	"COMPRESSION":       UnreservedKeyword,
+	"CONCURRENTLY":      TypeFuncNameKeyword,
+	"CONFIGURATION":     UnreservedKeyword,
+	"CONFLICT":          UnreservedKeyword,
+	"CONNECTION":        UnreservedKeyword,
+	"CONSTRAINT":        ReservedKeyword,
+	"CONSTRAINTS":       UnreservedKeyword,
+	"CONTENT":           UnreservedKeyword,
+	"CONTINUE":          UnreservedKeyword,
+	"CONVERSION":        UnreservedKeyword,
+	"COPY":              UnreservedKeyword,
+	"COST":              UnreservedKeyword,
+	"CREATE":            ReservedKeyword,
+	"CROSS":             TypeFuncNameKeyword,
+	"CSV":               UnreservedKeyword,
+	"CUBE":              UnreservedKeyword,
+	"CURRENT":           UnreservedKeyword,
+	"CURRENT_CATALOG":   ReservedKeyword,
+	"CURRENT_DATE":      ReservedKeyword,
+	"CURRENT_ROLE":      ReservedKeyword,
+	"CURRENT_SCHEMA":    TypeFuncNameKeyword,
+	"CURRENT_TIME":      ReservedKeyword,
+	"CURRENT_TIMESTAMP": ReservedKeyword,
+	"CURRENT_USER":      ReservedKeyword,
+	"CURSOR":            UnreservedKeyword,
+	"CYCLE":             UnreservedKeyword,
+	"DATA":              UnreservedKeyword,
+	"DATABASE":          UnreservedKeyword,
+	"DAY":               UnreservedKeyword,
+	"DEALLOCATE":        UnreservedKeyword,
+	"DEC":               ColNameKeyword,
+	"DECIMAL":           ColNameKeyword,
+	"DECLARE":           UnreservedKeyword,
+	"DEFAULT":           ReservedKeyword,
+	"DEFAULTS":          UnreservedKeyword,
+	"DEFERRABLE":        ReservedKeyword,
+	"DEFERRED":          UnreservedKeyword,
+	"DEFINER":           UnreservedKeyword,
+	"DELETE":            UnreservedKeyword,
+	"DELIMITER":         UnreservedKeyword,
+	"DELIMITERS":        UnreservedKeyword,
+	"DEPENDS":           UnreservedKeyword,
+	"DEPTH":             UnreservedKeyword,
+	"DESC":              ReservedKeyword,
+	"DETACH":            UnreservedKeyword,
+	"DICTIONARY":        UnreservedKeyword,
+	"DISABLE":           UnreservedKeyword,
+	"DISCARD":           UnreservedKeyword,
+	"DISTINCT":          ReservedKeyword,
+	"DO":                ReservedKeyword,
+	"DOCUMENT":          UnreservedKeyword,
+	"DOMAIN":            UnreservedKeyword,
+	"DOUBLE":            UnreservedKeyword,
+	"DROP":              UnreservedKeyword,
+	"EACH":              UnreservedKeyword,
+	"ELSE":              ReservedKeyword,
+	"ENABLE":            UnreservedKeyword,
+	"ENCODING":          UnreservedKeyword,
+	"ENCRYPTED":         UnreservedKeyword,
+	"END":               ReservedKeyword,
+	"ENUM":              UnreservedKeyword,
+	"ESCAPE":            UnreservedKeyword,
+	"EVENT":             UnreservedKeyword,
+	"EXCEPT":            ReservedKeyword,
+	"EXCLUDE":           UnreservedKeyword,
+	"EXCLUDING":         UnreservedKeyword,
+	"EXCLUSIVE":         UnreservedKeyword,
+	"EXECUTE":           UnreservedKeyword,
+	"EXISTS":            ColNameKeyword,
+	"EXPLAIN":           UnreservedKeyword,
+	"EXPRESSION":        UnreservedKeyword,
+	"EXTENSION":         UnreservedKeyword,
+	"EXTERNAL":          UnreservedKeyword,
+	"EXTRACT":           ColNameKeyword,
+	"FALSE":             ReservedKeyword,
+	"FAMILY":            UnreservedKeyword,
+	"FETCH":             ReservedKeyword,
+	"FILTER":            UnreservedKeyword,
+	"FINALIZE":          UnreservedKeyword,
+	"FIRST":             UnreservedKeyword,
+	"FLOAT":             ColNameKeyword,
+	"FOLLOWING":         UnreservedKeyword,
+	"FOR":               ReservedKeyword,
+	"FORCE":             UnreservedKeyword,
+	"FOREIGN":           ReservedKeyword,
+	"FORMAT":            UnreservedKeyword,
+	"FORWARD":           UnreservedKeyword,
+	"FREEZE":            TypeFuncNameKeyword,
+	"FROM":              ReservedKeyword,
+	"FULL":              TypeFuncNameKeyword,
+	"FUNCTION":          UnreservedKeyword,
+	"FUNCTIONS":         UnreservedKeyword,
+	"GENERATED":         UnreservedKeyword,
+	"GLOBAL":            UnreservedKeyword,
+	"GRANT":             ReservedKeyword,
+	"GRANTED":           UnreservedKeyword,
+	"GREATEST":          ColNameKeyword,
+	"GROUP":             ReservedKeyword,
+	"GROUPING":          ColNameKeyword,
+	"GROUPS":            UnreservedKeyword,
+	"HANDLER":           UnreservedKeyword,
+	"HAVING":            ReservedKeyword,
+	"HEADER":            UnreservedKeyword,
+	"HOLD":              UnreservedKeyword,
+	"HOUR":              UnreservedKeyword,
+	"IDENTITY":          UnreservedKeyword,
+	"IF":                UnreservedKeyword,
+	"ILIKE":             TypeFuncNameKeyword,
+	"IMMEDIATE":         UnreservedKeyword,
+	"IMMUTABLE":         UnreservedKeyword,
+	"IMPLICIT":          UnreservedKeyword,
+	"IMPORT":            UnreservedKeyword,
+	"IN":                ReservedKeyword,
+	"INCLUDE":           UnreservedKeyword,
+	"INCLUDING":         UnreservedKeyword,
+	"INCREMENT":         UnreservedKeyword,
+	"INDENT":            UnreservedKeyword,
+	"INDEX":             UnreservedKeyword,
+	"INDEXES":           UnreservedKeyword,
+	"INHERIT":           UnreservedKeyword,
+	"INHERITS":          UnreservedKeyword,
+	"INITIALLY":         ReservedKeyword,
+	"INLINE":            UnreservedKeyword,
+	"INNER":             TypeFuncNameKeyword,
+	"INOUT":             ColNameKeyword,
+	"INPUT":             UnreservedKeyword,
+	"INSENSITIVE":       UnreservedKeyword,
+	"INSERT":            UnreservedKeyword,
+	"INSTEAD":           UnreservedKeyword,
+	"INT":               ColNameKeyword,
+	"INTEGER":           ColNameKeyword,
+	"INTERSECT":         ReservedKeyword,
+	"INTERVAL":          ColNameKeyword,
+	"INTO":              ReservedKeyword,
+	"INVOKER":           UnreservedKeyword,
+	"IS":                TypeFuncNameKeyword,
+	"ISNULL":            TypeFuncNameKeyword,
+	"ISOLATION":         UnreservedKeyword,
+	"JOIN":              TypeFuncNameKeyword,
+	"JSON":              ColNameKeyword,
+	"JSON_ARRAY":        ColNameKeyword,
+	"JSON_ARRAYAGG":     ColNameKeyword,
+	"JSON_OBJECT":       ColNameKeyword,
+	"JSON_OBJECTAGG":    ColNameKeyword,
+	"KEY":               UnreservedKeyword,
+	"KEYS":              UnreservedKeyword,
+	"LABEL":             UnreservedKeyword,
+	"LANGUAGE":          UnreservedKeyword,
+	"LARGE":             UnreservedKeyword,
+	"LAST":              UnreservedKeyword,
+	"LATERAL":           ReservedKeyword,
+	"LEADING":           ReservedKeyword,
+	"LEAKPROOF":         UnreservedKeyword,
+	"LEAST":             ColNameKeyword,
+	"LEFT":              TypeFuncNameKeyword,
+	"LEVEL":             UnreservedKeyword,
+	"LIKE":              TypeFuncNameKeyword,
+	"LIMIT":             ReservedKeyword,
+	"LISTEN":            UnreservedKeyword,
+	"LOAD":              UnreservedKeyword,
+	"LOCAL":             UnreservedKeyword,
+	"LOCALTIME":         ReservedKeyword,
+	"LOCALTIMESTAMP":    ReservedKeyword,
+	"LOCATION":          UnreservedKeyword,
+	"LOCK":              UnreservedKeyword,
+	"LOCKED":            UnreservedKeyword,
+	"LOGGED":            UnreservedKeyword,
+	"MAPPING":           UnreservedKeyword,
+	"MATCH":             UnreservedKeyword,
+	"MATCHED":           UnreservedKeyword,
+	"MATERIALIZED":      UnreservedKeyword,
+	"MAXVALUE":          UnreservedKeyword,
+	"MERGE":             UnreservedKeyword,
+	"METHOD":            UnreservedKeyword,
+	"MINUTE":            UnreservedKeyword,
+	"MINVALUE":          UnreservedKeyword,
+	"MODE":              UnreservedKeyword,
+	"MONTH":             UnreservedKeyword,
+	"MOVE":              UnreservedKeyword,
+	"NAME":              UnreservedKeyword,
+	"NAMES":             UnreservedKeyword,
+	"NATIONAL":          ColNameKeyword,
+	"NATURAL":           TypeFuncNameKeyword,
+	"NCHAR":             ColNameKeyword,
+	"NEW":               UnreservedKeyword,
+	"NEXT":              UnreservedKeyword,
+	"NFC":               UnreservedKeyword,
+	"NFD":               UnreservedKeyword,
+	"NFKC":              UnreservedKeyword,
+	"NFKD":              UnreservedKeyword,
+	"NO":                UnreservedKeyword,
+	"NONE":              ColNameKeyword,
+	"NORMALIZE":         ColNameKeyword,
+	"NORMALIZED":        UnreservedKeyword,
+	"NOT":               ReservedKeyword,
+	"NOTHING":           UnreservedKeyword,
+	"NOTIFY":            UnreservedKeyword,
+	"NOTNULL":           TypeFuncNameKeyword,
+	"NOWAIT":            UnreservedKeyword,
+	"NULL":              ReservedKeyword,
+	"NULLIF":            ColNameKeyword,
+	"NULLS":             UnreservedKeyword,
+	"NUMERIC":           ColNameKeyword,
+	"OBJECT":            UnreservedKeyword,
+	"OF":                UnreservedKeyword,
+	"OFF":               UnreservedKeyword,
+	"OFFSET":            ReservedKeyword,
+	"OIDS":              UnreservedKeyword,
+	"OLD":               UnreservedKeyword,
+	"ON":                ReservedKeyword,
+	"ONLY":              ReservedKeyword,
+	"OPERATOR":          UnreservedKeyword,
+	"OPTION":            UnreservedKeyword,
+	"OPTIONS":           UnreservedKeyword,
+	"OR":                ReservedKeyword,
+	"ORDER":             ReservedKeyword,
+	"ORDINALITY":        UnreservedKeyword,
+	"OTHERS":            UnreservedKeyword,
+	"OUT":               ColNameKeyword,
+	"OUTER":             TypeFuncNameKeyword,
+	"OVER":              UnreservedKeyword,
+	"OVERLAPS":          TypeFuncNameKeyword,
+	"OVERLAY":           ColNameKeyword,
+	"OVERRIDING":        UnreservedKeyword,
+	"OWNED":             UnreservedKeyword,
+	"OWNER":             UnreservedKeyword,
+	"PARALLEL":          UnreservedKeyword,
+	"PARAMETER":         UnreservedKeyword,
+	"PARSER":            UnreservedKeyword,
+	"PARTIAL":           UnreservedKeyword,
+	"PARTITION":         UnreservedKeyword,
+	"PASSING":           UnreservedKeyword,
+	"PASSWORD":          UnreservedKeyword,
+	"PLACING":           ReservedKeyword,
+	"PLANS":             UnreservedKeyword,
+	"POLICY":            UnreservedKeyword,
+	"POSITION":          ColNameKeyword,
+	"PRECEDING":         UnreservedKeyword,
+	"PRECISION":         ColNameKeyword,
+	"PREPARE":           UnreservedKeyword,
+	"PREPARED":          UnreservedKeyword,
+	"PRESERVE":          UnreservedKeyword,
+	"PRIMARY":           ReservedKeyword,
+	"PRIOR":             UnreservedKeyword,
+	"PRIVILEGES":        UnreservedKeyword,
+	"PROCEDURAL":        UnreservedKeyword,
+	"PROCEDURE":         UnreservedKeyword,
+	"PROCEDURES":        UnreservedKeyword,
+	"PROGRAM":           UnreservedKeyword,
+	"PUBLICATION":       UnreservedKeyword,
+	"QUOTE":             UnreservedKeyword,
+	"RANGE":             UnreservedKeyword,
+	"READ":              UnreservedKeyword,
+	"REAL":              ColNameKeyword,
+	"REASSIGN":          UnreservedKeyword,
+	"RECHECK":           UnreservedKeyword,
+	"RECURSIVE":         UnreservedKeyword,
+	"REF":               UnreservedKeyword,
+	"REFERENCES":        ReservedKeyword,
+	"REFERENCING":       UnreservedKeyword,
+	"REFRESH":           UnreservedKeyword,
+	"REINDEX":           UnreservedKeyword,
+	"RELATIVE":          UnreservedKeyword,
+	"RELEASE":           UnreservedKeyword,
+	"RENAME":            UnreservedKeyword,
+	"REPEATABLE":        UnreservedKeyword,
+	"REPLACE":           UnreservedKeyword,
+	"REPLICA":           UnreservedKeyword,
+	"RESET":             UnreservedKeyword,
+	"RESTART":           UnreservedKeyword,
+	"RESTRICT":          UnreservedKeyword,
+	"RETURN":            UnreservedKeyword,
+	"RETURNING":         ReservedKeyword,
+	"RETURNS":           UnreservedKeyword,
+	"REVOKE":            UnreservedKeyword,
+	"RIGHT":             TypeFuncNameKeyword,
+	"ROLE":              UnreservedKeyword,
+	"ROLLBACK":          UnreservedKeyword,
+	"ROLLUP":            UnreservedKeyword,
+	"ROUTINE":           UnreservedKeyword,
+	"ROUTINES":          UnreservedKeyword,
+	"ROW":               ColNameKeyword,
+	"ROWS":              UnreservedKeyword,
+	"RULE":              UnreservedKeyword,
+	"SAVEPOINT":         UnreservedKeyword,
+	"SCALAR":            UnreservedKeyword,
+	"SCHEMA":            UnreservedKeyword,
+	"SCHEMAS":           UnreservedKeyword,
+	"SCROLL":            UnreservedKeyword,
+	"SEARCH":            UnreservedKeyword,
+	"SECOND":            UnreservedKeyword,
+	"SECURITY":          UnreservedKeyword,
+	"SELECT":            ReservedKeyword,
+	"SEQUENCE":          UnreservedKeyword,
+	"SEQUENCES":         UnreservedKeyword,
+	"SERIALIZABLE":      UnreservedKeyword,
+	"SERVER":            UnreservedKeyword,
+	"SESSION":           UnreservedKeyword,
+	"SESSION_USER":      ReservedKeyword,
+	"SET":               UnreservedKeyword,
+	"SETOF":             ColNameKeyword,
+	"SETS":              UnreservedKeyword,
+	"SHARE":             UnreservedKeyword,
+	"SHOW":              UnreservedKeyword,
+	"SIMILAR":           TypeFuncNameKeyword,
+	"SIMPLE":            UnreservedKeyword,
+	"SKIP":              UnreservedKeyword,
+	"SMALLINT":          ColNameKeyword,
+	"SNAPSHOT":          UnreservedKeyword,
+	"SOME":              ReservedKeyword,
+	"SQL":               UnreservedKeyword,
+	"STABLE":            UnreservedKeyword,
+	"STANDALONE":        UnreservedKeyword,
+	"START":             UnreservedKeyword,
+	"STATEMENT":         UnreservedKeyword,
+	"STATISTICS":        UnreservedKeyword,
+	"STDIN":             UnreservedKeyword,
+	"STDOUT":            UnreservedKeyword,
+	"STORAGE":           UnreservedKeyword,
+	"STORED":            UnreservedKeyword,
+	"STRICT":            UnreservedKeyword,
+	"STRIP":             UnreservedKeyword,
+	"SUBSCRIPTION":      UnreservedKeyword,
+	"SUBSTRING":         ColNameKeyword,
+	"SUPPORT":           UnreservedKeyword,
+	"SYMMETRIC":         ReservedKeyword,
+	"SYSID":             UnreservedKeyword,
+	"SYSTEM":            UnreservedKeyword,
+	"SYSTEM_USER":       ReservedKeyword,
+	"TABLE":             ReservedKeyword,
+	"TABLES":            UnreservedKeyword,
+	"TABLESAMPLE":       TypeFuncNameKeyword,
+	"TABLESPACE":        UnreservedKeyword,
+	"TEMP":              UnreservedKeyword,
+	"TEMPLATE":          UnreservedKeyword,
+	"TEMPORARY":         UnreservedKeyword,
+	"TEXT":              UnreservedKeyword,
+	"THEN":              ReservedKeyword,
+	"TIES":              UnreservedKeyword,
+	"TIME":              ColNameKeyword,
+	"TIMESTAMP":         ColNameKeyword,
+	"TO":                ReservedKeyword,
+	"TRAILING":          ReservedKeyword,
+	"TRANSACTION":       UnreservedKeyword,
+	"TRANSFORM":         UnreservedKeyword,
+	"TREAT":             ColNameKeyword,
+	"TRIGGER":           UnreservedKeyword,
+	"TRIM":              ColNameKeyword,
+	"TRUE":              ReservedKeyword,
+	"TRUNCATE":          UnreservedKeyword,
+	"TRUSTED":           UnreservedKeyword,
+	"TYPE":              UnreservedKeyword,
+	"TYPES":             UnreservedKeyword,
+	"UESCAPE":           UnreservedKeyword,
+	"UNBOUNDED":         UnreservedKeyword,
+	"UNCOMMITTED":       UnreservedKeyword,
+	"UNENCRYPTED":       UnreservedKeyword,
+	"UNION":             ReservedKeyword,
+	"UNIQUE":            ReservedKeyword,
+	"UNKNOWN":           UnreservedKeyword,
+	"UNLISTEN":          UnreservedKeyword,
+	"UNLOGGED":          UnreservedKeyword,
+	"UNTIL":             UnreservedKeyword,
+	"UPDATE":            UnreservedKeyword,
+	"USER":              ReservedKeyword,
+	"USING":             ReservedKeyword,
+	"VACUUM":            UnreservedKeyword,
+	"VALID":             UnreservedKeyword,
+	"VALIDATE":          UnreservedKeyword,
+	"VALIDATOR":         UnreservedKeyword,
+	"VALUE":             UnreservedKeyword,
+	"VALUES":            ColNameKeyword,
+	"VARCHAR":           ColNameKeyword,
+	"VARIADIC":          ReservedKeyword,
+	"VARYING":           UnreservedKeyword,
+	"VERBOSE":           TypeFuncNameKeyword,
+	"VERSION":           UnreservedKeyword,
+	"VIEW":              UnreservedKeyword,
+	"VIEWS":             UnreservedKeyword,
+	"VOLATILE":          UnreservedKeyword,
+	"WHEN":              ReservedKeyword,
+	"WHERE":             ReservedKeyword,
+	"WHITESPACE":        UnreservedKeyword,
+	"WINDOW":            ReservedKeyword,
+	"WITH":              ReservedKeyword,
+	"WITHIN":            UnreservedKeyword,
+	"WITHOUT":           UnreservedKeyword,
+	"WORK":              UnreservedKeyword,
+	"WRAPPER":           UnreservedKeyword,
+	"WRITE":             UnreservedKeyword,
+	"XML":               UnreservedKeyword,
+	"XMLATTRIBUTES":     ColNameKeyword,
+	"XMLCONCAT":         ColNameKeyword,
+	"XMLELEMENT":        ColNameKeyword,
+	"XMLEXISTS":         ColNameKeyword,
+	"XMLFOREST":         ColNameKeyword,
+	"XMLNAMESPACES":     ColNameKeyword,
+	"XMLPARSE":          ColNameKeyword,
+	"XMLPI":             ColNameKeyword,
+	"XMLROOT":           ColNameKeyword,
+	"XMLSERIALIZE":      ColNameKeyword,
+	"XMLTABLE":          ColNameKeyword,
+	"YEAR":              UnreservedKeyword,
+	"YES":               UnreservedKeyword,
+	"ZONE":              UnreservedKeyword,
+}
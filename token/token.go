@@ -14,6 +14,8 @@ const (
 	Comment
 	CommentBlock
 	CommentLine
+	CopyData
+	MetaCommand
 	Identifier
 	String
 	Number
@@ -29,67 +31,198 @@ const (
 	Minus
 	Asterisk
 	Slash
+	Percent
+	Caret
+	LessThan
+	GreaterThan
+	LessEquals
+	GreaterEquals
+	NotEquals
+	EqualsGreater
+	Op
+	Colon
 	Typecast
+	Keyword
 
 	Add
+	Access
+	Action
+	All
 	Alter
+	Always
+	And
+	Any
+	Array
+	As
 	Asc
-	BackslashConnect
+	Asymmetric
+	At
+	Attach
+	Begin
+	Between
 	By
 	Cache
+	Cascade
+	Case
+	Cast
+	Check
+	Cluster
+	Collate
+	Collation
 	Column
+	Commit
+	Compression
 	Concurrently
 	Constraint
+	Copy
 	Create
+	CurrentCatalog
+	CurrentDate
+	CurrentRole
+	CurrentSchema
+	CurrentTime
+	CurrentTimestamp
+	CurrentUser
+	Data
 	Database
 	Default
+	Deferrable
+	Deferred
+	Delete
 	Desc
+	Detach
+	Disable
+	Distinct
+	Drop
+	Else
+	Enable
+	End
+	Escape
+	Except
 	Exists
 	Extension
+	External
 	False
+	Finalize
+	First
+	For
+	Force
+	Foreign
+	From
+	Full
 	Function
+	Global
 	Grant
+	Identity
 	If
+	Ilike
+	Immediate
+	Import
+	In
+	Include
 	Increment
 	Index
+	Inherit
+	Inherits
+	Initially
 	Insert
+	Into
 	Is
+	Isnull
 	Key
+	Last
+	Level
+	Like
+	Limit
+	Local
+	Localtime
+	Localtimestamp
+	Logged
+	Mapping
+	Match
 	Maxvalue
+	Method
 	Minvalue
 	No
 	Not
+	Nothing
+	Notnull
 	Null
+	Nulls
+	Of
+	Oids
 	On
 	Only
 	Operator
+	Options
+	Or
 	Owned
 	Owner
+	Partial
+	Partition
+	Policy
+	Preserve
 	Primary
+	Range
+	References
+	Rename
+	Replica
+	Reset
+	Restrict
 	Revoke
 	Role
+	Rollback
+	Row
+	Rows
 	Schema
+	Security
 	Select
 	Sequence
+	Server
+	Session
+	SessionUser
 	Set
+	Similar
+	Simple
+	Some
 	Start
+	Statistics
+	Storage
+	Symmetric
 	Table
+	Tablespace
+	Temp
+	Temporary
 	TextPatternOps
+	Then
 	To
+	Transaction
 	Trigger
 	True
+	Type
 	Unique
+	Unknown
+	Unlogged
 	Update
+	User
 	Using
-	Varying
+	Valid
+	Values
 	VarcharPatternOps
+	Varying
+	Version
 	View
+	When
+	Where
 	With
 	Without
+	Work
+	Wrapper
 	Zone
 
 	Bigint
 	Smallint
+	Smallserial
 	Bigserial
 	Boolean
 	Bytea
@@ -106,109 +239,308 @@ const (
 	Uuid
 )
 
-type keyword struct {
-	Type     TokenType
-	Reserved bool
+// KeywordCategory is the category of a PostgreSQL keyword, which decides
+// where it can be used as a name.
+type KeywordCategory int
+
+const (
+	// NotKeyword is the category of words which are not keywords.
+	NotKeyword KeywordCategory = iota
+	// UnreservedKeyword can be used as any name.
+	UnreservedKeyword
+	// ColNameKeyword can be used as a column or table name, but not as a
+	// function or type name.
+	ColNameKeyword
+	// TypeFuncNameKeyword can be used as a function or type name, but not
+	// as a column or table name.
+	TypeFuncNameKeyword
+	// ReservedKeyword can only be used as a column label after AS or a dot.
+	ReservedKeyword
+)
+
+// LookupKeyword returns the category of word.
+func LookupKeyword(word string) KeywordCategory {
+	return kwlist[strings.ToUpper(word)]
 }
 
-// https://www.postgresql.org/docs/10/sql-keywords-appendix.html
-var keywords = map[string]keyword{
-	"ADD":                 {Add, false},
-	"ALTER":               {Alter, false},
-	"ASC":                 {Asc, true},
-	"\\CONNECT":           {BackslashConnect, false},
-	"BIGINT":              {Bigint, false},
-	"BIGSERIAL":           {Bigserial, false},
-	"BOOLEAN":             {Boolean, false},
-	"BY":                  {By, false},
-	"BYTEA":               {Bytea, false},
-	"CACHE":               {Cache, false},
-	"CHARACTER":           {Character, false},
-	"COLUMN":              {Column, true},
-	"COMMENT":             {Comment, false},
-	"CONCURRENTLY":        {Concurrently, true}, // reserved (can be function or type)
-	"CONSTRAINT":          {Constraint, true},
-	"CREATE":              {Create, true},
-	"DATABASE":            {Database, false},
-	"DATE":                {Date, false},
-	"DEFAULT":             {Default, true},
-	"DESC":                {Desc, true},
-	"EXISTS":              {Exists, false}, // non-reserved (cannot be function or type)
-	"EXTENSION":           {Extension, false},
-	"FALSE":               {False, true},
-	"FUNCTION":            {Function, false},
-	"GRANT":               {Grant, true},
-	"IF":                  {If, false},
-	"INCREMENT":           {Increment, false},
-	"INDEX":               {Index, false},
-	"INSERT":              {Insert, false},
-	"INTEGER":             {Integer, false},
-	"IS":                  {Is, true}, // reserved (can be function or type)
-	"JSONB":               {Jsonb, false},
-	"KEY":                 {Key, false},
-	"MAXVALUE":            {Maxvalue, false},
-	"MINVALUE":            {Minvalue, false},
-	"NO":                  {No, false},
-	"NOT":                 {Not, true},
-	"NULL":                {Null, true},
-	"NUMERIC":             {Numeric, false},
-	"ON":                  {On, true},
-	"ONLY":                {Only, true},
-	"OPERATOR":            {Operator, false},
-	"OWNED":               {Owned, false},
-	"OWNER":               {Owner, false},
-	"PRIMARY":             {Primary, true},
-	"REVOKE":              {Revoke, false},
-	"ROLE":                {Role, false},
-	"SCHEMA":              {Schema, false},
-	"SELECT":              {Select, true},
-	"SEQUENCE":            {Sequence, false},
-	"SERIAL":              {Serial, false},
-	"SET":                 {Set, false},
-	"SMALLINT":            {Smallint, false},
-	"START":               {Start, false},
-	"TABLE":               {Table, true},
-	"TEXT":                {Text, false},
-	"TEXT_PATTERN_OPS":    {TextPatternOps, false},
-	"TIME":                {Time, false},
-	"TIMESTAMP":           {Timestamp, false},
-	"TO":                  {To, true},
-	"TRIGGER":             {Trigger, false},
-	"TRUE":                {True, true},
-	"TSVECTOR":            {Tsvector, false},
-	"UNIQUE":              {Unique, true},
-	"UPDATE":              {Update, false},
-	"USING":               {Using, true},
-	"UUID":                {Uuid, false},
-	"VARYING":             {Varying, false},
-	"VARCHAR_PATTERN_OPS": {VarcharPatternOps, false},
-	"VIEW":                {View, false},
-	"WITH":                {With, true},
-	"WITHOUT":             {Without, true},
-	"ZONE":                {Zone, false},
+// keywords maps words to the token types the parser uses for them. Besides
+// PostgreSQL keywords, it contains words such as type names and operator
+// classes which PostgreSQL lexes as identifiers; kwlist has the categories.
+var keywords = map[string]TokenType{
+	"ACCESS":              Access,
+	"ACTION":              Action,
+	"ADD":                 Add,
+	"ALL":                 All,
+	"ALTER":               Alter,
+	"ALWAYS":              Always,
+	"AND":                 And,
+	"ANY":                 Any,
+	"ARRAY":               Array,
+	"AS":                  As,
+	"ASC":                 Asc,
+	"ASYMMETRIC":          Asymmetric,
+	"AT":                  At,
+	"ATTACH":              Attach,
+	"BEGIN":               Begin,
+	"BETWEEN":             Between,
+	"BIGINT":              Bigint,
+	"BIGSERIAL":           Bigserial,
+	"BOOLEAN":             Boolean,
+	"BY":                  By,
+	"BYTEA":               Bytea,
+	"CACHE":               Cache,
+	"CASCADE":             Cascade,
+	"CASE":                Case,
+	"CAST":                Cast,
+	"CHARACTER":           Character,
+	"CHECK":               Check,
+	"CLUSTER":             Cluster,
+	"COLLATE":             Collate,
+	"COLLATION":           Collation,
+	"COLUMN":              Column,
+	"COMMENT":             Comment,
+	"COMMIT":              Commit,
+	"COMPRESSION":         Compression,
+	"CONCURRENTLY":        Concurrently,
+	"CONSTRAINT":          Constraint,
+	"COPY":                Copy,
+	"CREATE":              Create,
+	"CURRENT_CATALOG":     CurrentCatalog,
+	"CURRENT_DATE":        CurrentDate,
+	"CURRENT_ROLE":        CurrentRole,
+	"CURRENT_SCHEMA":      CurrentSchema,
+	"CURRENT_TIME":        CurrentTime,
+	"CURRENT_TIMESTAMP":   CurrentTimestamp,
+	"CURRENT_USER":        CurrentUser,
+	"DATA":                Data,
+	"DATABASE":            Database,
+	"DATE":                Date,
+	"DEFAULT":             Default,
+	"DEFERRABLE":          Deferrable,
+	"DEFERRED":            Deferred,
+	"DELETE":              Delete,
+	"DESC":                Desc,
+	"DETACH":              Detach,
+	"DISABLE":             Disable,
+	"DISTINCT":            Distinct,
+	"DROP":                Drop,
+	"ELSE":                Else,
+	"ENABLE":              Enable,
+	"END":                 End,
+	"ESCAPE":              Escape,
+	"EXCEPT":              Except,
+	"EXISTS":              Exists,
+	"EXTENSION":           Extension,
+	"EXTERNAL":            External,
+	"FALSE":               False,
+	"FINALIZE":            Finalize,
+	"FIRST":               First,
+	"FOR":                 For,
+	"FORCE":               Force,
+	"FOREIGN":             Foreign,
+	"FROM":                From,
+	"FULL":                Full,
+	"FUNCTION":            Function,
+	"GLOBAL":              Global,
+	"GRANT":               Grant,
+	"IDENTITY":            Identity,
+	"IF":                  If,
+	"ILIKE":               Ilike,
+	"IMMEDIATE":           Immediate,
+	"IMPORT":              Import,
+	"IN":                  In,
+	"INCLUDE":             Include,
+	"INCREMENT":           Increment,
+	"INDEX":               Index,
+	"INHERIT":             Inherit,
+	"INHERITS":            Inherits,
+	"INITIALLY":           Initially,
+	"INSERT":              Insert,
+	"INT":                 Integer,
+	"INTEGER":             Integer,
+	"INTO":                Into,
+	"IS":                  Is,
+	"ISNULL":              Isnull,
+	"JSONB":               Jsonb,
+	"KEY":                 Key,
+	"LAST":                Last,
+	"LEVEL":               Level,
+	"LIKE":                Like,
+	"LIMIT":               Limit,
+	"LOCAL":               Local,
+	"LOCALTIME":           Localtime,
+	"LOCALTIMESTAMP":      Localtimestamp,
+	"LOGGED":              Logged,
+	"MAPPING":             Mapping,
+	"MATCH":               Match,
+	"MAXVALUE":            Maxvalue,
+	"METHOD":              Method,
+	"MINVALUE":            Minvalue,
+	"NO":                  No,
+	"NOT":                 Not,
+	"NOTHING":             Nothing,
+	"NOTNULL":             Notnull,
+	"NULL":                Null,
+	"NULLS":               Nulls,
+	"NUMERIC":             Numeric,
+	"OF":                  Of,
+	"OIDS":                Oids,
+	"ON":                  On,
+	"ONLY":                Only,
+	"OPERATOR":            Operator,
+	"OPTIONS":             Options,
+	"OR":                  Or,
+	"OWNED":               Owned,
+	"OWNER":               Owner,
+	"PARTIAL":             Partial,
+	"PARTITION":           Partition,
+	"POLICY":              Policy,
+	"PRESERVE":            Preserve,
+	"PRIMARY":             Primary,
+	"RANGE":               Range,
+	"REFERENCES":          References,
+	"RENAME":              Rename,
+	"REPLICA":             Replica,
+	"RESET":               Reset,
+	"RESTRICT":            Restrict,
+	"REVOKE":              Revoke,
+	"ROLE":                Role,
+	"ROLLBACK":            Rollback,
+	"ROW":                 Row,
+	"ROWS":                Rows,
+	"SCHEMA":              Schema,
+	"SECURITY":            Security,
+	"SELECT":              Select,
+	"SEQUENCE":            Sequence,
+	"SERIAL":              Serial,
+	"SERVER":              Server,
+	"SESSION":             Session,
+	"SESSION_USER":        SessionUser,
+	"SET":                 Set,
+	"SIMILAR":             Similar,
+	"SIMPLE":              Simple,
+	"SMALLINT":            Smallint,
+	"SMALLSERIAL":         Smallserial,
+	"SOME":                Some,
+	"START":               Start,
+	"STATISTICS":          Statistics,
+	"STORAGE":             Storage,
+	"SYMMETRIC":           Symmetric,
+	"TABLE":               Table,
+	"TABLESPACE":          Tablespace,
+	"TEMP":                Temp,
+	"TEMPORARY":           Temporary,
+	"TEXT":                Text,
+	"TEXT_PATTERN_OPS":    TextPatternOps,
+	"THEN":                Then,
+	"TIME":                Time,
+	"TIMESTAMP":           Timestamp,
+	"TO":                  To,
+	"TRANSACTION":         Transaction,
+	"TRIGGER":             Trigger,
+	"TRUE":                True,
+	"TSVECTOR":            Tsvector,
+	"TYPE":                Type,
+	"UNIQUE":              Unique,
+	"UNKNOWN":             Unknown,
+	"UNLOGGED":            Unlogged,
+	"UPDATE":              Update,
+	"USER":                User,
+	"USING":               Using,
+	"UUID":                Uuid,
+	"VALID":               Valid,
+	"VALUES":              Values,
+	"VARCHAR_PATTERN_OPS": VarcharPatternOps,
+	"VARYING":             Varying,
+	"VERSION":             Version,
+	"VIEW":                View,
+	"WHEN":                When,
+	"WHERE":               Where,
+	"WITH":                With,
+	"WITHOUT":             Without,
+	"WORK":                Work,
+	"WRAPPER":             Wrapper,
+	"ZONE":                Zone,
 }
 
 type Token struct {
 	Type    TokenType
 	Literal string
 	Line    int
+
+	// Value is the decoded content of the token: the content of a String,
+	// e.g. "a\nb" for E'a\nb'; the decimal form of a Number, e.g. "31" for
+	// 0x1F; the name of an Identifier or keyword, e.g. "users" for Users
+	// and `a"b` for "a""b".
+	Value string
+}
+
+// Category returns the keyword category of tok. Identifiers, including
+// quoted ones, and symbols are NotKeyword.
+func (tok *Token) Category() KeywordCategory {
+	if !tok.IsWord() || tok.Type == Identifier {
+		return NotKeyword
+	}
+	return LookupKeyword(tok.Literal)
+}
+
+// IsWord reports whether tok is an identifier or a keyword, as opposed to a
+// literal or a symbol.
+func (tok *Token) IsWord() bool {
+	switch tok.Type {
+	case Identifier, Keyword:
+		return true
+	default:
+		return LookupIdent(tok.Literal) == tok.Type
+	}
 }
 
 func (tok *Token) IsKeyword() bool {
-	key := strings.ToUpper(tok.Type.String())
-	_, ok := keywords[key]
-	return ok
+	return tok.Category() != NotKeyword
 }
 
+// IsReserved reports whether tok is a keyword which cannot be used as a
+// column or table name.
 func (tok *Token) IsReserved() bool {
-	key := strings.ToUpper(tok.Type.String())
-	keyword, ok := keywords[key]
-	return ok && keyword.Reserved
+	switch tok.Category() {
+	case TypeFuncNameKeyword, ReservedKeyword:
+		return true
+	default:
+		return false
+	}
 }
 
+// LookupIdent returns the token type of the unquoted word ident: the
+// dedicated type if the parser has one, Keyword for the other keywords,
+// or Identifier.
 func LookupIdent(ident string) TokenType {
-	if keyword, ok := keywords[strings.ToUpper(ident)]; ok {
-		return keyword.Type
+	word := strings.ToUpper(ident)
+	if typ, ok := keywords[word]; ok {
+		return typ
+	}
+	if _, ok := kwlist[word]; ok {
+		return Keyword
 	}
 	return Identifier
 }
+
+// NeedsQuoting reports whether ident has to be written as a quoted
+// identifier to be read back unchanged: it does not consist of lower case
+// letters, digits and underscores, starts with a digit, or is a keyword
+// other than an unreserved one, as PostgreSQL's quote_identifier decides.
+func NeedsQuoting(ident string) bool {
+	if ident == "" || (ident[0] >= '0' && ident[0] <= '9') {
+		return true
+	}
+	for _, r := range ident {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '_') {
+			return true
+		}
+	}
+	switch LookupKeyword(ident) {
+	case NotKeyword, UnreservedKeyword:
+		return false
+	default:
+		return true
+	}
+}
@@ -14,99 +14,232 @@ func _() {
 	_ = x[Comment-3]
 	_ = x[CommentBlock-4]
 	_ = x[CommentLine-5]
-	_ = x[Identifier-6]
-	_ = x[String-7]
-	_ = x[Number-8]
-	_ = x[Dot-9]
-	_ = x[Semicolon-10]
-	_ = x[Comma-11]
-	_ = x[LParen-12]
-	_ = x[RParen-13]
-	_ = x[LBracket-14]
-	_ = x[RBracket-15]
-	_ = x[Equal-16]
-	_ = x[Plus-17]
-	_ = x[Minus-18]
-	_ = x[Asterisk-19]
-	_ = x[Slash-20]
-	_ = x[Typecast-21]
-	_ = x[Add-22]
-	_ = x[Alter-23]
-	_ = x[Asc-24]
-	_ = x[BackslashConnect-25]
-	_ = x[By-26]
-	_ = x[Cache-27]
-	_ = x[Column-28]
-	_ = x[Concurrently-29]
-	_ = x[Constraint-30]
-	_ = x[Create-31]
-	_ = x[Database-32]
-	_ = x[Default-33]
-	_ = x[Desc-34]
-	_ = x[Exists-35]
-	_ = x[Extension-36]
-	_ = x[False-37]
-	_ = x[Function-38]
-	_ = x[Grant-39]
-	_ = x[If-40]
-	_ = x[Increment-41]
-	_ = x[Index-42]
-	_ = x[Insert-43]
-	_ = x[Is-44]
-	_ = x[Key-45]
-	_ = x[Maxvalue-46]
-	_ = x[Minvalue-47]
-	_ = x[No-48]
-	_ = x[Not-49]
-	_ = x[Null-50]
-	_ = x[On-51]
-	_ = x[Only-52]
-	_ = x[Operator-53]
-	_ = x[Owned-54]
-	_ = x[Owner-55]
-	_ = x[Primary-56]
-	_ = x[Revoke-57]
-	_ = x[Role-58]
-	_ = x[Schema-59]
-	_ = x[Select-60]
-	_ = x[Sequence-61]
-	_ = x[Set-62]
-	_ = x[Start-63]
-	_ = x[Table-64]
-	_ = x[TextPatternOps-65]
-	_ = x[To-66]
-	_ = x[Trigger-67]
-	_ = x[True-68]
-	_ = x[Unique-69]
-	_ = x[Update-70]
-	_ = x[Using-71]
-	_ = x[Varying-72]
-	_ = x[VarcharPatternOps-73]
-	_ = x[View-74]
-	_ = x[With-75]
-	_ = x[Without-76]
-	_ = x[Zone-77]
-	_ = x[Bigint-78]
-	_ = x[Smallint-79]
-	_ = x[Bigserial-80]
-	_ = x[Boolean-81]
-	_ = x[Bytea-82]
-	_ = x[Character-83]
-	_ = x[Date-84]
-	_ = x[Integer-85]
-	_ = x[Jsonb-86]
-	_ = x[Numeric-87]
-	_ = x[Serial-88]
-	_ = x[Text-89]
-	_ = x[Timestamp-90]
-	_ = x[Time-91]
-	_ = x[Tsvector-92]
-	_ = x[Uuid-93]
+	_ = x[CopyData-6]
+	_ = x[MetaCommand-7]
+	_ = x[Identifier-8]
+	_ = x[String-9]
+	_ = x[Number-10]
+	_ = x[Dot-11]
+	_ = x[Semicolon-12]
+	_ = x[Comma-13]
+	_ = x[LParen-14]
+	_ = x[RParen-15]
+	_ = x[LBracket-16]
+	_ = x[RBracket-17]
+	_ = x[Equal-18]
+	_ = x[Plus-19]
+	_ = x[Minus-20]
+	_ = x[Asterisk-21]
+	_ = x[Slash-22]
+	_ = x[Percent-23]
+	_ = x[Caret-24]
+	_ = x[LessThan-25]
+	_ = x[GreaterThan-26]
+	_ = x[LessEquals-27]
+	_ = x[GreaterEquals-28]
+	_ = x[NotEquals-29]
+	_ = x[EqualsGreater-30]
+	_ = x[Op-31]
+	_ = x[Colon-32]
+	_ = x[Typecast-33]
+	_ = x[Keyword-34]
+	_ = x[Add-35]
+	_ = x[Access-36]
+	_ = x[Action-37]
+	_ = x[All-38]
+	_ = x[Alter-39]
+	_ = x[Always-40]
+	_ = x[And-41]
+	_ = x[Any-42]
+	_ = x[Array-43]
+	_ = x[As-44]
+	_ = x[Asc-45]
+	_ = x[Asymmetric-46]
+	_ = x[At-47]
+	_ = x[Attach-48]
+	_ = x[Begin-49]
+	_ = x[Between-50]
+	_ = x[By-51]
+	_ = x[Cache-52]
+	_ = x[Cascade-53]
+	_ = x[Case-54]
+	_ = x[Cast-55]
+	_ = x[Check-56]
+	_ = x[Cluster-57]
+	_ = x[Collate-58]
+	_ = x[Collation-59]
+	_ = x[Column-60]
+	_ = x[Commit-61]
+	_ = x[Compression-62]
+	_ = x[Concurrently-63]
+	_ = x[Constraint-64]
+	_ = x[Copy-65]
+	_ = x[Create-66]
+	_ = x[CurrentCatalog-67]
+	_ = x[CurrentDate-68]
+	_ = x[CurrentRole-69]
+	_ = x[CurrentSchema-70]
+	_ = x[CurrentTime-71]
+	_ = x[CurrentTimestamp-72]
+	_ = x[CurrentUser-73]
+	_ = x[Data-74]
+	_ = x[Database-75]
+	_ = x[Default-76]
+	_ = x[Deferrable-77]
+	_ = x[Deferred-78]
+	_ = x[Delete-79]
+	_ = x[Desc-80]
+	_ = x[Detach-81]
+	_ = x[Disable-82]
+	_ = x[Distinct-83]
+	_ = x[Drop-84]
+	_ = x[Else-85]
+	_ = x[Enable-86]
+	_ = x[End-87]
+	_ = x[Escape-88]
+	_ = x[Except-89]
+	_ = x[Exists-90]
+	_ = x[Extension-91]
+	_ = x[External-92]
+	_ = x[False-93]
+	_ = x[Finalize-94]
+	_ = x[First-95]
+	_ = x[For-96]
+	_ = x[Force-97]
+	_ = x[Foreign-98]
+	_ = x[From-99]
+	_ = x[Full-100]
+	_ = x[Function-101]
+	_ = x[Global-102]
+	_ = x[Grant-103]
+	_ = x[Identity-104]
+	_ = x[If-105]
+	_ = x[Ilike-106]
+	_ = x[Immediate-107]
+	_ = x[Import-108]
+	_ = x[In-109]
+	_ = x[Include-110]
+	_ = x[Increment-111]
+	_ = x[Index-112]
+	_ = x[Inherit-113]
+	_ = x[Inherits-114]
+	_ = x[Initially-115]
+	_ = x[Insert-116]
+	_ = x[Into-117]
+	_ = x[Is-118]
+	_ = x[Isnull-119]
+	_ = x[Key-120]
+	_ = x[Last-121]
+	_ = x[Level-122]
+	_ = x[Like-123]
+	_ = x[Limit-124]
+	_ = x[Local-125]
+	_ = x[Localtime-126]
+	_ = x[Localtimestamp-127]
+	_ = x[Logged-128]
+	_ = x[Mapping-129]
+	_ = x[Match-130]
+	_ = x[Maxvalue-131]
+	_ = x[Method-132]
+	_ = x[Minvalue-133]
+	_ = x[No-134]
+	_ = x[Not-135]
+	_ = x[Nothing-136]
+	_ = x[Notnull-137]
+	_ = x[Null-138]
+	_ = x[Nulls-139]
+	_ = x[Of-140]
+	_ = x[Oids-141]
+	_ = x[On-142]
+	_ = x[Only-143]
+	_ = x[Operator-144]
+	_ = x[Options-145]
+	_ = x[Or-146]
+	_ = x[Owned-147]
+	_ = x[Owner-148]
+	_ = x[Partial-149]
+	_ = x[Partition-150]
+	_ = x[Policy-151]
+	_ = x[Preserve-152]
+	_ = x[Primary-153]
+	_ = x[Range-154]
+	_ = x[References-155]
+	_ = x[Rename-156]
+	_ = x[Replica-157]
+	_ = x[Reset-158]
+	_ = x[Restrict-159]
+	_ = x[Revoke-160]
+	_ = x[Role-161]
+	_ = x[Rollback-162]
+	_ = x[Row-163]
+	_ = x[Rows-164]
+	_ = x[Schema-165]
+	_ = x[Security-166]
+	_ = x[Select-167]
+	_ = x[Sequence-168]
+	_ = x[Server-169]
+	_ = x[Session-170]
+	_ = x[SessionUser-171]
+	_ = x[Set-172]
+	_ = x[Similar-173]
+	_ = x[Simple-174]
+	_ = x[Some-175]
+	_ = x[Start-176]
+	_ = x[Statistics-177]
+	_ = x[Storage-178]
+	_ = x[Symmetric-179]
+	_ = x[Table-180]
+	_ = x[Tablespace-181]
+	_ = x[Temp-182]
+	_ = x[Temporary-183]
+	_ = x[TextPatternOps-184]
+	_ = x[Then-185]
+	_ = x[To-186]
+	_ = x[Transaction-187]
+	_ = x[Trigger-188]
+	_ = x[True-189]
+	_ = x[Type-190]
+	_ = x[Unique-191]
+	_ = x[Unknown-192]
+	_ = x[Unlogged-193]
+	_ = x[Update-194]
+	_ = x[User-195]
+	_ = x[Using-196]
+	_ = x[Valid-197]
+	_ = x[Values-198]
+	_ = x[VarcharPatternOps-199]
+	_ = x[Varying-200]
+	_ = x[Version-201]
+	_ = x[View-202]
+	_ = x[When-203]
+	_ = x[Where-204]
+	_ = x[With-205]
+	_ = x[Without-206]
+	_ = x[Work-207]
+	_ = x[Wrapper-208]
+	_ = x[Zone-209]
+	_ = x[Bigint-210]
+	_ = x[Smallint-211]
+	_ = x[Smallserial-212]
+	_ = x[Bigserial-213]
+	_ = x[Boolean-214]
+	_ = x[Bytea-215]
+	_ = x[Character-216]
+	_ = x[Date-217]
+	_ = x[Integer-218]
+	_ = x[Jsonb-219]
+	_ = x[Numeric-220]
+	_ = x[Serial-221]
+	_ = x[Text-222]
+	_ = x[Timestamp-223]
+	_ = x[Time-224]
+	_ = x[Tsvector-225]
+	_ = x[Uuid-226]
 }
 
-const _TokenType_name = "IllegalEOFSpaceCommentCommentBlockCommentLineIdentifierStringNumberDotSemicolonCommaLParenRParenLBracketRBracketEqualPlusMinusAsteriskSlashTypecastAddAlterAscBackslashConnectByCacheColumnConcurrentlyConstraintCreateDatabaseDefaultDescExistsExtensionFalseFunctionGrantIfIncrementIndexInsertIsKeyMaxvalueMinvalueNoNotNullOnOnlyOperatorOwnedOwnerPrimaryRevokeRoleSchemaSelectSequenceSetStartTableTextPatternOpsToTriggerTrueUniqueUpdateUsingVaryingVarcharPatternOpsViewWithWithoutZoneBigintSmallintBigserialBooleanByteaCharacterDateIntegerJsonbNumericSerialTextTimestampTimeTsvectorUuid"
+const _TokenType_name = "IllegalEOFSpaceCommentCommentBlockCommentLineCopyDataMetaCommandIdentifierStringNumberDotSemicolonCommaLParenRParenLBracketRBracketEqualPlusMinusAsteriskSlashPercentCaretLessThanGreaterThanLessEqualsGreaterEqualsNotEqualsEqualsGreaterOpColonTypecastKeywordAddAccessActionAllAlterAlwaysAndAnyArrayAsAscAsymmetricAtAttachBeginBetweenByCacheCascadeCaseCastCheckClusterCollateCollationColumnCommitCompressionConcurrentlyConstraintCopyCreateCurrentCatalogCurrentDateCurrentRoleCurrentSchemaCurrentTimeCurrentTimestampCurrentUserDataDatabaseDefaultDeferrableDeferredDeleteDescDetachDisableDistinctDropElseEnableEndEscapeExceptExistsExtensionExternalFalseFinalizeFirstForForceForeignFromFullFunctionGlobalGrantIdentityIfIlikeImmediateImportInIncludeIncrementIndexInheritInheritsInitiallyInsertIntoIsIsnullKeyLastLevelLikeLimitLocalLocaltimeLocaltimestampLoggedMappingMatchMaxvalueMethodMinvalueNoNotNothingNotnullNullNullsOfOidsOnOnlyOperatorOptionsOrOwnedOwnerPartialPartitionPolicyPreservePrimaryRangeReferencesRenameReplicaResetRestrictRevokeRoleRollbackRowRowsSchemaSecuritySelectSequenceServerSessionSessionUserSetSimilarSimpleSomeStartStatisticsStorageSymmetricTableTablespaceTempTemporaryTextPatternOpsThenToTransactionTriggerTrueTypeUniqueUnknownUnloggedUpdateUserUsingValidValuesVarcharPatternOpsVaryingVersionViewWhenWhereWithWithoutWorkWrapperZoneBigintSmallintSmallserialBigserialBooleanByteaCharacterDateIntegerJsonbNumericSerialTextTimestampTimeTsvectorUuid"
 
-var _TokenType_index = [...]uint16{0, 7, 10, 15, 22, 34, 45, 55, 61, 67, 70, 79, 84, 90, 96, 104, 112, 117, 121, 126, 134, 139, 147, 150, 155, 158, 174, 176, 181, 187, 199, 209, 215, 223, 230, 234, 240, 249, 254, 262, 267, 269, 278, 283, 289, 291, 294, 302, 310, 312, 315, 319, 321, 325, 333, 338, 343, 350, 356, 360, 366, 372, 380, 383, 388, 393, 407, 409, 416, 420, 426, 432, 437, 444, 461, 465, 469, 476, 480, 486, 494, 503, 510, 515, 524, 528, 535, 540, 547, 553, 557, 566, 570, 578, 582}
+var _TokenType_index = [...]uint16{0, 7, 10, 15, 22, 34, 45, 53, 64, 74, 80, 86, 89, 98, 103, 109, 115, 123, 131, 136, 140, 145, 153, 158, 165, 170, 178, 189, 199, 212, 221, 234, 236, 241, 249, 256, 259, 265, 271, 274, 279, 285, 288, 291, 296, 298, 301, 311, 313, 319, 324, 331, 333, 338, 345, 349, 353, 358, 365, 372, 381, 387, 393, 404, 416, 426, 430, 436, 450, 461, 472, 485, 496, 512, 523, 527, 535, 542, 552, 560, 566, 570, 576, 583, 591, 595, 599, 605, 608, 614, 620, 626, 635, 643, 648, 656, 661, 664, 669, 676, 680, 684, 692, 698, 703, 711, 713, 718, 727, 733, 735, 742, 751, 756, 763, 771, 780, 786, 790, 792, 798, 801, 805, 810, 814, 819, 824, 833, 847, 853, 860, 865, 873, 879, 887, 889, 892, 899, 906, 910, 915, 917, 921, 923, 927, 935, 942, 944, 949, 954, 961, 970, 976, 984, 991, 996, 1006, 1012, 1019, 1024, 1032, 1038, 1042, 1050, 1053, 1057, 1063, 1071, 1077, 1085, 1091, 1098, 1109, 1112, 1119, 1125, 1129, 1134, 1144, 1151, 1160, 1165, 1175, 1179, 1188, 1202, 1206, 1208, 1219, 1226, 1230, 1234, 1240, 1247, 1255, 1261, 1265, 1270, 1275, 1281, 1298, 1305, 1312, 1316, 1320, 1325, 1329, 1336, 1340, 1347, 1351, 1357, 1365, 1376, 1385, 1392, 1397, 1406, 1410, 1417, 1422, 1429, 1435, 1439, 1448, 1452, 1460, 1464}
 
 func (i TokenType) String() string {
 	if i < 0 || i >= TokenType(len(_TokenType_index)-1) {